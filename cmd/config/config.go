@@ -0,0 +1,647 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Config agrupa toda la configuración del servicio.
+//
+// Los valores se resuelven con la siguiente precedencia (de menor a mayor):
+// valores por defecto, archivo YAML/TOML, variables de entorno y flags de línea de comandos.
+type Config struct {
+	Server   ServerConfig
+	Database DatabaseConfig
+	Auth     AuthConfig
+	Purge    PurgeConfig
+	Storage  StorageConfig
+	Log      LogConfig
+
+	// Args contiene los argumentos posicionales restantes, por ejemplo el subcomando migrate.
+	Args []string
+}
+
+type ServerConfig struct {
+	Port int
+	// RequireIfMatch exige If-Match en PUT, PATCH y DELETE para evitar que una escritura pise otra.
+	RequireIfMatch bool
+	// PublicURL es la URL con la que los clientes llegan al servidor, como https://api.example.com,
+	// y se usa para armar URLs absolutas como la del avatar. Es obligatoria: tomarla del Host del
+	// request dejaría que un cliente guarde en image_path un host elegido por él.
+	PublicURL string
+	// HealthTimeout limita cada verificación de /readyz, como el ping a la base de datos.
+	HealthTimeout time.Duration
+	// Al recibir SIGTERM el servidor hace fallar /readyz y espera ShutdownDelay, para que el
+	// balanceador deje de enviarle tráfico, antes de cerrar el listener. Después tiene hasta
+	// ShutdownTimeout para terminar los requests en curso; los que sigan abiertos se cortan.
+	ShutdownDelay   time.Duration
+	ShutdownTimeout time.Duration
+}
+
+// Drivers de base de datos soportados por database.driver.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+type DatabaseConfig struct {
+	// Driver es postgres o sqlite. Con sqlite la base es el archivo Path y se ignoran los datos
+	// de conexión a Postgres.
+	Driver string
+	Path   string
+
+	Host     string
+	Port     int
+	Name     string
+	User     string
+	Password string
+	SSLMode  string
+
+	// MaxOpenConns, MaxIdleConns, ConnMaxLifetime y ConnMaxIdleTime dimensionan el pool de
+	// conexiones; un valor 0 deja el comportamiento por defecto de database/sql (sin límite).
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// QueryTimeout limita cada operación de los repositorios; 0 la deja sin límite propio.
+	QueryTimeout time.Duration
+	// SlowQuery es la duración desde la que una consulta se registra como lenta; las demás solo
+	// aparecen con el nivel debug.
+	SlowQuery time.Duration
+}
+
+type AuthConfig struct {
+	// HMACSecret valida tokens HS256.
+	HMACSecret string
+	// JWKSURL y JWKSFile publican las llaves públicas para tokens RS256/ES256.
+	JWKSURL     string
+	JWKSFile    string
+	JWKSRefresh time.Duration
+	Issuer      string
+	Audience    string
+	ClockSkew   time.Duration
+	// AccessTokenTTL y RefreshTokenTTL controlan la vigencia de los tokens que emite /auth/login.
+	// Los access tokens se firman con HMACSecret.
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// PurgeConfig controla la purga de los registros eliminados, que se pueden restaurar hasta que se purgan.
+type PurgeConfig struct {
+	// Retention es cuánto tiempo se conserva un registro eliminado; 0 desactiva la purga.
+	Retention time.Duration
+	// Interval es cada cuánto se ejecuta la purga.
+	Interval time.Duration
+	// UploadTTL es cuánto tiempo tiene un upload por partes para completarse antes de que se
+	// borren sus partes; 0 los conserva hasta que se completan o se cancelan.
+	UploadTTL time.Duration
+}
+
+// LogConfig controla los logs estructurados de la aplicación.
+type LogConfig struct {
+	// Level es debug, info, warn o error.
+	Level string
+	// Format es json o text.
+	Format string
+}
+
+// SlogLevel convierte Level, ya validado, al nivel de log/slog.
+func (l LogConfig) SlogLevel() slog.Level {
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(l.Level))
+	return level
+}
+
+// StorageConfig define dónde se guardan los archivos de los videos y las imágenes de los avatares.
+type StorageConfig struct {
+	// Driver es local, que guarda los archivos en LocalPath, o s3, que los guarda en un bucket S3 o compatible.
+	Driver    string
+	LocalPath string
+	S3        S3Config
+	// MaxUploadSize es el tamaño máximo en bytes de un archivo de video.
+	MaxUploadSize int64
+	// MaxAvatarSize es el tamaño máximo en bytes de la imagen de un avatar.
+	MaxAvatarSize int64
+}
+
+type S3Config struct {
+	// Endpoint es la URL del servicio, por ejemplo https://s3.us-east-1.amazonaws.com o la de un MinIO.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle usa URLs endpoint/bucket/key en lugar de bucket.endpoint/key, como exigen la mayoría de los servicios compatibles.
+	PathStyle bool
+}
+
+// Addr retorna la dirección en la que escucha el servidor HTTP.
+func (s ServerConfig) Addr() string {
+	return fmt.Sprintf(":%d", s.Port)
+}
+
+// DSN retorna la cadena de conexión de Postgres. Cada valor va entre comillas simples, como pide
+// libpq, para que una contraseña con espacios o comillas no corte la cadena ni agregue parámetros.
+func (d DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s sslmode=%s",
+		dsnQuote(d.Host), d.Port, dsnQuote(d.Name), dsnQuote(d.User), dsnQuote(d.Password), dsnQuote(d.SSLMode))
+}
+
+var dsnEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func dsnQuote(value string) string {
+	return "'" + dsnEscaper.Replace(value) + "'"
+}
+
+// setting describe un valor configurable y las fuentes desde las que se puede cargar.
+type setting struct {
+	key   string
+	env   string
+	flag  string
+	usage string
+	apply func(c *Config, value string) error
+}
+
+func stringSetting(key, env, flagName, usage string, field func(c *Config) *string) setting {
+	return setting{key: key, env: env, flag: flagName, usage: usage, apply: func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func intSetting(key, env, flagName, usage string, field func(c *Config) *int) setting {
+	return setting{key: key, env: env, flag: flagName, usage: usage, apply: func(c *Config, value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s must be an integer", key)
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+func int64Setting(key, env, flagName, usage string, field func(c *Config) *int64) setting {
+	return setting{key: key, env: env, flag: flagName, usage: usage, apply: func(c *Config, value string) error {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s must be an integer", key)
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+func boolSetting(key, env, flagName, usage string, field func(c *Config) *bool) setting {
+	return setting{key: key, env: env, flag: flagName, usage: usage, apply: func(c *Config, value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false", key)
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+func durationSetting(key, env, flagName, usage string, field func(c *Config) *time.Duration) setting {
+	return setting{key: key, env: env, flag: flagName, usage: usage, apply: func(c *Config, value string) error {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a duration such as 30s", key)
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+// secretFileSetting lee el valor desde un archivo, como los secretos montados por Kubernetes.
+func secretFileSetting(key, env, flagName, usage string, field func(c *Config) *string) setting {
+	return setting{key: key, env: env, flag: flagName, usage: usage, apply: func(c *Config, value string) error {
+		content, err := os.ReadFile(value)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", key, err)
+		}
+		*field(c) = strings.TrimRight(string(content), "\r\n")
+		return nil
+	}}
+}
+
+var settings = []setting{
+	intSetting("server.port", "SERVER_PORT", "port", "HTTP listen port",
+		func(c *Config) *int { return &c.Server.Port }),
+	boolSetting("server.require_if_match", "SERVER_REQUIRE_IF_MATCH", "require-if-match", "require If-Match on PUT, PATCH and DELETE",
+		func(c *Config) *bool { return &c.Server.RequireIfMatch }),
+	stringSetting("server.public_url", "SERVER_PUBLIC_URL", "public-url", "URL clients use to reach the server, for absolute links such as avatars",
+		func(c *Config) *string { return &c.Server.PublicURL }),
+	durationSetting("server.health_timeout", "SERVER_HEALTH_TIMEOUT", "health-timeout", "maximum duration of each readiness check",
+		func(c *Config) *time.Duration { return &c.Server.HealthTimeout }),
+	durationSetting("server.shutdown_delay", "SERVER_SHUTDOWN_DELAY", "shutdown-delay", "time between failing readiness and closing the listener on shutdown",
+		func(c *Config) *time.Duration { return &c.Server.ShutdownDelay }),
+	durationSetting("server.shutdown_timeout", "SERVER_SHUTDOWN_TIMEOUT", "shutdown-timeout", "maximum time to finish in-flight requests on shutdown",
+		func(c *Config) *time.Duration { return &c.Server.ShutdownTimeout }),
+
+	stringSetting("database.driver", "DB_DRIVER", "db-driver", "database driver: postgres or sqlite",
+		func(c *Config) *string { return &c.Database.Driver }),
+	stringSetting("database.path", "DB_PATH", "db-path", "SQLite database file",
+		func(c *Config) *string { return &c.Database.Path }),
+	stringSetting("database.host", "DB_HOST", "db-host", "database host",
+		func(c *Config) *string { return &c.Database.Host }),
+	intSetting("database.port", "DB_PORT", "db-port", "database port",
+		func(c *Config) *int { return &c.Database.Port }),
+	stringSetting("database.name", "DB_NAME", "db-name", "database name",
+		func(c *Config) *string { return &c.Database.Name }),
+	stringSetting("database.user", "DB_USER", "db-user", "database user",
+		func(c *Config) *string { return &c.Database.User }),
+	stringSetting("database.password", "DB_PASSWORD", "db-password", "database password",
+		func(c *Config) *string { return &c.Database.Password }),
+	secretFileSetting("database.password_file", "DB_PASSWORD_FILE", "db-password-file", "file containing the database password",
+		func(c *Config) *string { return &c.Database.Password }),
+	stringSetting("database.sslmode", "DB_SSLMODE", "db-sslmode", "database sslmode",
+		func(c *Config) *string { return &c.Database.SSLMode }),
+	intSetting("database.max_open_conns", "DB_MAX_OPEN_CONNS", "db-max-open-conns", "maximum number of open database connections",
+		func(c *Config) *int { return &c.Database.MaxOpenConns }),
+	intSetting("database.max_idle_conns", "DB_MAX_IDLE_CONNS", "db-max-idle-conns", "maximum number of idle database connections",
+		func(c *Config) *int { return &c.Database.MaxIdleConns }),
+	durationSetting("database.conn_max_lifetime", "DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "maximum lifetime of a database connection",
+		func(c *Config) *time.Duration { return &c.Database.ConnMaxLifetime }),
+	durationSetting("database.conn_max_idle_time", "DB_CONN_MAX_IDLE_TIME", "db-conn-max-idle-time", "maximum idle time of a database connection",
+		func(c *Config) *time.Duration { return &c.Database.ConnMaxIdleTime }),
+	durationSetting("database.query_timeout", "DB_QUERY_TIMEOUT", "db-query-timeout", "maximum duration of a repository operation",
+		func(c *Config) *time.Duration { return &c.Database.QueryTimeout }),
+	durationSetting("database.slow_query", "DB_SLOW_QUERY", "db-slow-query", "duration from which a query is logged as slow",
+		func(c *Config) *time.Duration { return &c.Database.SlowQuery }),
+
+	stringSetting("auth.hmac_secret", "AUTH_HMAC_SECRET", "auth-hmac-secret", "secret used to validate HS256 tokens",
+		func(c *Config) *string { return &c.Auth.HMACSecret }),
+	secretFileSetting("auth.hmac_secret_file", "AUTH_HMAC_SECRET_FILE", "auth-hmac-secret-file", "file containing the HS256 secret",
+		func(c *Config) *string { return &c.Auth.HMACSecret }),
+	stringSetting("auth.jwks_url", "AUTH_JWKS_URL", "auth-jwks-url", "URL of the JWKS used to validate RS256/ES256 tokens",
+		func(c *Config) *string { return &c.Auth.JWKSURL }),
+	stringSetting("auth.jwks_file", "AUTH_JWKS_FILE", "auth-jwks-file", "local JWKS file used to validate RS256/ES256 tokens",
+		func(c *Config) *string { return &c.Auth.JWKSFile }),
+	durationSetting("auth.jwks_refresh", "AUTH_JWKS_REFRESH", "auth-jwks-refresh", "how long JWKS keys are cached",
+		func(c *Config) *time.Duration { return &c.Auth.JWKSRefresh }),
+	stringSetting("auth.issuer", "AUTH_ISSUER", "auth-issuer", "expected iss claim",
+		func(c *Config) *string { return &c.Auth.Issuer }),
+	stringSetting("auth.audience", "AUTH_AUDIENCE", "auth-audience", "expected aud claim",
+		func(c *Config) *string { return &c.Auth.Audience }),
+	durationSetting("auth.clock_skew", "AUTH_CLOCK_SKEW", "auth-clock-skew", "tolerance applied to exp/nbf/iat",
+		func(c *Config) *time.Duration { return &c.Auth.ClockSkew }),
+	durationSetting("auth.access_token_ttl", "AUTH_ACCESS_TOKEN_TTL", "auth-access-token-ttl", "lifetime of issued access tokens",
+		func(c *Config) *time.Duration { return &c.Auth.AccessTokenTTL }),
+	durationSetting("auth.refresh_token_ttl", "AUTH_REFRESH_TOKEN_TTL", "auth-refresh-token-ttl", "lifetime of issued refresh tokens",
+		func(c *Config) *time.Duration { return &c.Auth.RefreshTokenTTL }),
+
+	durationSetting("purge.retention", "PURGE_RETENTION", "purge-retention", "how long deleted records can be restored before they are purged (0 disables purging)",
+		func(c *Config) *time.Duration { return &c.Purge.Retention }),
+	durationSetting("purge.interval", "PURGE_INTERVAL", "purge-interval", "how often deleted records are purged",
+		func(c *Config) *time.Duration { return &c.Purge.Interval }),
+	durationSetting("purge.upload_ttl", "PURGE_UPLOAD_TTL", "purge-upload-ttl", "how long a resumable upload has to complete before its parts are removed (0 keeps them)",
+		func(c *Config) *time.Duration { return &c.Purge.UploadTTL }),
+	stringSetting("log.level", "LOG_LEVEL", "log-level", "minimum level written to the log: debug, info, warn or error",
+		func(c *Config) *string { return &c.Log.Level }),
+	stringSetting("log.format", "LOG_FORMAT", "log-format", "log format: json or text",
+		func(c *Config) *string { return &c.Log.Format }),
+
+	stringSetting("storage.driver", "STORAGE_DRIVER", "storage-driver", "where video files are stored: local or s3",
+		func(c *Config) *string { return &c.Storage.Driver }),
+	stringSetting("storage.local_path", "STORAGE_LOCAL_PATH", "storage-local-path", "directory of the local storage driver",
+		func(c *Config) *string { return &c.Storage.LocalPath }),
+	int64Setting("storage.max_upload_size", "STORAGE_MAX_UPLOAD_SIZE", "storage-max-upload-size", "maximum size of a video file in bytes",
+		func(c *Config) *int64 { return &c.Storage.MaxUploadSize }),
+	int64Setting("storage.max_avatar_size", "STORAGE_MAX_AVATAR_SIZE", "storage-max-avatar-size", "maximum size of an avatar image in bytes",
+		func(c *Config) *int64 { return &c.Storage.MaxAvatarSize }),
+	stringSetting("storage.s3.endpoint", "STORAGE_S3_ENDPOINT", "storage-s3-endpoint", "URL of the S3 compatible service",
+		func(c *Config) *string { return &c.Storage.S3.Endpoint }),
+	stringSetting("storage.s3.region", "STORAGE_S3_REGION", "storage-s3-region", "region used to sign S3 requests",
+		func(c *Config) *string { return &c.Storage.S3.Region }),
+	stringSetting("storage.s3.bucket", "STORAGE_S3_BUCKET", "storage-s3-bucket", "bucket that holds the video files",
+		func(c *Config) *string { return &c.Storage.S3.Bucket }),
+	stringSetting("storage.s3.access_key", "STORAGE_S3_ACCESS_KEY", "storage-s3-access-key", "S3 access key id",
+		func(c *Config) *string { return &c.Storage.S3.AccessKey }),
+	stringSetting("storage.s3.secret_key", "STORAGE_S3_SECRET_KEY", "storage-s3-secret-key", "S3 secret access key",
+		func(c *Config) *string { return &c.Storage.S3.SecretKey }),
+	secretFileSetting("storage.s3.secret_key_file", "STORAGE_S3_SECRET_KEY_FILE", "storage-s3-secret-key-file", "file containing the S3 secret access key",
+		func(c *Config) *string { return &c.Storage.S3.SecretKey }),
+	boolSetting("storage.s3.path_style", "STORAGE_S3_PATH_STYLE", "storage-s3-path-style", "address the bucket in the URL path instead of the host name",
+		func(c *Config) *bool { return &c.Storage.S3.PathStyle }),
+}
+
+func defaults() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:            8086,
+			RequireIfMatch:  true,
+			HealthTimeout:   2 * time.Second,
+			ShutdownTimeout: 20 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:  DriverPostgres,
+			Path:    "task.db",
+			Host:    "localhost",
+			Port:    5432,
+			Name:    "talentpitch",
+			SSLMode: "disable",
+
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			QueryTimeout:    5 * time.Second,
+			SlowQuery:       200 * time.Millisecond,
+		},
+		Auth: AuthConfig{
+			JWKSRefresh:     10 * time.Minute,
+			ClockSkew:       30 * time.Second,
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 30 * 24 * time.Hour,
+		},
+		Purge: PurgeConfig{
+			Retention: 30 * 24 * time.Hour,
+			Interval:  time.Hour,
+			UploadTTL: 24 * time.Hour,
+		},
+		Storage: StorageConfig{
+			Driver:        "local",
+			LocalPath:     "data/storage",
+			MaxUploadSize: 2 << 30,
+			MaxAvatarSize: 5 << 20,
+			S3: S3Config{
+				Region:    "us-east-1",
+				PathStyle: true,
+			},
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+	}
+}
+
+// Load construye la configuración a partir de los argumentos de línea de comandos y el entorno del proceso.
+func Load(args []string) (*Config, error) {
+	return load(args, os.LookupEnv)
+}
+
+func load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	fs := flag.NewFlagSet("crudplatform", flag.ContinueOnError)
+	configFile := fs.String("config", "", "path to a YAML or TOML configuration file (env CONFIG_FILE)")
+	for _, s := range settings {
+		fs.String(s.flag, "", fmt.Sprintf("%s (env %s)", s.usage, s.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := defaults()
+
+	path := *configFile
+	if path == "" {
+		path, _ = lookupEnv("CONFIG_FILE")
+	}
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range settings {
+			if value, ok := values[s.key]; ok {
+				if err := s.apply(cfg, value); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := lookupEnv(s.env); ok && value != "" {
+			if err := s.apply(cfg, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	flagged := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		flagged[f.Name] = f.Value.String()
+	})
+	for _, s := range settings {
+		if value, ok := flagged[s.flag]; ok {
+			if err := s.apply(cfg, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cfg.Args = fs.Args()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// readFile lee un archivo YAML o TOML y lo aplana en claves con puntos, por ejemplo database.host.
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	raw := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file format %s", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config file: %w", err)
+	}
+
+	values := map[string]string{}
+	flatten("", raw, values)
+	return values, nil
+}
+
+func flatten(prefix string, raw map[string]any, values map[string]string) {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			flatten(key, nested, values)
+			continue
+		}
+		values[key] = fmt.Sprint(value)
+	}
+}
+
+// Validate verifica que los valores obligatorios estén presentes y sean coherentes.
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.Server.Port <= 0 || c.Server.Port > 65535 {
+		problems = append(problems, "server.port must be between 1 and 65535")
+	}
+	if c.Server.PublicURL == "" {
+		problems = append(problems, "server.public_url is required")
+	} else if public, err := url.Parse(c.Server.PublicURL); err != nil || (public.Scheme != "http" && public.Scheme != "https") || public.Host == "" {
+		problems = append(problems, "server.public_url must be an http or https URL")
+	}
+	if c.Database.Port <= 0 || c.Database.Port > 65535 {
+		problems = append(problems, "database.port must be between 1 and 65535")
+	}
+
+	if c.Server.HealthTimeout <= 0 {
+		problems = append(problems, "server.health_timeout must be positive")
+	}
+	if c.Server.ShutdownDelay < 0 {
+		problems = append(problems, "server.shutdown_delay must not be negative")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		problems = append(problems, "server.shutdown_timeout must be positive")
+	}
+
+	if c.Database.MaxOpenConns < 0 {
+		problems = append(problems, "database.max_open_conns must not be negative")
+	}
+	if c.Database.MaxIdleConns < 0 {
+		problems = append(problems, "database.max_idle_conns must not be negative")
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		problems = append(problems, "database.max_idle_conns must not exceed database.max_open_conns")
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		problems = append(problems, "database.conn_max_lifetime must not be negative")
+	}
+	if c.Database.ConnMaxIdleTime < 0 {
+		problems = append(problems, "database.conn_max_idle_time must not be negative")
+	}
+	if c.Database.QueryTimeout < 0 {
+		problems = append(problems, "database.query_timeout must not be negative")
+	}
+	if c.Database.SlowQuery < 0 {
+		problems = append(problems, "database.slow_query must not be negative")
+	}
+
+	required := map[string]string{}
+	switch c.Database.Driver {
+	case DriverPostgres:
+		required["database.host"] = c.Database.Host
+		required["database.name"] = c.Database.Name
+		required["database.user"] = c.Database.User
+		required["database.password"] = c.Database.Password
+	case DriverSQLite:
+		required["database.path"] = c.Database.Path
+	default:
+		problems = append(problems, "database.driver must be postgres or sqlite")
+	}
+	for key, value := range required {
+		if value == "" {
+			problems = append(problems, key+" is required")
+		}
+	}
+
+	if c.Auth.HMACSecret == "" && c.Auth.JWKSURL == "" && c.Auth.JWKSFile == "" {
+		problems = append(problems, "one of auth.hmac_secret, auth.jwks_url or auth.jwks_file is required")
+	}
+	if c.Auth.JWKSURL != "" && c.Auth.JWKSFile != "" {
+		problems = append(problems, "auth.jwks_url and auth.jwks_file are mutually exclusive")
+	}
+	if c.Auth.JWKSURL != "" && c.Auth.JWKSRefresh <= 0 {
+		problems = append(problems, "auth.jwks_refresh must be positive")
+	}
+	if c.Purge.Retention < 0 {
+		problems = append(problems, "purge.retention must not be negative")
+	}
+	if c.Purge.Interval <= 0 {
+		problems = append(problems, "purge.interval must be positive")
+	}
+	if c.Purge.UploadTTL < 0 {
+		problems = append(problems, "purge.upload_ttl must not be negative")
+	}
+
+	problems = append(problems, c.Storage.problems()...)
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, "log.level must be debug, info, warn or error")
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		problems = append(problems, "log.format must be json or text")
+	}
+
+	if c.Auth.ClockSkew < 0 {
+		problems = append(problems, "auth.clock_skew must not be negative")
+	}
+	if c.Auth.AccessTokenTTL <= 0 {
+		problems = append(problems, "auth.access_token_ttl must be positive")
+	}
+	if c.Auth.RefreshTokenTTL <= 0 {
+		problems = append(problems, "auth.refresh_token_ttl must be positive")
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return errors.New("invalid configuration: " + strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func (s StorageConfig) problems() []string {
+	var problems []string
+
+	if s.MaxUploadSize <= 0 {
+		problems = append(problems, "storage.max_upload_size must be positive")
+	}
+	if s.MaxAvatarSize <= 0 {
+		problems = append(problems, "storage.max_avatar_size must be positive")
+	}
+
+	switch s.Driver {
+	case "local":
+		if s.LocalPath == "" {
+			problems = append(problems, "storage.local_path is required")
+		}
+	case "s3":
+		required := map[string]string{
+			"storage.s3.endpoint":   s.S3.Endpoint,
+			"storage.s3.region":     s.S3.Region,
+			"storage.s3.bucket":     s.S3.Bucket,
+			"storage.s3.access_key": s.S3.AccessKey,
+			"storage.s3.secret_key": s.S3.SecretKey,
+		}
+		for key, value := range required {
+			if value == "" {
+				problems = append(problems, key+" is required")
+			}
+		}
+		if s.S3.Endpoint != "" {
+			if endpoint, err := url.Parse(s.S3.Endpoint); err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+				problems = append(problems, "storage.s3.endpoint must be an http or https URL")
+			}
+		}
+	default:
+		problems = append(problems, "storage.driver must be local or s3")
+	}
+
+	return problems
+}
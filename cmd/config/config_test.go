@@ -0,0 +1,418 @@
+package config
+
+import (
+	"bufio"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func envFrom(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+var requiredEnv = map[string]string{
+	"SERVER_PUBLIC_URL": "http://localhost:8086",
+	"DB_USER":           "app",
+	"DB_PASSWORD":       "secret",
+	"AUTH_HMAC_SECRET":  "secret",
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg, err := load(nil, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.Equal(t, 8086, cfg.Server.Port)
+		assert.Equal(t, ":8086", cfg.Server.Addr())
+		assert.True(t, cfg.Server.RequireIfMatch)
+		assert.Equal(t, "localhost", cfg.Database.Host)
+		assert.Equal(t, 5432, cfg.Database.Port)
+		assert.Equal(t, "app", cfg.Database.User)
+		assert.Equal(t, "host='localhost' port=5432 dbname='talentpitch' user='app' password='secret' sslmode='disable'", cfg.Database.DSN())
+	})
+
+	t.Run("DSNQuoting", func(t *testing.T) {
+		// Una contraseña leída de DB_PASSWORD_FILE puede traer espacios, comillas y barras.
+		passwordFile := writeFile(t, "password", `it's a \secret sslmode=disable`)
+		env := map[string]string{"DB_PASSWORD_FILE": passwordFile}
+		for k, v := range requiredEnv {
+			if k != "DB_PASSWORD" {
+				env[k] = v
+			}
+		}
+
+		cfg, err := load([]string{"-db-sslmode", "require"}, envFrom(env))
+		require.NoError(t, err)
+		assert.Equal(t, `host='localhost' port=5432 dbname='talentpitch' user='app' password='it\'s a \\secret sslmode=disable' sslmode='require'`, cfg.Database.DSN())
+	})
+
+	t.Run("Precedence", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", `
+server:
+  port: 9000
+database:
+  host: file-host
+  name: file-db
+`)
+		env := map[string]string{"DB_HOST": "env-host", "CONFIG_FILE": path}
+		for k, v := range requiredEnv {
+			env[k] = v
+		}
+
+		cfg, err := load([]string{"-db-name", "flag-db"}, envFrom(env))
+		require.NoError(t, err)
+		assert.Equal(t, 9000, cfg.Server.Port)
+		assert.Equal(t, "env-host", cfg.Database.Host)
+		assert.Equal(t, "flag-db", cfg.Database.Name)
+	})
+
+	t.Run("TOML", func(t *testing.T) {
+		path := writeFile(t, "config.toml", `
+[database]
+host = "toml-host"
+port = 6543
+`)
+
+		cfg, err := load([]string{"-config", path}, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.Equal(t, "toml-host", cfg.Database.Host)
+		assert.Equal(t, 6543, cfg.Database.Port)
+	})
+
+	t.Run("SecretFiles", func(t *testing.T) {
+		passwordFile := writeFile(t, "password", "from-file\n")
+		secretFile := writeFile(t, "hmac", "hmac-from-file\n")
+
+		cfg, err := load(nil, envFrom(map[string]string{
+			"SERVER_PUBLIC_URL":     "http://localhost:8086",
+			"DB_USER":               "app",
+			"DB_PASSWORD_FILE":      passwordFile,
+			"AUTH_HMAC_SECRET_FILE": secretFile,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "from-file", cfg.Database.Password)
+		assert.Equal(t, "hmac-from-file", cfg.Auth.HMACSecret)
+	})
+
+	t.Run("PositionalArgs", func(t *testing.T) {
+		cfg, err := load([]string{"-port", "9100", "migrate", "up"}, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.Equal(t, 9100, cfg.Server.Port)
+		assert.Equal(t, []string{"migrate", "up"}, cfg.Args)
+	})
+
+	t.Run("MissingRequired", func(t *testing.T) {
+		_, err := load(nil, envFrom(nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database.user is required")
+		assert.Contains(t, err.Error(), "database.password is required")
+		assert.Contains(t, err.Error(), "one of auth.hmac_secret, auth.jwks_url or auth.jwks_file is required")
+	})
+
+	t.Run("InvalidPort", func(t *testing.T) {
+		env := map[string]string{"SERVER_PORT": "abc"}
+		for k, v := range requiredEnv {
+			env[k] = v
+		}
+
+		_, err := load(nil, envFrom(env))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server.port must be an integer")
+	})
+
+	t.Run("AuthSettings", func(t *testing.T) {
+		env := map[string]string{
+			"SERVER_PUBLIC_URL": "http://localhost:8086",
+			"DB_USER":           "app",
+			"DB_PASSWORD":       "secret",
+			"AUTH_JWKS_URL":     "https://issuer.example.com/.well-known/jwks.json",
+			"AUTH_ISSUER":       "https://issuer.example.com/",
+			"AUTH_AUDIENCE":     "crudplatform",
+			"AUTH_CLOCK_SKEW":   "1m",
+		}
+
+		cfg, err := load(nil, envFrom(env))
+		require.NoError(t, err)
+		assert.Equal(t, "https://issuer.example.com/", cfg.Auth.Issuer)
+		assert.Equal(t, time.Minute, cfg.Auth.ClockSkew)
+		assert.Equal(t, 10*time.Minute, cfg.Auth.JWKSRefresh)
+		assert.Equal(t, 15*time.Minute, cfg.Auth.AccessTokenTTL)
+
+		// Sin caché, cada request volvería a descargar el JWKS.
+		for _, refresh := range []string{"0s", "-1m"} {
+			env["AUTH_JWKS_REFRESH"] = refresh
+			_, err = load(nil, envFrom(env))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "auth.jwks_refresh must be positive")
+		}
+	})
+
+	t.Run("InvalidTokenTTL", func(t *testing.T) {
+		env := map[string]string{"AUTH_REFRESH_TOKEN_TTL": "0s"}
+		for k, v := range requiredEnv {
+			env[k] = v
+		}
+
+		_, err := load(nil, envFrom(env))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "auth.refresh_token_ttl must be positive")
+	})
+
+	t.Run("Pool", func(t *testing.T) {
+		env := map[string]string{"DB_MAX_OPEN_CONNS": "50", "DB_CONN_MAX_IDLE_TIME": "1m"}
+		for k, v := range requiredEnv {
+			env[k] = v
+		}
+
+		cfg, err := load([]string{"-db-max-idle-conns", "10"}, envFrom(env))
+		require.NoError(t, err)
+		assert.Equal(t, 50, cfg.Database.MaxOpenConns)
+		assert.Equal(t, 10, cfg.Database.MaxIdleConns)
+		assert.Equal(t, 30*time.Minute, cfg.Database.ConnMaxLifetime)
+		assert.Equal(t, time.Minute, cfg.Database.ConnMaxIdleTime)
+		assert.Equal(t, 5*time.Second, cfg.Database.QueryTimeout)
+	})
+
+	t.Run("InvalidPool", func(t *testing.T) {
+		env := map[string]string{"DB_MAX_OPEN_CONNS": "5", "DB_MAX_IDLE_CONNS": "10"}
+		for k, v := range requiredEnv {
+			env[k] = v
+		}
+
+		_, err := load(nil, envFrom(env))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database.max_idle_conns must not exceed database.max_open_conns")
+	})
+
+	t.Run("InvalidDuration", func(t *testing.T) {
+		env := map[string]string{"AUTH_CLOCK_SKEW": "soon"}
+		for k, v := range requiredEnv {
+			env[k] = v
+		}
+
+		_, err := load(nil, envFrom(env))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "auth.clock_skew must be a duration")
+	})
+
+	t.Run("RequireIfMatch", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", `
+server:
+  require_if_match: false
+`)
+		cfg, err := load([]string{"-config", path}, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.False(t, cfg.Server.RequireIfMatch)
+
+		env := map[string]string{"SERVER_REQUIRE_IF_MATCH": "maybe"}
+		for k, v := range requiredEnv {
+			env[k] = v
+		}
+		_, err = load(nil, envFrom(env))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server.require_if_match must be true or false")
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		cfg, err := load([]string{"-purge-retention", "0s"}, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.Zero(t, cfg.Purge.Retention)
+		assert.Equal(t, time.Hour, cfg.Purge.Interval)
+		assert.Equal(t, 24*time.Hour, cfg.Purge.UploadTTL)
+
+		_, err = load([]string{"-purge-retention", "-1h", "-purge-interval", "0s", "-purge-upload-ttl", "-1h"}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "purge.retention must not be negative")
+		assert.Contains(t, err.Error(), "purge.interval must be positive")
+		assert.Contains(t, err.Error(), "purge.upload_ttl must not be negative")
+	})
+
+	t.Run("Storage", func(t *testing.T) {
+		cfg, err := load(nil, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.Equal(t, "local", cfg.Storage.Driver)
+		assert.Equal(t, "data/storage", cfg.Storage.LocalPath)
+		assert.Equal(t, int64(5<<20), cfg.Storage.MaxAvatarSize)
+
+		env := map[string]string{"STORAGE_DRIVER": "s3", "STORAGE_S3_ENDPOINT": "http://minio:9000", "STORAGE_S3_BUCKET": "videos",
+			"STORAGE_S3_ACCESS_KEY": "key", "STORAGE_S3_SECRET_KEY": "secret", "STORAGE_MAX_UPLOAD_SIZE": "1048576"}
+		for key, value := range requiredEnv {
+			env[key] = value
+		}
+		cfg, err = load(nil, envFrom(env))
+		require.NoError(t, err)
+		assert.Equal(t, "http://minio:9000", cfg.Storage.S3.Endpoint)
+		assert.Equal(t, int64(1048576), cfg.Storage.MaxUploadSize)
+		assert.True(t, cfg.Storage.S3.PathStyle)
+
+		_, err = load([]string{"-storage-driver", "s3", "-storage-s3-endpoint", "minio:9000"}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "storage.s3.bucket is required")
+		assert.Contains(t, err.Error(), "storage.s3.endpoint must be an http or https URL")
+
+		_, err = load([]string{"-storage-driver", "gcs"}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "storage.driver must be local or s3")
+	})
+
+	t.Run("PublicURL", func(t *testing.T) {
+		cfg, err := load([]string{"-public-url", "https://api.example.com"}, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.Equal(t, "https://api.example.com", cfg.Server.PublicURL)
+
+		_, err = load([]string{"-public-url", "api.example.com"}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server.public_url must be an http or https URL")
+
+		_, err = load([]string{"-public-url", ""}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server.public_url is required")
+	})
+
+	t.Run("HealthTimeout", func(t *testing.T) {
+		cfg, err := load([]string{"-health-timeout", "500ms"}, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.Equal(t, 500*time.Millisecond, cfg.Server.HealthTimeout)
+
+		_, err = load([]string{"-health-timeout", "0s"}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server.health_timeout must be positive")
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		cfg, err := load(nil, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(0), cfg.Server.ShutdownDelay)
+		assert.Equal(t, 20*time.Second, cfg.Server.ShutdownTimeout)
+
+		env := map[string]string{"SERVER_SHUTDOWN_TIMEOUT": "25s"}
+		for k, v := range requiredEnv {
+			env[k] = v
+		}
+		cfg, err = load([]string{"-shutdown-delay", "10s"}, envFrom(env))
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Second, cfg.Server.ShutdownDelay)
+		assert.Equal(t, 25*time.Second, cfg.Server.ShutdownTimeout)
+
+		_, err = load([]string{"-shutdown-delay", "-1s", "-shutdown-timeout", "0s"}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server.shutdown_delay must not be negative")
+		assert.Contains(t, err.Error(), "server.shutdown_timeout must be positive")
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		cfg, err := load([]string{"-db-driver", "sqlite", "-db-path", "/tmp/demo.db"}, envFrom(map[string]string{"SERVER_PUBLIC_URL": "http://localhost:8086", "AUTH_HMAC_SECRET": "secret"}))
+		require.NoError(t, err)
+		assert.Equal(t, DriverSQLite, cfg.Database.Driver)
+		assert.Equal(t, "/tmp/demo.db", cfg.Database.Path)
+
+		_, err = load([]string{"-db-driver", "sqlite", "-db-path", ""}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database.path is required")
+
+		_, err = load([]string{"-db-driver", "mysql"}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database.driver must be postgres or sqlite")
+	})
+
+	t.Run("Log", func(t *testing.T) {
+		cfg, err := load(nil, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.Equal(t, LogConfig{Level: "info", Format: "json"}, cfg.Log)
+		assert.Equal(t, 200*time.Millisecond, cfg.Database.SlowQuery)
+
+		cfg, err = load([]string{"-log-level", "debug", "-log-format", "text", "-db-slow-query", "1s"}, envFrom(requiredEnv))
+		require.NoError(t, err)
+		assert.Equal(t, slog.LevelDebug, cfg.Log.SlogLevel())
+		assert.Equal(t, "text", cfg.Log.Format)
+		assert.Equal(t, time.Second, cfg.Database.SlowQuery)
+
+		_, err = load([]string{"-log-level", "verbose", "-log-format", "xml"}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "log.level must be debug, info, warn or error")
+		assert.Contains(t, err.Error(), "log.format must be json or text")
+	})
+
+	t.Run("UnsupportedFile", func(t *testing.T) {
+		path := writeFile(t, "config.json", "{}")
+
+		_, err := load([]string{"-config", path}, envFrom(requiredEnv))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported config file format")
+	})
+}
+
+// imageEnv retorna las variables ENV de la última etapa del Dockerfile.
+func imageEnv(t *testing.T, path string) map[string]string {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	env := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "FROM ") {
+			env = map[string]string{}
+		}
+		if key, value, ok := strings.Cut(strings.TrimPrefix(line, "ENV "), "="); ok && strings.HasPrefix(line, "ENV ") {
+			env[key] = value
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return env
+}
+
+// La configuración que recibe el pod es la del Dockerfile pisada por la del deployment; si el
+// deployment deja de fijar algo, la imagen no debe cambiar en silencio a otra base de datos.
+func TestDeploymentEnv(t *testing.T) {
+	content, err := os.ReadFile("../../k8s/deployment.yaml")
+	require.NoError(t, err)
+	var deployment struct {
+		Spec struct {
+			Template struct {
+				Spec struct {
+					Containers []struct {
+						Env []struct {
+							Name  string `yaml:"name"`
+							Value string `yaml:"value"`
+						} `yaml:"env"`
+					} `yaml:"containers"`
+				} `yaml:"spec"`
+			} `yaml:"template"`
+		} `yaml:"spec"`
+	}
+	require.NoError(t, yaml.Unmarshal(content, &deployment))
+	require.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+
+	env := imageEnv(t, "../../Dockerfile")
+	for _, variable := range deployment.Spec.Template.Spec.Containers[0].Env {
+		env[variable.Name] = variable.Value
+		// Los secretos montados no existen fuera del cluster.
+		if strings.HasSuffix(variable.Name, "_FILE") {
+			env[variable.Name] = writeFile(t, variable.Name, "secret")
+		}
+	}
+
+	cfg, err := load(nil, envFrom(env))
+	require.NoError(t, err)
+	assert.Equal(t, DriverPostgres, cfg.Database.Driver)
+	assert.Equal(t, "postgres", cfg.Database.Host)
+	assert.Equal(t, 8086, cfg.Server.Port)
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"CrudPlatform/cmd/config"
+	"CrudPlatform/internal/core/domain/logging"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigurePool(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	configurePool(db, config.DatabaseConfig{MaxOpenConns: 8, MaxIdleConns: 4, ConnMaxLifetime: time.Minute, ConnMaxIdleTime: time.Second})
+
+	assert.Equal(t, 8, db.Stats().MaxOpenConnections)
+}
+
+func TestQueryLogConnector(t *testing.T) {
+	mockDB, mock, err := sqlmock.NewWithDSN("querylog")
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	db := sql.OpenDB(&queryLogConnector{Connector: dsnConnector{dsn: "querylog", driver: mockDB.Driver()}, slow: time.Hour})
+	defer db.Close()
+
+	var buf bytes.Buffer
+	ctx := logging.WithAttrs(logging.With(context.Background(), logging.New(&buf, "json", slog.LevelDebug)), "video_id", "7")
+
+	mock.ExpectQuery("SELECT id").WithArgs("secret").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec("DELETE FROM videos").WillReturnError(errors.New("boom"))
+
+	var id int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT id\n\t FROM videos WHERE name = $1", "secret").Scan(&id))
+	_, err = db.ExecContext(ctx, "DELETE FROM videos")
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "DEBUG", entry["level"])
+	assert.Equal(t, "SELECT id FROM videos WHERE name = $1", entry["query"])
+	assert.Equal(t, "7", entry["video_id"])
+	assert.NotContains(t, lines[0], "secret")
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "query failed", entry["msg"])
+	assert.Equal(t, "boom", entry["error"])
+}
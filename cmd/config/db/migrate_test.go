@@ -0,0 +1,192 @@
+package db
+
+import (
+	"CrudPlatform/cmd/config"
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("Embedded", func(t *testing.T) {
+		migrations, err := loadMigrations(migrationFiles, "migrations/postgres")
+		require.NoError(t, err)
+		require.NotEmpty(t, migrations)
+		assert.Equal(t, 1, migrations[0].Version)
+		assert.Equal(t, "create_tables", migrations[0].Name)
+		assert.Contains(t, migrations[0].Up, "CREATE TABLE IF NOT EXISTS users")
+		assert.Contains(t, migrations[0].Down, "DROP TABLE IF EXISTS users")
+	})
+
+	t.Run("Sorted", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"m/0002_second.up.sql":   {Data: []byte("up 2")},
+			"m/0002_second.down.sql": {Data: []byte("down 2")},
+			"m/0001_first.up.sql":    {Data: []byte("up 1")},
+			"m/0001_first.down.sql":  {Data: []byte("down 1")},
+		}
+
+		migrations, err := loadMigrations(fsys, "m")
+		require.NoError(t, err)
+		require.Len(t, migrations, 2)
+		assert.Equal(t, 1, migrations[0].Version)
+		assert.Equal(t, 2, migrations[1].Version)
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		fsys := fstest.MapFS{"m/first.sql": {Data: []byte("up")}}
+
+		_, err := loadMigrations(fsys, "m")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid migration file name")
+	})
+
+	t.Run("MissingDown", func(t *testing.T) {
+		fsys := fstest.MapFS{"m/0001_first.up.sql": {Data: []byte("up")}}
+
+		_, err := loadMigrations(fsys, "m")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must have both up and down files")
+	})
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return &Migrator{
+		db:     db,
+		driver: config.DriverPostgres,
+		migrations: []Migration{
+			{Version: 1, Name: "first", Up: "CREATE TABLE first", Down: "DROP TABLE first"},
+			{Version: 2, Name: "second", Up: "CREATE TABLE second", Down: "DROP TABLE second"},
+		},
+	}, mock
+}
+
+func expectLock(mock sqlmock.Sqlmock, applied ...int) {
+	mock.ExpectExec("SELECT pg_advisory_lock").WithArgs(migrationLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range applied {
+		rows.AddRow(version, time.Now())
+	}
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").WillReturnRows(rows)
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Up", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+		expectLock(mock, 1)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("CREATE TABLE second").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO schema_migrations").
+			WithArgs(2, "second", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(migrationLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, migrator.Up(ctx))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Up_ApplyError", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+		expectLock(mock)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("CREATE TABLE first").WillReturnError(fmt.Errorf("syntax error"))
+		mock.ExpectRollback()
+		mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := migrator.Up(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error applying migration 1_first")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Down", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+		expectLock(mock, 1, 2)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DROP TABLE second").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM schema_migrations WHERE version = \\$1").
+			WithArgs(2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, migrator.Down(ctx))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("To_Zero", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+		expectLock(mock, 1, 2)
+
+		for _, step := range []struct {
+			version int
+			down    string
+		}{{2, "DROP TABLE second"}, {1, "DROP TABLE first"}} {
+			mock.ExpectBegin()
+			mock.ExpectExec(step.down).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(step.version).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}
+		mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, migrator.To(ctx, 0))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("To_UnknownVersion", func(t *testing.T) {
+		migrator, _ := newTestMigrator(t)
+
+		err := migrator.To(ctx, 9)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown migration version 9")
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+
+		status, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		require.Len(t, status, 2)
+		assert.True(t, status[0].Applied)
+		assert.NotNil(t, status[0].AppliedAt)
+		assert.False(t, status[1].Applied)
+	})
+
+	t.Run("CheckApplied", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+		mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()).AddRow(2, time.Now()))
+
+		assert.NoError(t, migrator.CheckApplied(ctx))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("CheckApplied_Pending", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+		mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").
+			WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+
+		assert.EqualError(t, migrator.CheckApplied(ctx), "pending migrations: 2")
+	})
+}
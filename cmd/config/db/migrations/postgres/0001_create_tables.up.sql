@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS users (
+	id TEXT PRIMARY KEY,
+	name TEXT,
+	email TEXT UNIQUE,
+	image_path TEXT,
+	created_at TIMESTAMP,
+	updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS challenges (
+	id TEXT PRIMARY KEY,
+	title TEXT,
+	description TEXT,
+	difficulty INTEGER,
+	created_at TIMESTAMP,
+	updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS videos (
+	id TEXT PRIMARY KEY,
+	title TEXT,
+	description TEXT,
+	created_at TIMESTAMP,
+	updated_at TIMESTAMP
+);
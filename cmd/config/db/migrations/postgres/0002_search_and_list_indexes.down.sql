@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS videos_created_at_id_idx;
+DROP INDEX IF EXISTS challenges_created_at_id_idx;
+DROP INDEX IF EXISTS users_created_at_id_idx;
+
+DROP INDEX IF EXISTS videos_search_vector_idx;
+DROP INDEX IF EXISTS challenges_search_vector_idx;
+
+ALTER TABLE videos DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE challenges DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,12 @@
+ALTER TABLE challenges ADD COLUMN search_vector tsvector
+	GENERATED ALWAYS AS (to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(description, ''))) STORED;
+
+ALTER TABLE videos ADD COLUMN search_vector tsvector
+	GENERATED ALWAYS AS (to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(description, ''))) STORED;
+
+CREATE INDEX challenges_search_vector_idx ON challenges USING GIN (search_vector);
+CREATE INDEX videos_search_vector_idx ON videos USING GIN (search_vector);
+
+CREATE INDEX users_created_at_id_idx ON users (created_at, id);
+CREATE INDEX challenges_created_at_id_idx ON challenges (created_at, id);
+CREATE INDEX videos_created_at_id_idx ON videos (created_at, id);
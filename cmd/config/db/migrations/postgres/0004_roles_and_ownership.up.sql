@@ -0,0 +1,8 @@
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'viewer'
+	CHECK (role IN ('admin', 'creator', 'viewer'));
+
+ALTER TABLE challenges ADD COLUMN created_by TEXT;
+ALTER TABLE videos ADD COLUMN created_by TEXT;
+
+CREATE INDEX challenges_created_by_idx ON challenges (created_by);
+CREATE INDEX videos_created_by_idx ON videos (created_by);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS videos_challenge_id_idx;
+DROP INDEX IF EXISTS videos_user_id_idx;
+
+ALTER TABLE videos DROP COLUMN IF EXISTS challenge_id;
+ALTER TABLE videos DROP COLUMN IF EXISTS user_id;
@@ -0,0 +1,8 @@
+ALTER TABLE videos ADD COLUMN user_id TEXT REFERENCES users (id) ON DELETE SET NULL;
+ALTER TABLE videos ADD COLUMN challenge_id TEXT REFERENCES challenges (id) ON DELETE SET NULL;
+
+-- Los videos existentes quedan asociados a quien los creó, cuando es un usuario registrado.
+UPDATE videos SET user_id = created_by WHERE created_by IN (SELECT id FROM users);
+
+CREATE INDEX videos_user_id_idx ON videos (user_id);
+CREATE INDEX videos_challenge_id_idx ON videos (challenge_id);
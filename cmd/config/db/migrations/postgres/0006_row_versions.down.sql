@@ -0,0 +1,3 @@
+ALTER TABLE videos DROP COLUMN IF EXISTS version;
+ALTER TABLE challenges DROP COLUMN IF EXISTS version;
+ALTER TABLE users DROP COLUMN IF EXISTS version;
@@ -0,0 +1,4 @@
+-- version se incrementa en cada UPDATE y se expone como ETag para el control de concurrencia optimista.
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE challenges ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE videos ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
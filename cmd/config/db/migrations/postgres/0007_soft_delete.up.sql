@@ -0,0 +1,12 @@
+-- deleted_at marca los registros eliminados; las lecturas los excluyen hasta que se restauran o se purgan.
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE challenges ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE videos ADD COLUMN deleted_at TIMESTAMP;
+
+-- Un usuario eliminado libera su email para que pueda volver a registrarse.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX users_email_key ON users (email) WHERE deleted_at IS NULL;
+
+CREATE INDEX users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX challenges_deleted_at_idx ON challenges (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX videos_deleted_at_idx ON videos (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,27 @@
+-- audit_events registra cada escritura sobre users, challenges y videos. No tiene llaves foráneas
+-- para que los eventos sobrevivan a la purga de los registros que describen.
+CREATE TABLE IF NOT EXISTS audit_events (
+	id TEXT PRIMARY KEY,
+	actor TEXT,
+	action TEXT NOT NULL,
+	entity_type TEXT NOT NULL,
+	entity_id TEXT NOT NULL,
+	before JSONB,
+	after JSONB,
+	request_id TEXT,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX audit_events_entity_idx ON audit_events (entity_type, entity_id, created_at DESC, id DESC);
+CREATE INDEX audit_events_actor_idx ON audit_events (actor, created_at DESC, id DESC);
+
+-- La auditoría es de solo inserción: cualquier UPDATE o DELETE sobre la tabla falla.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+	BEFORE UPDATE OR DELETE ON audit_events
+	FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS video_upload_parts;
+DROP TABLE IF EXISTS video_uploads;
+
+ALTER TABLE videos DROP COLUMN IF EXISTS content_duration;
+ALTER TABLE videos DROP COLUMN IF EXISTS content_checksum;
+ALTER TABLE videos DROP COLUMN IF EXISTS content_type;
+ALTER TABLE videos DROP COLUMN IF EXISTS content_size;
+ALTER TABLE videos DROP COLUMN IF EXISTS content_key;
@@ -0,0 +1,7 @@
+-- El login compara el email sin distinguir mayúsculas, así que la unicidad también debe hacerlo:
+-- si no, A@x.com y a@x.com podrían registrarse como usuarios distintos. Los emails se guardan en
+-- minúsculas; si dos usuarios vigentes solo difieren en mayúsculas, la migración falla y hay que
+-- resolver el duplicado a mano.
+DROP INDEX IF EXISTS users_email_key;
+UPDATE users SET email = LOWER(email) WHERE email <> LOWER(email);
+CREATE UNIQUE INDEX users_email_key ON users (LOWER(email)) WHERE deleted_at IS NULL;
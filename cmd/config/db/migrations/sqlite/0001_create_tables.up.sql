@@ -0,0 +1,29 @@
+-- El email único se declara como índice, y no como restricción de la columna, para que 0007 lo
+-- pueda reemplazar: SQLite no permite borrar restricciones de una tabla existente.
+CREATE TABLE IF NOT EXISTS users (
+	id TEXT PRIMARY KEY,
+	name TEXT,
+	email TEXT,
+	image_path TEXT,
+	created_at TIMESTAMP,
+	updated_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX users_email_key ON users (email);
+
+CREATE TABLE IF NOT EXISTS challenges (
+	id TEXT PRIMARY KEY,
+	title TEXT,
+	description TEXT,
+	difficulty INTEGER,
+	created_at TIMESTAMP,
+	updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS videos (
+	id TEXT PRIMARY KEY,
+	title TEXT,
+	description TEXT,
+	created_at TIMESTAMP,
+	updated_at TIMESTAMP
+);
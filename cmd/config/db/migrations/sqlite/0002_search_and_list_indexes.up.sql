@@ -0,0 +1,4 @@
+-- SQLite no tiene tsvector: la búsqueda de texto compara title y description con LIKE.
+CREATE INDEX users_created_at_id_idx ON users (created_at, id);
+CREATE INDEX challenges_created_at_id_idx ON challenges (created_at, id);
+CREATE INDEX videos_created_at_id_idx ON videos (created_at, id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS videos_created_by_idx;
+DROP INDEX IF EXISTS challenges_created_by_idx;
+
+ALTER TABLE videos DROP COLUMN created_by;
+ALTER TABLE challenges DROP COLUMN created_by;
+
+ALTER TABLE users DROP COLUMN role;
@@ -0,0 +1,19 @@
+-- SQLite no borra columnas con llave foránea, así que videos se reconstruye con las columnas que
+-- tenía en la versión 4.
+CREATE TABLE videos_previous (
+	id TEXT PRIMARY KEY,
+	title TEXT,
+	description TEXT,
+	created_at TIMESTAMP,
+	updated_at TIMESTAMP,
+	created_by TEXT
+);
+
+INSERT INTO videos_previous (id, title, description, created_at, updated_at, created_by)
+	SELECT id, title, description, created_at, updated_at, created_by FROM videos;
+
+DROP TABLE videos;
+ALTER TABLE videos_previous RENAME TO videos;
+
+CREATE INDEX videos_created_at_id_idx ON videos (created_at, id);
+CREATE INDEX videos_created_by_idx ON videos (created_by);
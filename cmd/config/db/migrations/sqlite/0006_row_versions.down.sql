@@ -0,0 +1,3 @@
+ALTER TABLE videos DROP COLUMN version;
+ALTER TABLE challenges DROP COLUMN version;
+ALTER TABLE users DROP COLUMN version;
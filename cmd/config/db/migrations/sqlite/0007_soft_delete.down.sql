@@ -0,0 +1,15 @@
+-- Los registros eliminados no tienen representación sin deleted_at, así que se borran definitivamente.
+DELETE FROM videos WHERE deleted_at IS NOT NULL;
+DELETE FROM challenges WHERE deleted_at IS NOT NULL;
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS videos_deleted_at_idx;
+DROP INDEX IF EXISTS challenges_deleted_at_idx;
+DROP INDEX IF EXISTS users_deleted_at_idx;
+
+DROP INDEX IF EXISTS users_email_key;
+CREATE UNIQUE INDEX users_email_key ON users (email);
+
+ALTER TABLE videos DROP COLUMN deleted_at;
+ALTER TABLE challenges DROP COLUMN deleted_at;
+ALTER TABLE users DROP COLUMN deleted_at;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS video_upload_parts;
+DROP TABLE IF EXISTS video_uploads;
+
+ALTER TABLE videos DROP COLUMN content_duration;
+ALTER TABLE videos DROP COLUMN content_checksum;
+ALTER TABLE videos DROP COLUMN content_type;
+ALTER TABLE videos DROP COLUMN content_size;
+ALTER TABLE videos DROP COLUMN content_key;
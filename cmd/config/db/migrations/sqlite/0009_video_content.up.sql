@@ -0,0 +1,28 @@
+-- El archivo de cada video se guarda en el BlobStore configurado; la fila guarda su llave y sus metadatos.
+ALTER TABLE videos ADD COLUMN content_key TEXT;
+ALTER TABLE videos ADD COLUMN content_size BIGINT;
+ALTER TABLE videos ADD COLUMN content_type TEXT;
+ALTER TABLE videos ADD COLUMN content_checksum TEXT;
+ALTER TABLE videos ADD COLUMN content_duration DOUBLE PRECISION;
+
+-- Un upload por partes se completa con varios PATCH; cada parte recibida queda en el BlobStore
+-- hasta que el upload llega a upload_length y se une en el archivo del video.
+CREATE TABLE IF NOT EXISTS video_uploads (
+	id TEXT PRIMARY KEY,
+	video_id TEXT NOT NULL REFERENCES videos (id) ON DELETE CASCADE,
+	upload_length BIGINT NOT NULL,
+	upload_offset BIGINT NOT NULL DEFAULT 0,
+	content_type TEXT NOT NULL,
+	created_by TEXT,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS video_upload_parts (
+	upload_id TEXT NOT NULL REFERENCES video_uploads (id) ON DELETE CASCADE,
+	part_offset BIGINT NOT NULL,
+	blob_key TEXT NOT NULL,
+	size BIGINT NOT NULL,
+	PRIMARY KEY (upload_id, part_offset)
+);
+
+CREATE INDEX video_uploads_video_id_idx ON video_uploads (video_id);
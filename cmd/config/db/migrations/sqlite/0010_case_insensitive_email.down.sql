@@ -0,0 +1,3 @@
+-- Los emails quedan en minúsculas.
+DROP INDEX IF EXISTS users_email_key;
+CREATE UNIQUE INDEX users_email_key ON users (email) WHERE deleted_at IS NULL;
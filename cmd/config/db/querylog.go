@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"CrudPlatform/internal/core/domain/logging"
+)
+
+// queryLogConnector envuelve el conector de pq para registrar la duración de cada consulta con el
+// logger del contexto, que ya trae el request id y los ids de la operación del repositorio.
+type queryLogConnector struct {
+	driver.Connector
+	slow time.Duration
+}
+
+func (c *queryLogConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &queryLogConn{Conn: conn, slow: c.slow}, nil
+}
+
+// queryLogConn reenvía a la conexión real las interfaces opcionales de database/sql; si la conexión
+// no implementa alguna, responde driver.ErrSkip para que database/sql use su alternativa.
+type queryLogConn struct {
+	driver.Conn
+	slow time.Duration
+}
+
+func (c *queryLogConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.log(ctx, query, start, err)
+	return rows, err
+}
+
+func (c *queryLogConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.log(ctx, query, start, err)
+	return result, err
+}
+
+func (c *queryLogConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *queryLogConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *queryLogConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *queryLogConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *queryLogConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *queryLogConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// log registra la consulta sin sus argumentos, que pueden traer datos personales. Las lentas y las
+// fallidas salen como warn; el resto solo con el nivel debug.
+func (c *queryLogConn) log(ctx context.Context, query string, start time.Time, err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+	elapsed := time.Since(start)
+	attrs := []any{"query", strings.Join(strings.Fields(query), " "), "duration_ms", elapsed.Milliseconds()}
+
+	logger := logging.FromContext(ctx)
+	switch {
+	case err != nil:
+		logger.WarnContext(ctx, "query failed", append(attrs, "error", err)...)
+	case c.slow > 0 && elapsed >= c.slow:
+		logger.WarnContext(ctx, "slow query", attrs...)
+	default:
+		logger.DebugContext(ctx, "query", attrs...)
+	}
+}
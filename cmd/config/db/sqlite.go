@@ -0,0 +1,56 @@
+package db
+
+import (
+	"CrudPlatform/cmd/config"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+
+	"modernc.org/sqlite"
+)
+
+// NewSQLiteDB abre el archivo database.path con el driver de SQLite en Go puro, sin cgo. Si el
+// archivo no existe se crea vacío y las migraciones arman el esquema.
+func NewSQLiteDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+	connector := dsnConnector{dsn: sqliteDSN(cfg.Path), driver: &sqlite.Driver{}}
+	db := sql.OpenDB(&queryLogConnector{Connector: connector, slow: cfg.SlowQuery})
+
+	configurePool(db, cfg)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open sqlite database %s: %w", cfg.Path, err)
+	}
+
+	return db, nil
+}
+
+// sqliteDSN activa en cada conexión las llaves foráneas, que SQLite trae apagadas, y el modo WAL
+// para que las lecturas no esperen a las escrituras. Las transacciones toman el lock de escritura
+// al empezar (immediate), así dos transacciones concurrentes esperan busy_timeout en lugar de
+// fallar con SQLITE_BUSY al intentar escribir.
+func sqliteDSN(path string) string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Set("_txlock", "immediate")
+	return "file:" + path + "?" + params.Encode()
+}
+
+// dsnConnector adapta un driver que solo abre conexiones por DSN a driver.Connector, que es lo que
+// envuelve queryLogConnector.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
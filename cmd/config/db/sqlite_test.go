@@ -0,0 +1,46 @@
+package db
+
+import (
+	"CrudPlatform/cmd/config"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteMigrations(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(config.DatabaseConfig{Driver: config.DriverSQLite, Path: filepath.Join(t.TempDir(), "task.db")})
+	require.NoError(t, err)
+	defer db.Close()
+
+	migrator, err := NewMigrator(db, config.DriverSQLite)
+	require.NoError(t, err)
+
+	postgres, err := loadMigrations(migrationFiles, "migrations/postgres")
+	require.NoError(t, err)
+	assert.Equal(t, len(postgres), len(migrator.migrations), "both drivers must have the same migrations")
+
+	require.NoError(t, migrator.Up(ctx))
+
+	_, err = db.ExecContext(ctx, "INSERT INTO users (id, email, created_at, updated_at) VALUES ($1, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)", "1", "a@example.com")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "INSERT INTO videos (id, user_id) VALUES ($1, $2)", "v", "missing")
+	assert.Error(t, err, "foreign keys must be enforced")
+
+	_, err = db.ExecContext(ctx, "INSERT INTO audit_events (id, action, entity_type, entity_id, created_at) VALUES ('e', 'create', 'users', '1', CURRENT_TIMESTAMP)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "DELETE FROM audit_events")
+	assert.ErrorContains(t, err, "append-only")
+
+	require.NoError(t, migrator.To(ctx, 0))
+	require.NoError(t, migrator.Up(ctx))
+
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, migration := range status {
+		assert.True(t, migration.Applied, "migration %d", migration.Version)
+	}
+}
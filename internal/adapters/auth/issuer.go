@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"CrudPlatform/cmd/config"
+	domain "CrudPlatform/internal/core/domain/auth"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Issuer firma access tokens HS256 de corta duración para los usuarios de la plataforma.
+// Los tokens emitidos son aceptados por Validator con la misma configuración.
+type Issuer struct {
+	secret   []byte
+	issuer   string
+	audience string
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+func NewIssuer(cfg config.AuthConfig) *Issuer {
+	issuer := &Issuer{
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		ttl:      cfg.AccessTokenTTL,
+		now:      time.Now,
+	}
+	if cfg.HMACSecret != "" {
+		issuer.secret = []byte(cfg.HMACSecret)
+	}
+	return issuer
+}
+
+// Issue firma un access token para el usuario con su rol y retorna su vigencia.
+func (i *Issuer) Issue(ctx context.Context, subject string, role domain.Role) (string, time.Duration, error) {
+	if i.secret == nil {
+		return "", 0, errors.New("token issuing requires auth.hmac_secret")
+	}
+
+	now := i.now()
+	claims := tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   subject,
+			Issuer:    i.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(i.ttl)),
+		},
+		Role: string(role),
+	}
+	if i.audience != "" {
+		claims.Audience = jwt.ClaimStrings{i.audience}
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+	if err != nil {
+		return "", 0, err
+	}
+	return token, i.ttl, nil
+}
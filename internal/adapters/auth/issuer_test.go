@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"CrudPlatform/cmd/config"
+	domain "CrudPlatform/internal/core/domain/auth"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssuer(t *testing.T) {
+	cfg := config.AuthConfig{
+		HMACSecret:     "secret",
+		Issuer:         "https://issuer.example.com/",
+		Audience:       "crudplatform",
+		AccessTokenTTL: 15 * time.Minute,
+	}
+	ctx := context.Background()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		token, ttl, err := NewIssuer(cfg).Issue(ctx, "user-1", domain.RoleCreator)
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Minute, ttl)
+
+		claims, err := NewValidator(cfg).Validate(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+		assert.Equal(t, domain.RoleCreator, claims.Role)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		issuer := NewIssuer(cfg)
+		issuer.now = func() time.Time { return time.Now().Add(-time.Hour) }
+
+		token, _, err := issuer.Issue(ctx, "user-1", domain.RoleCreator)
+		require.NoError(t, err)
+
+		_, err = NewValidator(cfg).Validate(ctx, token)
+		assert.Error(t, err)
+	})
+
+	t.Run("WithoutSecret", func(t *testing.T) {
+		_, _, err := NewIssuer(config.AuthConfig{JWKSURL: "https://issuer.example.com/jwks"}).Issue(ctx, "user-1", domain.RoleCreator)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// minRefetchInterval limita las recargas forzadas cuando llega un kid desconocido.
+const minRefetchInterval = 30 * time.Second
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// KeySet mantiene en caché las llaves públicas de un JWKS y las recarga al expirar
+// o cuando un token trae un kid que aún no conoce (rotación de llaves).
+type KeySet struct {
+	fetch   func(ctx context.Context) ([]byte, error)
+	refresh time.Duration
+	now     func() time.Time
+
+	mu        sync.RWMutex
+	keys      map[string]any
+	fetchedAt time.Time
+	// retryAt es cuándo se vuelve a intentar la carga después de un error. Hasta entonces se usan
+	// las llaves en caché sin esperar al JWKS, para que una caída del emisor no bloquee los requests.
+	retryAt time.Time
+}
+
+// NewFileKeySet carga el JWKS desde un archivo local.
+func NewFileKeySet(path string, refresh time.Duration) *KeySet {
+	return newKeySet(func(ctx context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	}, refresh)
+}
+
+// NewURLKeySet carga el JWKS desde una URL.
+func NewURLKeySet(url string, refresh time.Duration, client *http.Client) *KeySet {
+	return newKeySet(func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %d fetching JWKS", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	}, refresh)
+}
+
+func newKeySet(fetch func(ctx context.Context) ([]byte, error), refresh time.Duration) *KeySet {
+	return &KeySet{
+		fetch:   fetch,
+		refresh: refresh,
+		now:     time.Now,
+	}
+}
+
+// Key retorna la llave pública asociada al kid.
+func (k *KeySet) Key(ctx context.Context, kid string) (any, error) {
+	k.mu.RLock()
+	key, ok := k.keys[kid]
+	fresh := k.fresh()
+	k.mu.RUnlock()
+	if ok && fresh {
+		return key, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	// Otro goroutine pudo haber recargado, o fallado al recargar, mientras se esperaba el lock.
+	key, ok = k.keys[kid]
+	if ok && k.fresh() {
+		return key, nil
+	}
+	if !ok && k.keys != nil && k.now().Sub(k.fetchedAt) < minRefetchInterval {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if k.now().Before(k.retryAt) {
+		return nil, fmt.Errorf("JWKS unavailable, unknown key id %q", kid)
+	}
+
+	keys, err := k.load(ctx)
+	if err != nil {
+		k.retryAt = k.now().Add(minRefetchInterval)
+		if ok {
+			// Si el JWKS no está disponible se sigue usando la llave en caché.
+			return key, nil
+		}
+		return nil, err
+	}
+	k.keys = keys
+	k.fetchedAt = k.now()
+
+	key, ok = k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+// fresh indica si las llaves en caché se pueden usar sin recargar: no expiraron, o la última carga
+// falló hace menos de minRefetchInterval. Se llama con k.mu tomado.
+func (k *KeySet) fresh() bool {
+	now := k.now()
+	return now.Sub(k.fetchedAt) < k.refresh || now.Before(k.retryAt)
+}
+
+func (k *KeySet) load(ctx context.Context) (map[string]any, error) {
+	raw, err := k.fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading JWKS: %w", err)
+	}
+	return parseJWKS(raw)
+}
+
+func parseJWKS(raw []byte) (map[string]any, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("error parsing JWKS: %w", err)
+	}
+
+	keys := map[string]any{}
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		var parsed any
+		var err error
+		switch key.Kty {
+		case "RSA":
+			parsed, err = rsaKey(key)
+		case "EC":
+			parsed, err = ecKey(key)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing key %q: %w", key.Kid, err)
+		}
+		keys[key.Kid] = parsed
+	}
+
+	return keys, nil
+}
+
+func rsaKey(key jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+func ecKey(key jwk) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch key.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %s", key.Crv)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := base64.RawURLEncoding.DecodeString(key.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	public := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if _, err := public.ECDH(); err != nil {
+		return nil, fmt.Errorf("invalid %s point: %w", key.Crv, err)
+	}
+	return public, nil
+}
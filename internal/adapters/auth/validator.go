@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"CrudPlatform/cmd/config"
+	domain "CrudPlatform/internal/core/domain/auth"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenClaims son los claims que se leen del JWT. Los scopes pueden venir como
+// "scope" separado por espacios (RFC 8693) o como arreglo en "scp". Sin claim role
+// el usuario se trata como viewer.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Role  string   `json:"role,omitempty"`
+	Scope string   `json:"scope,omitempty"`
+	Scp   []string `json:"scp,omitempty"`
+}
+
+// Validator valida tokens bearer HS256, RS256 y ES256.
+type Validator struct {
+	hmacSecret []byte
+	keys       *KeySet
+	parser     *jwt.Parser
+}
+
+func NewValidator(cfg config.AuthConfig) *Validator {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "RS256", "ES256"}),
+		jwt.WithLeeway(cfg.ClockSkew),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	validator := &Validator{parser: jwt.NewParser(options...)}
+	if cfg.HMACSecret != "" {
+		validator.hmacSecret = []byte(cfg.HMACSecret)
+	}
+
+	switch {
+	case cfg.JWKSURL != "":
+		validator.keys = NewURLKeySet(cfg.JWKSURL, cfg.JWKSRefresh, &http.Client{Timeout: 5 * time.Second})
+	case cfg.JWKSFile != "":
+		validator.keys = NewFileKeySet(cfg.JWKSFile, cfg.JWKSRefresh)
+	}
+
+	return validator
+}
+
+// Validate verifica firma, expiración, emisor y audiencia del token y retorna sus claims.
+func (v *Validator) Validate(ctx context.Context, token string) (*domain.Claims, error) {
+	var claims tokenClaims
+	_, err := v.parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		switch t.Method.Alg() {
+		case "HS256":
+			if v.hmacSecret == nil {
+				return nil, errors.New("HS256 tokens are not accepted")
+			}
+			return v.hmacSecret, nil
+		default:
+			if v.keys == nil {
+				return nil, fmt.Errorf("%s tokens are not accepted", t.Method.Alg())
+			}
+			kid, _ := t.Header["kid"].(string)
+			return v.keys.Key(ctx, kid)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("invalid token: missing subject")
+	}
+
+	scopes := append([]string{}, claims.Scp...)
+	scopes = append(scopes, strings.Fields(claims.Scope)...)
+
+	return &domain.Claims{
+		Subject: claims.Subject,
+		Role:    domain.ParseRole(claims.Role),
+		Scopes:  scopes,
+	}, nil
+}
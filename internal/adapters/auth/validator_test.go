@@ -0,0 +1,293 @@
+package auth
+
+import (
+	"CrudPlatform/cmd/config"
+	domain "CrudPlatform/internal/core/domain/auth"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "RSA",
+		"use": "sig",
+		"n":   b64(key.N.Bytes()),
+		"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   b64(key.X.FillBytes(make([]byte, 32))),
+		"y":   b64(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func jwks(t *testing.T, keys ...map[string]string) []byte {
+	raw, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	return raw
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "https://issuer.example.com/",
+		"aud":   "crudplatform",
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Minute).Unix(),
+		"scope": "videos:write videos:read",
+	}
+}
+
+func TestValidatorHS256(t *testing.T) {
+	validator := NewValidator(config.AuthConfig{
+		HMACSecret: "secret",
+		Issuer:     "https://issuer.example.com/",
+		Audience:   "crudplatform",
+		ClockSkew:  30 * time.Second,
+	})
+	ctx := context.Background()
+
+	t.Run("Valid", func(t *testing.T) {
+		claims, err := validator.Validate(ctx, sign(t, jwt.SigningMethodHS256, "", []byte("secret"), validClaims()))
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+		assert.Equal(t, []string{"videos:write", "videos:read"}, claims.Scopes)
+		assert.True(t, claims.HasScope("videos:read"))
+		assert.Equal(t, domain.RoleViewer, claims.Role)
+	})
+
+	t.Run("Role", func(t *testing.T) {
+		claims := validClaims()
+		claims["role"] = "admin"
+
+		parsed, err := validator.Validate(ctx, sign(t, jwt.SigningMethodHS256, "", []byte("secret"), claims))
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleAdmin, parsed.Role)
+	})
+
+	t.Run("WrongSecret", func(t *testing.T) {
+		_, err := validator.Validate(ctx, sign(t, jwt.SigningMethodHS256, "", []byte("other"), validClaims()))
+		assert.Error(t, err)
+	})
+
+	t.Run("ExpiredWithinSkew", func(t *testing.T) {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-10 * time.Second).Unix()
+
+		_, err := validator.Validate(ctx, sign(t, jwt.SigningMethodHS256, "", []byte("secret"), claims))
+		assert.NoError(t, err)
+	})
+
+	t.Run("ExpiredBeyondSkew", func(t *testing.T) {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+
+		_, err := validator.Validate(ctx, sign(t, jwt.SigningMethodHS256, "", []byte("secret"), claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+	})
+
+	t.Run("MissingExpiration", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "exp")
+
+		_, err := validator.Validate(ctx, sign(t, jwt.SigningMethodHS256, "", []byte("secret"), claims))
+		assert.Error(t, err)
+	})
+
+	t.Run("WrongIssuer", func(t *testing.T) {
+		claims := validClaims()
+		claims["iss"] = "https://evil.example.com/"
+
+		_, err := validator.Validate(ctx, sign(t, jwt.SigningMethodHS256, "", []byte("secret"), claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+	})
+
+	t.Run("WrongAudience", func(t *testing.T) {
+		claims := validClaims()
+		claims["aud"] = "another-service"
+
+		_, err := validator.Validate(ctx, sign(t, jwt.SigningMethodHS256, "", []byte("secret"), claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+	})
+
+	t.Run("MissingSubject", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "sub")
+
+		_, err := validator.Validate(ctx, sign(t, jwt.SigningMethodHS256, "", []byte("secret"), claims))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing subject")
+	})
+
+	t.Run("NoneAlgorithm", func(t *testing.T) {
+		token := sign(t, jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, validClaims())
+
+		_, err := validator.Validate(ctx, token)
+		assert.Error(t, err)
+	})
+
+	t.Run("RS256NotConfigured", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		_, err = validator.Validate(ctx, sign(t, jwt.SigningMethodRS256, "k1", key, validClaims()))
+		assert.Error(t, err)
+	})
+}
+
+func TestValidatorRS256FromFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwks(t, rsaJWK("k1", key)), 0o600))
+
+	validator := NewValidator(config.AuthConfig{JWKSFile: path, JWKSRefresh: time.Minute})
+
+	claims, err := validator.Validate(context.Background(), sign(t, jwt.SigningMethodRS256, "k1", key, validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+
+	_, err = validator.Validate(context.Background(), sign(t, jwt.SigningMethodHS256, "", []byte("secret"), validClaims()))
+	assert.Error(t, err)
+}
+
+func TestValidatorES256FromURLWithRotation(t *testing.T) {
+	first, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	second, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var current atomic.Value
+	current.Store(jwks(t, ecJWK("k1", first)))
+	var fetches atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Write(current.Load().([]byte))
+	}))
+	defer server.Close()
+
+	validator := NewValidator(config.AuthConfig{JWKSURL: server.URL, JWKSRefresh: time.Hour})
+	ctx := context.Background()
+
+	_, err = validator.Validate(ctx, sign(t, jwt.SigningMethodES256, "k1", first, validClaims()))
+	require.NoError(t, err)
+	_, err = validator.Validate(ctx, sign(t, jwt.SigningMethodES256, "k1", first, validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fetches.Load(), "keys must be cached")
+
+	// El emisor rota a una nueva llave; el kid desconocido fuerza una recarga.
+	current.Store(jwks(t, ecJWK("k1", first), ecJWK("k2", second)))
+	validator.keys.fetchedAt = time.Now().Add(-minRefetchInterval)
+
+	_, err = validator.Validate(ctx, sign(t, jwt.SigningMethodES256, "k2", second, validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+
+	// Un kid desconocido no vuelve a consultar el JWKS antes de minRefetchInterval.
+	_, err = validator.Validate(ctx, sign(t, jwt.SigningMethodES256, "k3", second, validClaims()))
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestKeySetServesCachedKeyWhenSourceFails(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var fail atomic.Bool
+	set := newKeySet(func(ctx context.Context) ([]byte, error) {
+		if fail.Load() {
+			return nil, assert.AnError
+		}
+		return jwks(t, rsaJWK("k1", key)), nil
+	}, time.Minute)
+
+	_, err = set.Key(context.Background(), "k1")
+	require.NoError(t, err)
+
+	fail.Store(true)
+	set.fetchedAt = time.Now().Add(-time.Hour)
+
+	cached, err := set.Key(context.Background(), "k1")
+	require.NoError(t, err)
+	assert.NotNil(t, cached)
+}
+
+func TestKeySetBacksOffWhenSourceFails(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var fail atomic.Bool
+	var fetches atomic.Int32
+	set := newKeySet(func(ctx context.Context) ([]byte, error) {
+		fetches.Add(1)
+		if fail.Load() {
+			return nil, assert.AnError
+		}
+		return jwks(t, rsaJWK("k1", key)), nil
+	}, time.Minute)
+	set.now = func() time.Time { return now }
+
+	_, err = set.Key(context.Background(), "k1")
+	require.NoError(t, err)
+
+	// Con el JWKS caído, solo el primer request después de expirar intenta recargar; los demás
+	// usan la llave en caché hasta minRefetchInterval.
+	fail.Store(true)
+	now = now.Add(time.Hour)
+	for range 3 {
+		_, err = set.Key(context.Background(), "k1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), fetches.Load())
+
+	// Un kid desconocido tampoco consulta el JWKS mientras dura la espera.
+	_, err = set.Key(context.Background(), "k2")
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+
+	now = now.Add(minRefetchInterval)
+	_, err = set.Key(context.Background(), "k1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), fetches.Load())
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	modelAudit "CrudPlatform/internal/core/domain/repository/model/audit"
+	"CrudPlatform/internal/core/ports"
+)
+
+type managementAuditHandler struct {
+	Service ports.CommunicationAuditServices
+}
+
+func newAuditHandler(service ports.CommunicationAuditServices) *managementAuditHandler {
+	return &managementAuditHandler{
+		Service: service,
+	}
+}
+
+func (o *managementAuditHandler) listAuditEvents() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var Events modelAudit.ListEvents
+		if !bindQuery(c, &Events, "List Audit Events") {
+			return
+		}
+		entityResponse, err := o.Service.ListAuditEvents(c.Request.Context(), &Events)
+		if err != nil {
+			serviceError(c, err, "List Audit Events")
+			return
+		}
+
+		setPaginationLinks(c, entityResponse.Pagination)
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
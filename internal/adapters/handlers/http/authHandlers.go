@@ -0,0 +1,71 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	modelAuth "CrudPlatform/internal/core/domain/repository/model/auth"
+	"CrudPlatform/internal/core/ports"
+)
+
+type managementAuthHandler struct {
+	Service ports.CommunicationAuthServices
+}
+
+func newAuthHandler(service ports.CommunicationAuthServices) *managementAuthHandler {
+	return &managementAuthHandler{
+		Service: service,
+	}
+}
+
+func (o *managementAuthHandler) postLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var User modelAuth.Login
+		if !bindJSON(c, &User, "Login") {
+			return
+		}
+		entityResponse, err := o.Service.Login(c.Request.Context(), &User)
+		if err != nil {
+			serviceError(c, err, "Login")
+			return
+		}
+
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
+
+func (o *managementAuthHandler) postRefresh() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var User modelAuth.Refresh
+		if !bindJSON(c, &User, "Refresh") {
+			return
+		}
+		entityResponse, err := o.Service.Refresh(c.Request.Context(), &User)
+		if err != nil {
+			serviceError(c, err, "Refresh")
+			return
+		}
+
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
+
+func (o *managementAuthHandler) postLogout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var User modelAuth.Logout
+		if !bindJSON(c, &User, "Logout") {
+			return
+		}
+		entityResponse, err := o.Service.Logout(c.Request.Context(), &User)
+		if err != nil {
+			serviceError(c, err, "Logout")
+			return
+		}
+
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/users"
+	"CrudPlatform/internal/core/ports"
+)
+
+type managementAvatarHandler struct {
+	Service ports.CommunicationAvatarServices
+	// PublicURL es la URL pública del servidor, de la configuración y nunca del request.
+	PublicURL string
+}
+
+func newAvatarHandler(service ports.CommunicationAvatarServices, publicURL string) *managementAvatarHandler {
+	return &managementAvatarHandler{
+		Service:   service,
+		PublicURL: publicURL,
+	}
+}
+
+// putAvatar recibe la imagen como body o en la parte "file" de un multipart/form-data.
+func (o *managementAvatarHandler) putAvatar() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := ifMatchVersion(c, "Upload Avatar")
+		if !ok {
+			return
+		}
+
+		body := io.Reader(c.Request.Body)
+		if c.ContentType() == "multipart/form-data" {
+			reader, err := c.Request.MultipartReader()
+			if err != nil {
+				serviceError(c, entity.WrapError(entity.KindValidation, err, "invalid multipart body"), "Upload Avatar")
+				return
+			}
+			part, err := filePart(reader)
+			if err != nil {
+				serviceError(c, err, "Upload Avatar")
+				return
+			}
+			defer part.Close()
+			body = part
+		}
+
+		entityResponse, err := o.Service.UploadAvatar(c.Request.Context(), &model.UploadAvatar{
+			ID:      c.Param("id"),
+			Body:    body,
+			BaseURL: o.PublicURL,
+			Version: version,
+		})
+		if err != nil {
+			serviceError(c, err, "Upload Avatar")
+			return
+		}
+
+		setETag(c, entityResponse.Version)
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
+
+// getAvatar sirve una miniatura del avatar, de 256 px o de ?size=64. Cada imagen subida tiene su
+// propia URL, así que la respuesta se puede guardar en caché para siempre.
+func (o *managementAvatarHandler) getAvatar() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request model.GetAvatar
+		if !bindQuery(c, &request, "Get Avatar") {
+			return
+		}
+		request.UserID = c.Param("id")
+		request.File = c.Param("file")
+
+		avatar, err := o.Service.OpenAvatar(c.Request.Context(), &request)
+		if err != nil {
+			serviceError(c, err, "Get Avatar")
+			return
+		}
+		defer avatar.Body.Close()
+
+		c.Header("Content-Type", avatar.ContentType)
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+		http.ServeContent(c.Writer, c.Request, "", time.Time{}, avatar.Body)
+	}
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"CrudPlatform/internal/adapters/handlers/http/middleware"
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/users"
+	schema "CrudPlatform/internal/core/domain/repository/schema/users"
+	mockPorts "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAvatarRouter(service *mockPorts.CommunicationAvatarServices, publicURL string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := newAvatarHandler(service, publicURL)
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.PUT("/users/:id/avatar", handler.putAvatar())
+	router.GET("/users/:id/avatar/:file", handler.getAvatar())
+	return router
+}
+
+func TestPutAvatar(t *testing.T) {
+	t.Run("RawBody", func(t *testing.T) {
+		service := mockPorts.NewCommunicationAvatarServices(t)
+		service.On("UploadAvatar", mock.Anything, mock.MatchedBy(func(request *model.UploadAvatar) bool {
+			data, _ := io.ReadAll(request.Body)
+			return request.ID == "1" && string(data) == "image" && request.BaseURL == "https://api.example.com" &&
+				request.Version != nil && *request.Version == 2
+		})).Return(&entity.Response{Data: &schema.UsersUpdateResponse{ID: "1", Version: 3}, Version: 3}, nil)
+
+		w := serve(newAvatarRouter(service, "https://api.example.com"), http.MethodPut, "/users/1/avatar", "image",
+			map[string]string{"Content-Type": "image/png", "If-Match": `"2"`})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("IgnoresRequestHost", func(t *testing.T) {
+		service := mockPorts.NewCommunicationAvatarServices(t)
+		service.On("UploadAvatar", mock.Anything, mock.MatchedBy(func(request *model.UploadAvatar) bool {
+			return request.BaseURL == "https://api.example.com"
+		})).Return(&entity.Response{Data: &schema.UsersUpdateResponse{ID: "1", Version: 3}, Version: 3}, nil)
+
+		// El Host del request y X-Forwarded-Proto los elige el cliente y no deben terminar en image_path.
+		req := httptest.NewRequest(http.MethodPut, "/users/1/avatar", strings.NewReader("image"))
+		req.Host = "evil.example"
+		req.Header.Set("Content-Type", "image/png")
+		req.Header.Set("X-Forwarded-Proto", "http")
+		w := httptest.NewRecorder()
+		newAvatarRouter(service, "https://api.example.com").ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("NotAnImage", func(t *testing.T) {
+		service := mockPorts.NewCommunicationAvatarServices(t)
+		service.On("UploadAvatar", mock.Anything, mock.Anything).
+			Return(nil, entity.NewError(entity.KindUnsupportedMediaType, "avatar must be a JPEG, PNG or GIF image"))
+
+		w := serve(newAvatarRouter(service, ""), http.MethodPut, "/users/1/avatar", "<svg/>", map[string]string{"Content-Type": "image/svg+xml"})
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+}
+
+func TestGetAvatar(t *testing.T) {
+	service := mockPorts.NewCommunicationAvatarServices(t)
+	service.On("OpenAvatar", mock.Anything, &model.GetAvatar{UserID: "1", File: "a.jpg", Size: 64}).
+		Return(&model.Avatar{Body: &closingReader{Reader: strings.NewReader("jpeg")}, ContentType: "image/jpeg"}, nil)
+
+	w := serve(newAvatarRouter(service, ""), http.MethodGet, "/users/1/avatar/a.jpg?size=64", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "jpeg", w.Body.String())
+	assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Cache-Control"), "immutable")
+
+	w = serve(newAvatarRouter(service, ""), http.MethodGet, "/users/1/avatar/a.jpg?size=100", "", nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	"CrudPlatform/internal/core/ports"
+)
+
+// replacement es el body de un PUT, que reemplaza el registro completo y se aplica como una
+// actualización de todos sus campos.
+type replacement[U any] interface {
+	Update(id string) *U
+}
+
+// crudHandler expone por HTTP el ports.Service de una entidad. C, U y L son las solicitudes de
+// creación, actualización y listado; P es el body de PUT.
+type crudHandler[C, U, L any, P replacement[U]] struct {
+	Service ports.Service[C, U, L]
+	// name y plural forman el Source de los errores, como "Update Challenge" o "List Challenges".
+	name   string
+	plural string
+	// target fija en una actualización el id de la ruta y la versión de If-Match.
+	target func(request *U, id string, version *int64)
+}
+
+func newCrudHandler[C, U, L any, P replacement[U]](service ports.Service[C, U, L], name, plural string, target func(request *U, id string, version *int64)) *crudHandler[C, U, L, P] {
+	return &crudHandler[C, U, L, P]{
+		Service: service,
+		name:    name,
+		plural:  plural,
+		target:  target,
+	}
+}
+
+func (o *crudHandler[C, U, L, P]) post() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request C
+		if !bindJSON(c, &request, "Create "+o.name) {
+			return
+		}
+		entityResponse, err := o.Service.Create(c.Request.Context(), &request)
+		if err != nil {
+			serviceError(c, err, "Create "+o.name)
+			return
+		}
+
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
+
+func (o *crudHandler[C, U, L, P]) get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request entity.GetRecord
+		request.ID = c.Param("id")
+		if err := c.ShouldBindQuery(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
+			return
+		}
+		o.selectRecord(c, &request)
+	}
+}
+
+// selectRecord responde con el registro pedido, o con 304 si el cliente ya tiene su versión.
+func (o *crudHandler[C, U, L, P]) selectRecord(c *gin.Context, request *entity.GetRecord) {
+	entityResponse, err := o.Service.Select(c.Request.Context(), request)
+	if err != nil {
+		serviceError(c, err, "Select "+o.name)
+		return
+	}
+
+	if notModified(c, entityResponse.Version) {
+		return
+	}
+	setETag(c, entityResponse.Version)
+	c.Set("entityResponse", *entityResponse)
+	c.JSON(http.StatusOK, entityResponse)
+}
+
+func (o *crudHandler[C, U, L, P]) put() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := ifMatchVersion(c, "Update "+o.name)
+		if !ok {
+			return
+		}
+		var body P
+		if !bindJSON(c, &body, "Update "+o.name) {
+			return
+		}
+		request := body.Update(c.Param("id"))
+		o.target(request, c.Param("id"), version)
+		o.update(c, request)
+	}
+}
+
+// patch aplica un JSON Merge Patch (RFC 7396): solo cambian los campos presentes en el body.
+func (o *crudHandler[C, U, L, P]) patch() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := ifMatchVersion(c, "Update "+o.name)
+		if !ok {
+			return
+		}
+		var request U
+		if !bindMergePatch(c, &request, "Update "+o.name) {
+			return
+		}
+		o.target(&request, c.Param("id"), version)
+		o.update(c, &request)
+	}
+}
+
+func (o *crudHandler[C, U, L, P]) update(c *gin.Context, request *U) {
+	entityResponse, err := o.Service.Update(c.Request.Context(), request)
+	if err != nil {
+		serviceError(c, err, "Update "+o.name)
+		return
+	}
+
+	setETag(c, entityResponse.Version)
+	c.Set("entityResponse", *entityResponse)
+	c.JSON(http.StatusOK, entityResponse)
+}
+
+func (o *crudHandler[C, U, L, P]) delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request entity.DeleteRecord
+		request.ID = c.Param("id")
+		if err := c.ShouldBindQuery(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
+			return
+		}
+		version, ok := ifMatchVersion(c, "Delete "+o.name)
+		if !ok {
+			return
+		}
+		request.Version = version
+		entityResponse, err := o.Service.Delete(c.Request.Context(), &request)
+		if err != nil {
+			serviceError(c, err, "Delete "+o.name)
+			return
+		}
+
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
+
+// restore recupera un registro eliminado que todavía no se purgó.
+func (o *crudHandler[C, U, L, P]) restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := entity.RestoreRecord{ID: c.Param("id")}
+		entityResponse, err := o.Service.Restore(c.Request.Context(), &request)
+		if err != nil {
+			serviceError(c, err, "Restore "+o.name)
+			return
+		}
+
+		setETag(c, entityResponse.Version)
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
+
+// list lista los registros; scope, si no es nil, fija los filtros que vienen de la ruta y no de
+// la query, como el usuario de GET /users/:id/videos.
+func (o *crudHandler[C, U, L, P]) list(scope func(c *gin.Context, request *L)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request L
+		if !bindQuery(c, &request, "List "+o.plural) {
+			return
+		}
+		if scope != nil {
+			scope(c, &request)
+		}
+		entityResponse, err := o.Service.List(c.Request.Context(), &request)
+		if err != nil {
+			serviceError(c, err, "List "+o.plural)
+			return
+		}
+
+		setPaginationLinks(c, entityResponse.Pagination)
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
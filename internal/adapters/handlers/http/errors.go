@@ -0,0 +1,11 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// serviceError registra el error de un servicio para que middleware.ErrorMiddleware lo
+// responda con el status que corresponde a su clasificación.
+func serviceError(c *gin.Context, err error, source string) {
+	_ = c.Error(err).SetMeta(source)
+}
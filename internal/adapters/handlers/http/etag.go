@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag representa la versión de un registro como ETag fuerte, por ejemplo "3".
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// setETag publica la versión del registro de la respuesta; una versión 0 no tiene ETag.
+func setETag(c *gin.Context, version int64) {
+	if version > 0 {
+		c.Header("ETag", etag(version))
+	}
+}
+
+// notModified responde 304 cuando If-None-Match incluye el ETag actual del registro.
+// La comparación es débil, así que W/"3" también coincide con "3".
+func notModified(c *gin.Context, version int64) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" || version <= 0 {
+		return false
+	}
+
+	current := etag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			setETag(c, version)
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion lee de If-Match la versión que el cliente espera modificar. Sin header o con "*"
+// la escritura no se condiciona. Un valor que no es el ETag de una versión nunca coincide y
+// responde 412, igual que una versión desactualizada.
+func ifMatchVersion(c *gin.Context, source string) (*int64, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	value, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		value, ok = strings.CutSuffix(value, `"`)
+	}
+	version, err := strconv.ParseInt(value, 10, 64)
+	if !ok || err != nil || version <= 0 {
+		serviceError(c, entity.NewError(entity.KindPreconditionFailed, "If-Match %s does not match the current ETag", header), source)
+		return nil, false
+	}
+	return &version, true
+}
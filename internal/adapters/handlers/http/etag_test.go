@@ -0,0 +1,111 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"CrudPlatform/internal/adapters/handlers/http/middleware"
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/challenges"
+	schema "CrudPlatform/internal/core/domain/repository/schema/challenges"
+	mockPorts "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newChallengeRouter(service *mockPorts.CommunicationChallengeServices) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := newChallengeHandler(service)
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.GET("/challenge/:id", handler.get())
+	router.PUT("/challenge/:id", handler.put())
+	router.DELETE("/challenge/:id", handler.delete())
+	router.POST("/challenge/:id/restore", handler.restore())
+	return router
+}
+
+func serve(router *gin.Engine, method, target, body string, headers map[string]string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestChallengeETag(t *testing.T) {
+	selected := &entity.Response{Data: &schema.ChallengeGetResponse{ID: "123", Version: 3}, Version: 3}
+
+	t.Run("GetSetsETag", func(t *testing.T) {
+		service := mockPorts.NewCommunicationChallengeServices(t)
+		service.On("Select", mock.Anything, mock.Anything).Return(selected, nil)
+
+		w := serve(newChallengeRouter(service), http.MethodGet, "/challenge/123", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("GetNotModified", func(t *testing.T) {
+		service := mockPorts.NewCommunicationChallengeServices(t)
+		service.On("Select", mock.Anything, mock.Anything).Return(selected, nil)
+
+		w := serve(newChallengeRouter(service), http.MethodGet, "/challenge/123", "", map[string]string{"If-None-Match": `"2", W/"3"`})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("PutPassesExpectedVersion", func(t *testing.T) {
+		service := mockPorts.NewCommunicationChallengeServices(t)
+		service.On("Update", mock.Anything, mock.MatchedBy(func(request *model.UpdateChallenge) bool {
+			return request.ID == "123" && request.Version != nil && *request.Version == 3
+		})).Return(&entity.Response{Data: &schema.ChallengeUpdateResponse{ID: "123", Version: 4}, Version: 4}, nil)
+
+		w := serve(newChallengeRouter(service), http.MethodPut, "/challenge/123", `{"title":"Go","difficulty":2}`, map[string]string{"If-Match": `"3"`})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("PutStale", func(t *testing.T) {
+		service := mockPorts.NewCommunicationChallengeServices(t)
+		service.On("Update", mock.Anything, mock.Anything).
+			Return(nil, entity.NewError(entity.KindPreconditionFailed, "record 123 was modified since version 2"))
+
+		w := serve(newChallengeRouter(service), http.MethodPut, "/challenge/123", `{"title":"Go","difficulty":2}`, map[string]string{"If-Match": `"2"`})
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("WeakIfMatchNeverMatches", func(t *testing.T) {
+		service := mockPorts.NewCommunicationChallengeServices(t)
+
+		w := serve(newChallengeRouter(service), http.MethodDelete, "/challenge/123", "", map[string]string{"If-Match": `W/"3"`})
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("DeleteAnyVersion", func(t *testing.T) {
+		service := mockPorts.NewCommunicationChallengeServices(t)
+		service.On("Delete", mock.Anything, mock.MatchedBy(func(request *entity.DeleteRecord) bool {
+			return request.Version == nil
+		})).Return(&entity.Response{}, nil)
+
+		w := serve(newChallengeRouter(service), http.MethodDelete, "/challenge/123", "", map[string]string{"If-Match": "*"})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("RestoreSetsETag", func(t *testing.T) {
+		service := mockPorts.NewCommunicationChallengeServices(t)
+		service.On("Restore", mock.Anything, &entity.RestoreRecord{ID: "123"}).
+			Return(&entity.Response{Data: &schema.ChallengeUpdateResponse{ID: "123", Version: 5}, Version: 5}, nil)
+
+		w := serve(newChallengeRouter(service), http.MethodPost, "/challenge/123/restore", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+	})
+}
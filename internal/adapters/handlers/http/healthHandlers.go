@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"CrudPlatform/internal/core/domain/health"
+	"CrudPlatform/internal/core/ports"
+)
+
+type managementHealthHandler struct {
+	Service ports.CommunicationHealthServices
+}
+
+func newHealthHandler(service ports.CommunicationHealthServices) *managementHealthHandler {
+	return &managementHealthHandler{
+		Service: service,
+	}
+}
+
+// getLive responde /healthz y /livez: el proceso está vivo mientras pueda atender el request.
+func (o *managementHealthHandler) getLive() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writeReport(c, o.Service.Live(c.Request.Context()))
+	}
+}
+
+// getReady responde /readyz con el detalle de cada verificación.
+func (o *managementHealthHandler) getReady() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writeReport(c, o.Service.Ready(c.Request.Context()))
+	}
+}
+
+// writeReport responde 200 si el reporte es ok y 503 si no, para que Kubernetes saque al pod del
+// balanceo sin reiniciarlo.
+func writeReport(c *gin.Context, r *health.Report) {
+	status := http.StatusOK
+	if !r.Healthy() {
+		status = http.StatusServiceUnavailable
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, r)
+}
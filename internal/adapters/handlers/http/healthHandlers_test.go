@@ -0,0 +1,53 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"CrudPlatform/internal/core/domain/health"
+	mockPorts "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newHealthRouter(service *mockPorts.CommunicationHealthServices) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := newHealthHandler(service)
+	router := gin.New()
+	router.GET("/livez", handler.getLive())
+	router.GET("/readyz", handler.getReady())
+	return router
+}
+
+func TestHealthHandlers(t *testing.T) {
+	t.Run("Live", func(t *testing.T) {
+		service := mockPorts.NewCommunicationHealthServices(t)
+		service.On("Live", mock.Anything).Return(&health.Report{Status: health.StatusOK})
+
+		rec := httptest.NewRecorder()
+		newHealthRouter(service).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+	})
+
+	t.Run("NotReady", func(t *testing.T) {
+		service := mockPorts.NewCommunicationHealthServices(t)
+		service.On("Ready", mock.Anything).Return(&health.Report{
+			Status: health.StatusFail,
+			Checks: map[string]health.Result{
+				"database": {Status: health.StatusFail, Error: health.ErrorUnavailable, DurationMS: 3},
+			},
+		})
+
+		rec := httptest.NewRecorder()
+		newHealthRouter(service).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{"status":"fail","checks":{"database":{"status":"fail","error":"unavailable","duration_ms":3}}}`, rec.Body.String())
+	})
+}
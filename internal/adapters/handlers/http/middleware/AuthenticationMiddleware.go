@@ -1,21 +1,41 @@
 package middleware
 
 import (
-	"fmt"
 	"net/http"
+	"strings"
+
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/ports"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AuthenticationMiddleware es un middleware para la autenticación
-func AuthenticationMiddleware() gin.HandlerFunc {
+// ClaimsKey es la llave con la que se guardan los claims validados en el contexto de gin.
+const ClaimsKey = "claims"
+
+// AuthenticationMiddleware es un middleware para la autenticación con tokens bearer JWT.
+//
+// Los claims validados quedan disponibles en el contexto de gin (ClaimsKey) y en el
+// contexto del request (auth.FromContext) para handlers y servicios.
+func AuthenticationMiddleware(validator ports.TokenValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		fmt.Println("Middleware de autenticación invocado")
-		token := c.GetHeader("Authorization")
-		if token != "mi_token_secreto" {
+		header := c.GetHeader("Authorization")
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			c.Header("WWW-Authenticate", `Bearer realm="crudplatform"`)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 			return
 		}
+
+		claims, err := validator.Validate(c.Request.Context(), strings.TrimSpace(token))
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="crudplatform", error="invalid_token"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		c.Set(ClaimsKey, claims)
+		c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), claims))
 		c.Next()
 	}
 }
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAuthRouter(validator *mocks.TokenValidator) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(AuthenticationMiddleware(validator))
+	router.GET("/", func(c *gin.Context) {
+		fromGin, _ := c.Get(ClaimsKey)
+		fromContext, _ := auth.FromContext(c)
+		c.JSON(http.StatusOK, gin.H{
+			"gin":     fromGin.(*auth.Claims).Subject,
+			"context": fromContext.Subject,
+		})
+	})
+	return router
+}
+
+func TestAuthenticationMiddleware(t *testing.T) {
+	t.Run("ValidToken", func(t *testing.T) {
+		validator := mocks.NewTokenValidator(t)
+		validator.On("Validate", mock.Anything, "good-token").Return(&auth.Claims{Subject: "user-1"}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer good-token")
+		newAuthRouter(validator).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"gin":"user-1","context":"user-1"}`, w.Body.String())
+	})
+
+	t.Run("MissingHeader", func(t *testing.T) {
+		validator := mocks.NewTokenValidator(t)
+
+		w := httptest.NewRecorder()
+		newAuthRouter(validator).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+	})
+
+	t.Run("WrongScheme", func(t *testing.T) {
+		validator := mocks.NewTokenValidator(t)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		newAuthRouter(validator).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		validator := mocks.NewTokenValidator(t)
+		validator.On("Validate", mock.Anything, "bad-token").Return(nil, errors.New("invalid token"))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer bad-token")
+		newAuthRouter(validator).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), "invalid_token")
+	})
+}
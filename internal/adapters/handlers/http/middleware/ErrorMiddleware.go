@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// kindStatus relaciona cada clasificación de error de dominio con su status HTTP.
+var kindStatus = map[entity.ErrorKind]int{
+	entity.KindNotFound:     http.StatusNotFound,
+	entity.KindConflict:     http.StatusConflict,
+	entity.KindValidation:   http.StatusUnprocessableEntity,
+	entity.KindUnauthorized: http.StatusUnauthorized,
+	entity.KindForbidden:    http.StatusForbidden,
+	entity.KindUnavailable:  http.StatusServiceUnavailable,
+	entity.KindInternal:     http.StatusInternalServerError,
+
+	entity.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	entity.KindPreconditionRequired: http.StatusPreconditionRequired,
+	entity.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	entity.KindTooLarge:             http.StatusRequestEntityTooLarge,
+}
+
+// StatusFor retorna el status HTTP que corresponde al error según su clasificación.
+func StatusFor(err error) int {
+	if status, ok := kindStatus[entity.KindOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// ErrorMiddleware responde los errores que los handlers registran con c.Error.
+//
+// El último error registrado se responde con el sobre entity.Response; el meta del error
+// se usa como Source. Los errores 5xx no exponen el detalle para no filtrar información
+// interna como mensajes del driver de base de datos.
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		last := c.Errors.Last()
+		if last == nil || c.Writer.Written() {
+			return
+		}
+
+		status := StatusFor(last.Err)
+		source, _ := last.Meta.(string)
+
+		if status == http.StatusUnauthorized {
+			c.Header("WWW-Authenticate", `Bearer realm="crudplatform"`)
+		}
+		c.JSON(status, entity.Response{
+			Result: entity.Result{
+				Details: errorDetails(last.Err, status),
+				Source:  source,
+			},
+		})
+	}
+}
+
+// errorDetails arma un detalle por error; las validaciones generan uno por cada campo inválido.
+func errorDetails(err error, status int) []entity.Detail {
+	var validation *entity.ValidationError
+	if errors.As(err, &validation) && len(validation.Fields) > 0 {
+		details := make([]entity.Detail, len(validation.Fields))
+		for i, field := range validation.Fields {
+			details[i] = entity.Detail{
+				InternalCode: strconv.Itoa(status),
+				Message:      http.StatusText(status),
+				Detail:       field.Message,
+				Field:        field.Field,
+			}
+		}
+		return details
+	}
+
+	detail := err.Error()
+	if status >= http.StatusInternalServerError {
+		detail = http.StatusText(status)
+	}
+	return []entity.Detail{
+		{
+			InternalCode: strconv.Itoa(status),
+			Message:      http.StatusText(status),
+			Detail:       detail,
+		},
+	}
+}
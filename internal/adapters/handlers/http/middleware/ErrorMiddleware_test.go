@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"CrudPlatform/internal/core/domain/auth"
+	entity "CrudPlatform/internal/core/domain/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newErrorRouter(err error) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		_ = c.Error(err).SetMeta("Select User")
+	})
+	return router
+}
+
+func TestErrorMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		detail string
+	}{
+		{"NotFound", entity.NewError(entity.KindNotFound, "user with id 1 not found"), http.StatusNotFound, "user with id 1 not found"},
+		{"Conflict", entity.NewError(entity.KindConflict, "duplicated email"), http.StatusConflict, "duplicated email"},
+		{"Validation", entity.NewError(entity.KindValidation, "invalid role"), http.StatusUnprocessableEntity, "invalid role"},
+		{"Unauthorized", entity.NewError(entity.KindUnauthorized, "invalid credentials"), http.StatusUnauthorized, "invalid credentials"},
+		{"Forbidden", &auth.ForbiddenError{Permission: auth.PermissionUsersUpdate}, http.StatusForbidden, (&auth.ForbiddenError{Permission: auth.PermissionUsersUpdate}).Error()},
+		{"PreconditionFailed", entity.NewError(entity.KindPreconditionFailed, "record 1 was modified since version 2"), http.StatusPreconditionFailed, "record 1 was modified since version 2"},
+		{"PreconditionRequired", entity.NewError(entity.KindPreconditionRequired, "If-Match header is required"), http.StatusPreconditionRequired, "If-Match header is required"},
+		{"UnsupportedMediaType", entity.NewError(entity.KindUnsupportedMediaType, "content type text/plain is not a video"), http.StatusUnsupportedMediaType, "content type text/plain is not a video"},
+		{"TooLarge", entity.NewError(entity.KindTooLarge, "video exceeds 10 bytes"), http.StatusRequestEntityTooLarge, "video exceeds 10 bytes"},
+		{"Unavailable", entity.WrapError(entity.KindUnavailable, errors.New("dial tcp: refused"), "error executing query"), http.StatusServiceUnavailable, "Service Unavailable"},
+		{"Unclassified", errors.New("pq: syntax error"), http.StatusInternalServerError, "Internal Server Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newErrorRouter(tt.err).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.status, w.Code)
+
+			var response entity.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Len(t, response.Result.Details, 1)
+			assert.Equal(t, http.StatusText(tt.status), response.Result.Details[0].Message)
+			assert.Equal(t, tt.detail, response.Result.Details[0].Detail)
+			assert.Equal(t, "Select User", response.Result.Source)
+		})
+	}
+
+	t.Run("ValidationFields", func(t *testing.T) {
+		err := &entity.ValidationError{Fields: []entity.FieldError{
+			{Field: "name", Message: "is required"},
+			{Field: "difficulty", Message: "must be at most 5"},
+		}}
+
+		w := httptest.NewRecorder()
+		newErrorRouter(err).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var response entity.Response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []entity.Detail{
+			{InternalCode: "422", Message: "Unprocessable Entity", Detail: "is required", Field: "name"},
+			{InternalCode: "422", Message: "Unprocessable Entity", Detail: "must be at most 5", Field: "difficulty"},
+		}, response.Result.Details)
+	})
+
+	t.Run("ResponseAlreadyWritten", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(ErrorMiddleware())
+		router.GET("/", func(c *gin.Context) {
+			_ = c.Error(errors.New("ignored"))
+			c.JSON(http.StatusOK, gin.H{"ok": true})
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"ok":true}`, w.Body.String())
+	})
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/domain/logging"
+	"CrudPlatform/internal/core/domain/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoggingMiddleware deja en el contexto del request un logger con su request_id, que servicios y
+// repositorios obtienen con logging.FromContext, y al terminar registra una línea por request.
+// Debe ir después de RequestIDMiddleware.
+//
+// La línea no incluye headers ni la query string, que pueden traer tokens o datos personales.
+func LoggingMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		ctx := logging.With(c.Request.Context(), logger.With("request_id", requestid.FromContext(c.Request.Context())))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []any{
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"route", c.FullPath(),
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"bytes", c.Writer.Size(),
+			"client_ip", c.ClientIP(),
+		}
+		if claims, ok := auth.FromContext(c.Request.Context()); ok {
+			attrs = append(attrs, "user_id", claims.Subject)
+		}
+		if last := c.Errors.Last(); last != nil {
+			attrs = append(attrs, "error", last.Err)
+		}
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logging.FromContext(ctx).Log(ctx, level, "request", attrs...)
+	}
+}
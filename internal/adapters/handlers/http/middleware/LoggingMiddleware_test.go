@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/domain/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), LoggingMiddleware(logging.New(&buf, "json", 0)), ErrorMiddleware())
+	router.GET("/videos/:id", func(c *gin.Context) {
+		c.Request = c.Request.WithContext(auth.WithClaims(c.Request.Context(), &auth.Claims{Subject: "42"}))
+		logging.FromContext(c.Request.Context()).Info("inside", "email", "ana@example.com")
+		c.Error(errors.New("connection refused"))
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/videos/7?token=abc", nil)
+	req.Header.Set(RequestIDHeader, "trace-1")
+	req.Header.Set("Authorization", "Bearer abc")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var inside, entry map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &inside))
+	assert.Equal(t, "trace-1", inside["request_id"])
+	assert.Equal(t, "a***@example.com", inside["email"])
+
+	require.NoError(t, json.Unmarshal(lines[1], &entry))
+	assert.Equal(t, "ERROR", entry["level"])
+	assert.Equal(t, "trace-1", entry["request_id"])
+	assert.Equal(t, "/videos/7", entry["path"])
+	assert.Equal(t, "/videos/:id", entry["route"])
+	assert.Equal(t, float64(500), entry["status"])
+	assert.Equal(t, "42", entry["user_id"])
+	assert.Equal(t, "connection refused", entry["error"])
+	assert.NotContains(t, buf.String(), "abc")
+}
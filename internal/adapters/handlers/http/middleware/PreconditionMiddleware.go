@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreconditionMiddleware exige el header If-Match en las escrituras sobre un registro, para que
+// ningún cliente sobrescriba cambios que no vio. Con required en false no exige nada y los
+// handlers solo condicionan la escritura cuando el cliente envía If-Match.
+func PreconditionMiddleware(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if required && c.GetHeader("If-Match") == "" {
+			c.Error(entity.NewError(entity.KindPreconditionRequired, "If-Match header is required; send the ETag of the record"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPreconditionRouter(required bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorMiddleware())
+	router.PUT("/", PreconditionMiddleware(required), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func TestPreconditionMiddleware(t *testing.T) {
+	t.Run("MissingIfMatch", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newPreconditionRouter(true).ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", nil))
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	})
+
+	t.Run("WithIfMatch", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/", nil)
+		req.Header.Set("If-Match", `"3"`)
+		newPreconditionRouter(true).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("NotRequired", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newPreconditionRouter(false).ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", nil))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"CrudPlatform/internal/core/domain/requestid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader es el header con el que se recibe y se responde el id del request.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength limita el id que se acepta del cliente, ya que se guarda en la auditoría.
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware asigna a cada request un id para correlacionarlo en la auditoría. Se usa el
+// X-Request-ID del cliente o de un proxy cuando es válido; si no, se genera uno. El id se responde
+// en el mismo header y queda en el contexto del request (requestid.FromContext).
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(requestid.With(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// validRequestID acepta ids no vacíos de caracteres ASCII visibles y de largo acotado.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
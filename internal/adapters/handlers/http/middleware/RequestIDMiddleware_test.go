@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"CrudPlatform/internal/core/domain/requestid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveRequestID(header string) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+
+	var seen string
+	router.GET("/", func(c *gin.Context) {
+		seen = requestid.FromContext(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		req.Header.Set(RequestIDHeader, header)
+	}
+	router.ServeHTTP(w, req)
+	return w, seen
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	t.Run("Generated", func(t *testing.T) {
+		w, seen := serveRequestID("")
+		assert.NotEmpty(t, seen)
+		assert.Equal(t, seen, w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("FromClient", func(t *testing.T) {
+		w, seen := serveRequestID("trace-123")
+		assert.Equal(t, "trace-123", seen)
+		assert.Equal(t, "trace-123", w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("InvalidReplaced", func(t *testing.T) {
+		for _, header := range []string{"has space", strings.Repeat("a", maxRequestIDLength+1)} {
+			w, seen := serveRequestID(header)
+			assert.NotEqual(t, header, seen)
+			assert.Equal(t, seen, w.Header().Get(RequestIDHeader))
+		}
+	})
+}
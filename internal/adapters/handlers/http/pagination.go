@@ -0,0 +1,44 @@
+package http
+
+import (
+	"strconv"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setPaginationLinks completa los enlaces next/prev conservando los demás parámetros de la consulta.
+func setPaginationLinks(c *gin.Context, pagination *entity.Pagination) {
+	if pagination == nil {
+		return
+	}
+
+	link := func(key, value string) string {
+		u := *c.Request.URL
+		query := u.Query()
+		query.Del("page")
+		query.Del("cursor")
+		query.Set("page_size", strconv.Itoa(pagination.PageSize))
+		query.Set(key, value)
+		u.RawQuery = query.Encode()
+		return u.RequestURI()
+	}
+
+	if pagination.Page > 0 {
+		if pagination.Page*pagination.PageSize < pagination.Total {
+			pagination.Next = link("page", strconv.Itoa(pagination.Page+1))
+		}
+		if pagination.Page > 1 {
+			pagination.Prev = link("page", strconv.Itoa(pagination.Page-1))
+		}
+		return
+	}
+
+	if pagination.NextCursor != "" {
+		pagination.Next = link("cursor", pagination.NextCursor)
+	}
+	if pagination.PrevCursor != "" {
+		pagination.Prev = link("cursor", pagination.PrevCursor)
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// mergePatchTypes son los Content-Type aceptados en PATCH; application/json se acepta por compatibilidad.
+var mergePatchTypes = map[string]bool{
+	"application/merge-patch+json": true,
+	"application/json":             true,
+}
+
+// bindMergePatch decodifica un JSON Merge Patch (RFC 7396) en un modelo de actualización con
+// campos puntero: un campo ausente queda nil y no se modifica, y un null vacía los campos marcados
+// con patch:"nullable". Un null en cualquier otro campo se reporta junto con las reglas incumplidas (422).
+func bindMergePatch(c *gin.Context, obj any, source string) bool {
+	if !mergePatchTypes[c.ContentType()] {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "unsupported media type, use application/merge-patch+json"})
+		return false
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request"})
+		return false
+	}
+
+	// El patch debe ser un objeto; null, arreglos o escalares reemplazarían el registro completo.
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil || json.Unmarshal(body, obj) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request"})
+		return false
+	}
+
+	var fields []entity.FieldError
+	var cleared []reflect.Value
+	value := reflect.ValueOf(obj).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		raw, ok := patch[name]
+		if !ok || field.Type.Kind() != reflect.Pointer || !bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			continue
+		}
+		if field.Tag.Get("patch") != "nullable" {
+			fields = append(fields, entity.FieldError{Field: name, Message: "cannot be null"})
+			continue
+		}
+		cleared = append(cleared, value.Field(i))
+	}
+
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		var violations validator.ValidationErrors
+		if !errors.As(err, &violations) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request"})
+			return false
+		}
+		fields = append(fields, validationError(violations).Fields...)
+	}
+	if len(fields) > 0 {
+		serviceError(c, &entity.ValidationError{Fields: fields}, source)
+		return false
+	}
+
+	// Un null vacía el campo: el puntero apunta al valor cero, que el repositorio escribe.
+	for _, field := range cleared {
+		field.Set(reflect.New(field.Type().Elem()))
+	}
+	return true
+}
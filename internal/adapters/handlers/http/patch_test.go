@@ -0,0 +1,103 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"CrudPlatform/internal/adapters/handlers/http/middleware"
+	modelChallenge "CrudPlatform/internal/core/domain/repository/model/challenges"
+	modelVideo "CrudPlatform/internal/core/domain/repository/model/videos"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPatchRouter decodifica el patch en obj y lo deja en bound para revisarlo en el test.
+func newPatchRouter(obj func() any, bound *any) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.PATCH("/", func(c *gin.Context) {
+		target := obj()
+		if !bindMergePatch(c, target, "Update") {
+			return
+		}
+		*bound = target
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func patchJSON(router *gin.Engine, contentType, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestBindMergePatch_Challenge(t *testing.T) {
+	var bound any
+	router := newPatchRouter(func() any { return &modelChallenge.UpdateChallenge{} }, &bound)
+
+	t.Run("OnlyPresentFields", func(t *testing.T) {
+		w := patchJSON(router, "application/merge-patch+json", `{"difficulty":2}`)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		request := bound.(*modelChallenge.UpdateChallenge)
+		assert.Nil(t, request.Title)
+		assert.Nil(t, request.Description)
+		require.NotNil(t, request.Difficulty)
+		assert.Equal(t, 2, *request.Difficulty)
+	})
+
+	t.Run("NullClearsNullableField", func(t *testing.T) {
+		w := patchJSON(router, "application/merge-patch+json", `{"description":null}`)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		request := bound.(*modelChallenge.UpdateChallenge)
+		require.NotNil(t, request.Description)
+		assert.Empty(t, *request.Description)
+	})
+
+	t.Run("NullOnRequiredFieldAndViolations", func(t *testing.T) {
+		w := patchJSON(router, "application/merge-patch+json", `{"title":null,"difficulty":0}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, map[string]string{
+			"title":      "cannot be null",
+			"difficulty": "must be at least 1",
+		}, fieldDetails(t, w))
+	})
+
+	t.Run("EmptyTitle", func(t *testing.T) {
+		w := patchJSON(router, "application/json", `{"title":""}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, map[string]string{"title": "must be at least 1 characters long"}, fieldDetails(t, w))
+	})
+
+	t.Run("NotAnObject", func(t *testing.T) {
+		w := patchJSON(router, "application/merge-patch+json", `null`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("UnsupportedMediaType", func(t *testing.T) {
+		w := patchJSON(router, "application/json-patch+json", `[{"op":"replace","path":"/title","value":"Go"}]`)
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+}
+
+func TestBindMergePatch_VideoClearsChallenge(t *testing.T) {
+	var bound any
+	router := newPatchRouter(func() any { return &modelVideo.UpdateVideo{} }, &bound)
+
+	w := patchJSON(router, "application/merge-patch+json", `{"challenge_id":null}`)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	request := bound.(*modelVideo.UpdateVideo)
+	assert.Nil(t, request.Title)
+	require.NotNil(t, request.ChallengeID)
+	assert.Empty(t, *request.ChallengeID)
+}
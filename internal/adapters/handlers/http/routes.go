@@ -1,45 +1,110 @@
 package http
 
 import (
+	"CrudPlatform/cmd/config"
+	"CrudPlatform/internal/adapters/auth"
+	"CrudPlatform/internal/adapters/handlers/http/middleware"
 	repository "CrudPlatform/internal/adapters/repository"
+	"CrudPlatform/internal/adapters/storage"
+	domainAuth "CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/ports"
 	services "CrudPlatform/internal/core/services"
 	"database/sql"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterRoutes(e *gin.Engine, db *sql.DB) {
+func RegisterRoutes(e *gin.Engine, db *sql.DB, cfg *config.Config, health ports.CommunicationHealthServices) {
 	// Crea e inicializa el repositorio BDRepository con la conexión a la base de datos
-	Repository := repository.NewBdRepository(db)
-	RepositoryChallenge := repository.NewBdRepositoryChallenge(db)
-	RepositoryVideo := repository.NewBdRepositoryVideo(db)
+	Repository := repository.NewBdRepository(db, cfg.Database.QueryTimeout)
+	RepositoryChallenge := repository.NewBdRepositoryChallenge(db, cfg.Database.QueryTimeout)
+	RepositoryVideo := repository.NewBdRepositoryVideo(db, cfg.Database.QueryTimeout)
+	RepositoryTokens := repository.NewBdRepositoryTokens(db, cfg.Database.QueryTimeout)
+	RepositoryAudit := repository.NewBdRepositoryAudit(db, cfg.Database.QueryTimeout)
 
-	// Crea e inicializa el servicio con el repositorio
-	Service := services.NewService(Repository)
-	ServiceChallenge := services.NewServiceChallenge(RepositoryChallenge)
-	ServiceVideo := services.NewServiceVideo(RepositoryVideo)
+	// Los archivos de los videos y los avatares se guardan fuera de la base de datos, en el driver configurado
+	Blobs := storage.New(cfg.Storage)
 
-	// Crea el manejador con el servicio y el repositorio
-	managementHandler := newHandler(Service, Repository)
-	managementChallengeHandler := newChallengeHandler(ServiceChallenge, RepositoryChallenge)
-	managementVideoHandler := newVideosHandler(ServiceVideo, RepositoryVideo)
+	// Crea e inicializa el servicio con el repositorio y la política de autorización
+	Policy := domainAuth.NewPolicy()
+	Service := services.NewService(Repository, Policy, RepositoryAudit)
+	ServiceChallenge := services.NewServiceChallenge(RepositoryChallenge, Policy, RepositoryAudit)
+	ServiceVideo := services.NewServiceVideo(RepositoryVideo, Repository, RepositoryChallenge, Policy, RepositoryAudit)
+	ServiceVideoContent := services.NewServiceVideoContent(RepositoryVideo, RepositoryVideo, Blobs, Policy, RepositoryAudit, cfg.Storage.MaxUploadSize)
+	ServiceAvatar := services.NewServiceAvatar(Repository, Blobs, Policy, RepositoryAudit, cfg.Storage.MaxAvatarSize)
+	ServiceAudit := services.NewServiceAudit(RepositoryAudit, Policy)
+	ServiceAuth := services.NewServiceAuth(Repository, RepositoryTokens, auth.NewIssuer(cfg.Auth), cfg.Auth.RefreshTokenTTL)
+
+	// Crea los manejadores con sus servicios
+	managementHandler := newHandler(Service)
+	managementChallengeHandler := newChallengeHandler(ServiceChallenge)
+	managementVideoHandler := newVideosHandler(ServiceVideo)
+	managementVideoContentHandler := newVideoContentHandler(ServiceVideoContent)
+	managementAvatarHandler := newAvatarHandler(ServiceAvatar, cfg.Server.PublicURL)
+	managementAuthHandler := newAuthHandler(ServiceAuth)
+	managementAuditHandler := newAuditHandler(ServiceAudit)
+	managementHealthHandler := newHealthHandler(health)
+
+	// Las rutas de sesión son públicas; el resto exige un access token válido
+	public := e.Group("")
+	protected := e.Group("", middleware.AuthenticationMiddleware(auth.NewValidator(cfg.Auth)))
+
+	// Las escrituras sobre un registro exigen If-Match salvo que la configuración lo desactive
+	ifMatch := middleware.PreconditionMiddleware(cfg.Server.RequireIfMatch)
+
+	// Los probes de Kubernetes no envían token
+	public.GET("/healthz", managementHealthHandler.getLive())
+	public.GET("/livez", managementHealthHandler.getLive())
+	public.GET("/readyz", managementHealthHandler.getReady())
+
+	// Registra las rutas Auth
+	public.POST("/auth/login", managementAuthHandler.postLogin())
+	public.POST("/auth/refresh", managementAuthHandler.postRefresh())
+	public.POST("/auth/logout", managementAuthHandler.postLogout())
+
+	// Los avatares son públicos para que image_path funcione en un <img>, que no envía el token
+	public.GET("/users/:id/avatar/:file", managementAvatarHandler.getAvatar())
 
 	// Registra las rutas Users
-	e.POST("/users/", managementHandler.postUsers())
-	e.GET("/users/:id", managementHandler.getUsers())
-	e.PUT("/users/:id", managementHandler.putUsers())
-	e.DELETE("/users/:id", managementHandler.deleteUsers())
+	protected.POST("/users/", managementHandler.post())
+	protected.GET("/users/", managementHandler.list(nil))
+	protected.GET("/users/me", getMe(managementHandler))
+	protected.GET("/users/:id", managementHandler.get())
+	protected.GET("/users/:id/videos", managementVideoHandler.list(userVideos))
+	protected.PUT("/users/:id", ifMatch, managementHandler.put())
+	protected.PATCH("/users/:id", ifMatch, managementHandler.patch())
+	protected.DELETE("/users/:id", ifMatch, managementHandler.delete())
+	protected.POST("/users/:id/restore", managementHandler.restore())
+	protected.PUT("/users/:id/avatar", ifMatch, managementAvatarHandler.putAvatar())
 
 	// Registra las rutas Challenge
-	e.POST("/challenge/", managementChallengeHandler.postChallenge())
-	e.GET("/challenge/:id", managementChallengeHandler.getChallenge())
-	e.PUT("/challenge/:id", managementChallengeHandler.putChallenge())
-	e.DELETE("/challenge/:id", managementChallengeHandler.deleteChallenge())
+	protected.POST("/challenge/", managementChallengeHandler.post())
+	protected.GET("/challenge/", managementChallengeHandler.list(nil))
+	protected.GET("/challenge/:id", managementChallengeHandler.get())
+	protected.GET("/challenge/:id/videos", managementVideoHandler.list(challengeVideos))
+	protected.PUT("/challenge/:id", ifMatch, managementChallengeHandler.put())
+	protected.PATCH("/challenge/:id", ifMatch, managementChallengeHandler.patch())
+	protected.DELETE("/challenge/:id", ifMatch, managementChallengeHandler.delete())
+	protected.POST("/challenge/:id/restore", managementChallengeHandler.restore())
 
 	// Registra las rutas Video
-	e.POST("/video/", managementVideoHandler.postVideo())
-	e.GET("/video/:id", managementVideoHandler.getVideo())
-	e.PUT("/video/:id", managementVideoHandler.putVideo())
-	e.DELETE("/video/:id", managementVideoHandler.deleteVideo())
+	protected.POST("/video/", managementVideoHandler.post())
+	protected.GET("/video/", managementVideoHandler.list(nil))
+	protected.GET("/video/:id", managementVideoHandler.get())
+	protected.PUT("/video/:id", ifMatch, managementVideoHandler.put())
+	protected.PATCH("/video/:id", ifMatch, managementVideoHandler.patch())
+	protected.DELETE("/video/:id", ifMatch, managementVideoHandler.delete())
+	protected.POST("/video/:id/restore", managementVideoHandler.restore())
+
+	// Registra las rutas del archivo de un video; los uploads por partes siguen el protocolo tus
+	protected.POST("/video/:id/content", managementVideoContentHandler.postVideoContent())
+	protected.GET("/video/:id/content", managementVideoContentHandler.getVideoContent())
+	protected.HEAD("/video/:id/content", managementVideoContentHandler.getVideoContent())
+	protected.HEAD("/video/:id/content/uploads/:upload", managementVideoContentHandler.headUpload())
+	protected.PATCH("/video/:id/content/uploads/:upload", managementVideoContentHandler.patchUpload())
+	protected.DELETE("/video/:id/content/uploads/:upload", managementVideoContentHandler.deleteUpload())
+
+	// Registra las rutas Audit
+	protected.GET("/audit", managementAuditHandler.listAuditEvents())
 
 }
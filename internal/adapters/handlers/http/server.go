@@ -1,7 +1,14 @@
 package http
 
 import (
+	"CrudPlatform/cmd/config"
 	"CrudPlatform/internal/adapters/handlers/http/middleware"
+	"CrudPlatform/internal/core/ports"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
 	"time"
 
 	"database/sql"
@@ -10,24 +17,70 @@ import (
 	cors "github.com/itsjamie/gin-cors"
 )
 
-func CreateServer(db *sql.DB) *gin.Engine {
-	server := gin.Default()
+// CreateServer arma el engine con los middlewares y las rutas; health responde los probes.
+func CreateServer(db *sql.DB, cfg *config.Config, health ports.CommunicationHealthServices) *gin.Engine {
+	// gin.Default agregaría su propio logger de texto; los requests se registran con LoggingMiddleware.
+	server := gin.New()
+	server.Use(gin.Recovery())
+	// Permite que ctx.Value en servicios y repositorios lea los valores del request, como los claims.
+	server.ContextWithFallback = true
 
 	server.Use(cors.Middleware(cors.Config{
 		Origins:        "*",
-		Methods:        "GET,POST,DELETE,PUT",
-		RequestHeaders: "Origin, Authorization, Content-Type, Access-Control-Allow-Origin",
+		Methods:        "GET,HEAD,POST,DELETE,PUT,PATCH",
+		RequestHeaders: "Origin, Authorization, Content-Type, Access-Control-Allow-Origin, If-Match, If-None-Match, X-Request-ID, Range, Tus-Resumable, Upload-Length, Upload-Offset, Upload-Metadata",
+		ExposedHeaders: "ETag, X-Request-ID, Location, Accept-Ranges, Content-Range, Tus-Resumable, Upload-Offset, Upload-Length",
 		MaxAge:         50 * time.Second,
 	}))
+	server.Use(middleware.RequestIDMiddleware())
+	server.Use(middleware.LoggingMiddleware(slog.Default()))
+	server.Use(middleware.ErrorMiddleware())
 
-	server.Use(middleware.AuthenticationMiddleware())
-
-	RegisterRoutes(server, db)
+	RegisterRoutes(server, db, cfg, health)
 
 	return server
 }
 
-func RunServer(db *sql.DB) {
-	server := CreateServer(db)
-	server.Run(":8086")
+// RunServer atiende requests hasta que ctx se cancela, normalmente por SIGINT o SIGTERM, y
+// entonces apaga el servidor con shutdown. Retorna nil si todos los requests en curso terminaron.
+func RunServer(ctx context.Context, db *sql.DB, cfg *config.Config, health ports.CommunicationHealthServices) error {
+	listener, err := net.Listen("tcp", cfg.Server.Addr())
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           CreateServer(db, cfg, health),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	slog.Info("server listening", "addr", listener.Addr().String())
+	return serveUntil(ctx, server, listener, health, cfg.Server)
+}
+
+// serveUntil atiende en listener hasta que ctx se cancela. Entonces hace fallar /readyz, espera
+// ShutdownDelay para que el balanceador saque al pod y le da ShutdownTimeout a los requests en
+// curso; los que no terminan a tiempo se cortan cerrando sus conexiones.
+func serveUntil(ctx context.Context, server *http.Server, listener net.Listener, health ports.CommunicationHealthServices, cfg config.ServerConfig) error {
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-stopped:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down server", "delay", cfg.ShutdownDelay.String(), "timeout", cfg.ShutdownTimeout.String())
+	health.Drain()
+	time.Sleep(cfg.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		server.Close()
+		return fmt.Errorf("draining in-flight requests: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"CrudPlatform/cmd/config"
+	mockPorts "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// startServe atiende handler en un puerto libre y retorna su URL y el resultado de serveUntil.
+func startServe(t *testing.T, ctx context.Context, handler http.Handler, health *mockPorts.CommunicationHealthServices, cfg config.ServerConfig) (string, <-chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- serveUntil(ctx, &http.Server{Handler: handler}, listener, health, cfg)
+	}()
+	return "http://" + listener.Addr().String(), done
+}
+
+func TestServeUntil(t *testing.T) {
+	t.Run("DrainsInFlightRequests", func(t *testing.T) {
+		started, release := make(chan struct{}), make(chan struct{})
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			io.WriteString(w, "done")
+		})
+
+		drained := make(chan struct{})
+		health := mockPorts.NewCommunicationHealthServices(t)
+		health.On("Drain").Run(func(_ mock.Arguments) { close(drained) }).Return()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		url, done := startServe(t, ctx, handler, health, config.ServerConfig{ShutdownTimeout: 5 * time.Second})
+
+		responses := make(chan *http.Response, 1)
+		go func() {
+			resp, err := http.Get(url)
+			assert.NoError(t, err)
+			responses <- resp
+		}()
+		<-started
+		cancel()
+		<-drained
+
+		// Mientras el request sigue en curso, serveUntil espera en lugar de cortarlo.
+		select {
+		case err := <-done:
+			t.Fatalf("serveUntil returned with a request in flight: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		resp := <-responses
+		require.NotNil(t, resp)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "done", string(body))
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		started := make(chan struct{})
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-r.Context().Done()
+		})
+
+		health := mockPorts.NewCommunicationHealthServices(t)
+		health.On("Drain").Return()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		url, done := startServe(t, ctx, handler, health, config.ServerConfig{ShutdownTimeout: 20 * time.Millisecond})
+
+		go func() {
+			if resp, err := http.Get(url); err == nil {
+				resp.Body.Close()
+			}
+		}()
+		<-started
+		cancel()
+
+		// Un request que no termina a tiempo se corta y serveUntil informa el error.
+		assert.ErrorIs(t, <-done, context.DeadlineExceeded)
+	})
+}
@@ -5,97 +5,30 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"CrudPlatform/internal/core/domain/auth"
+	entity "CrudPlatform/internal/core/domain/repository"
 	model "CrudPlatform/internal/core/domain/repository/model/users"
 	"CrudPlatform/internal/core/ports"
 )
 
-type managementHandler struct {
-	Service    ports.CommunicationUserServices
-	Repository ports.DBRepositoryUsers
-}
-
-func newHandler(service ports.CommunicationUserServices, repo ports.DBRepositoryUsers) *managementHandler {
-	return &managementHandler{
-		Service:    service,
-		Repository: repo,
-	}
-}
-
-func (o *managementHandler) postUsers() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var User model.User
-		if err := c.BindJSON(&User); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request"})
-			return
-		}
-		entityResponse, err := o.Service.CreateUser(c, &User)
-		if err != nil {
-			c.JSON(http.StatusNotFound, err.Error())
-			return
-		}
-
-		c.Set("entityResponse", *entityResponse)
-		c.JSON(http.StatusOK, entityResponse)
-	}
-}
-
-func (o *managementHandler) getUsers() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var User model.GetUser
-		User.Id = c.Param("id")
-		if err := c.ShouldBindQuery(&User); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
-			return
-		}
-		entityResponse, err := o.Service.SelectUser(c, &User)
-		if err != nil {
-			c.JSON(http.StatusNotFound, err.Error())
-			return
-		}
+type userHandler = crudHandler[model.User, model.UpdateUser, model.ListUsers, model.ReplaceUser]
 
-		c.Set("entityResponse", *entityResponse)
-		c.JSON(http.StatusOK, entityResponse)
-	}
+func newHandler(service ports.CommunicationUserServices) *userHandler {
+	return newCrudHandler[model.User, model.UpdateUser, model.ListUsers, model.ReplaceUser](service, "User", "Users",
+		func(request *model.UpdateUser, id string, version *int64) {
+			request.Id = id
+			request.Version = version
+		})
 }
 
-func (o *managementHandler) putUsers() gin.HandlerFunc {
+// getMe retorna el usuario dueño del access token.
+func getMe(o *userHandler) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var User model.UpdateUser
-		User.Id = c.Param("id")
-		if err := c.ShouldBindQuery(&User); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
-			return
-		}
-		if err := c.BindJSON(&User); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
+		claims, ok := auth.FromContext(c.Request.Context())
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 			return
 		}
-		entityResponse, err := o.Service.UpdateUser(c, &User)
-		if err != nil {
-			c.JSON(http.StatusNotFound, err.Error())
-			return
-		}
-
-		c.Set("entityResponse", *entityResponse)
-		c.JSON(http.StatusOK, entityResponse)
-	}
-}
-
-func (o *managementHandler) deleteUsers() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var User model.DeleteUser
-		User.Id = c.Param("id")
-		if err := c.ShouldBindQuery(&User); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
-			return
-		}
-		entityResponse, err := o.Service.DeleteUser(c, &User)
-		if err != nil {
-			c.JSON(http.StatusNotFound, err.Error())
-			return
-		}
-
-		c.Set("entityResponse", *entityResponse)
-		c.JSON(http.StatusOK, entityResponse)
+		o.selectRecord(c, &entity.GetRecord{ID: claims.Subject})
 	}
 }
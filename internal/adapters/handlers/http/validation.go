@@ -0,0 +1,145 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// imageSchemes son los esquemas de URL aceptados para image_path.
+var imageSchemes = map[string]bool{"http": true, "https": true}
+
+// Las reglas propias se registran en el validador de gin para usarlas en los tags binding de los modelos.
+func init() {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	// Los errores se reportan con el nombre del campo en el JSON o en la query, que es el que conoce el cliente.
+	engine.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name != "" && name != "-" {
+				return name
+			}
+		}
+		return ""
+	})
+	engine.RegisterValidation("rfcemail", validateEmail)
+	engine.RegisterValidation("imageurl", validateImageURL)
+	engine.RegisterValidation("maxbytes", validateMaxBytes)
+}
+
+// validateEmail acepta solo una dirección RFC 5322 sin nombre visible, como "john@example.com".
+func validateEmail(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value
+}
+
+// validateImageURL acepta URLs absolutas con un esquema permitido.
+func validateImageURL(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	return err == nil && imageSchemes[strings.ToLower(u.Scheme)] && u.Host != ""
+}
+
+// validateMaxBytes limita el largo en bytes, no en caracteres; bcrypt, por ejemplo, no acepta
+// contraseñas de más de 72 bytes aunque tengan menos de 72 caracteres.
+func validateMaxBytes(fl validator.FieldLevel) bool {
+	limit, err := strconv.Atoi(fl.Param())
+	return err == nil && len(fl.Field().String()) <= limit
+}
+
+// bindJSON decodifica y valida el body antes de llamar al servicio. Un body mal formado
+// responde 400; las reglas incumplidas se registran juntas como un error de validación (422).
+func bindJSON(c *gin.Context, obj any, source string) bool {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return true
+	}
+
+	var violations validator.ValidationErrors
+	if errors.As(err, &violations) {
+		serviceError(c, validationError(violations), source)
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request"})
+	return false
+}
+
+// bindQuery es el equivalente de bindJSON para los parámetros de la query.
+func bindQuery(c *gin.Context, obj any, source string) bool {
+	err := c.ShouldBindQuery(obj)
+	if err == nil {
+		return true
+	}
+
+	var violations validator.ValidationErrors
+	if errors.As(err, &violations) {
+		serviceError(c, validationError(violations), source)
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
+	return false
+}
+
+func validationError(violations validator.ValidationErrors) *entity.ValidationError {
+	fields := make([]entity.FieldError, len(violations))
+	for i, violation := range violations {
+		fields[i] = entity.FieldError{
+			Field:   fieldPath(violation),
+			Message: fieldMessage(violation),
+		}
+	}
+	return &entity.ValidationError{Fields: fields}
+}
+
+// fieldPath quita el nombre del struct raíz de la ruta, por ejemplo "User.email" queda "email".
+func fieldPath(violation validator.FieldError) string {
+	_, path, found := strings.Cut(violation.Namespace(), ".")
+	if !found {
+		return violation.Field()
+	}
+	return path
+}
+
+func fieldMessage(violation validator.FieldError) string {
+	switch violation.Tag() {
+	case "required":
+		return "is required"
+	case "rfcemail":
+		return "must be a valid email address"
+	case "uuid":
+		return "must be a UUID"
+	case "imageurl":
+		return "must be an http or https URL"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(violation.Param(), " ", ", ")
+	case "min":
+		if violation.Kind() == reflect.String {
+			return "must be at least " + violation.Param() + " characters long"
+		}
+		return "must be at least " + violation.Param()
+	case "max":
+		if violation.Kind() == reflect.String {
+			return "must be at most " + violation.Param() + " characters long"
+		}
+		return "must be at most " + violation.Param()
+	case "maxbytes":
+		return "must be at most " + violation.Param() + " bytes long"
+	}
+	return "is not valid"
+}
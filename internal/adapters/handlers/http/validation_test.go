@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"CrudPlatform/internal/adapters/handlers/http/middleware"
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelChallenge "CrudPlatform/internal/core/domain/repository/model/challenges"
+	model "CrudPlatform/internal/core/domain/repository/model/users"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidationRouter(obj func() any) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/", func(c *gin.Context) {
+		if !bindJSON(c, obj(), "Create") {
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func postJSON(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func fieldDetails(t *testing.T, w *httptest.ResponseRecorder) map[string]string {
+	var response entity.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	fields := map[string]string{}
+	for _, detail := range response.Result.Details {
+		assert.Equal(t, "422", detail.InternalCode)
+		fields[detail.Field] = detail.Detail
+	}
+	return fields
+}
+
+func TestBindJSON_User(t *testing.T) {
+	router := newValidationRouter(func() any { return &model.User{} })
+
+	t.Run("Valid", func(t *testing.T) {
+		w := postJSON(router, `{"name":"John Doe","email":"john@example.com","image_path":"https://cdn.example.com/john.png","role":"creator","password":"s3cretpass"}`)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("AllViolations", func(t *testing.T) {
+		w := postJSON(router, `{"name":"","email":"John <john@example.com>","image_path":"javascript:alert(1)","role":"root","password":"short"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, map[string]string{
+			"name":       "is required",
+			"email":      "must be a valid email address",
+			"image_path": "must be an http or https URL",
+			"role":       "must be one of: admin, creator, viewer",
+			"password":   "must be at least 8 characters long",
+		}, fieldDetails(t, w))
+	})
+
+	t.Run("PasswordOverBcryptBytes", func(t *testing.T) {
+		// 40 caracteres que ocupan 80 bytes: caben en max=72 pero no en bcrypt.
+		w := postJSON(router, `{"name":"John Doe","email":"john@example.com","password":"`+strings.Repeat("é", 40)+`"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, map[string]string{"password": "must be at most 72 bytes long"}, fieldDetails(t, w))
+	})
+
+	t.Run("MalformedBody", func(t *testing.T) {
+		w := postJSON(router, `{"name":`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestBindJSON_Challenge(t *testing.T) {
+	router := newValidationRouter(func() any { return &modelChallenge.Challenge{} })
+
+	w := postJSON(router, `{"title":"Test Challenge","description":"`+strings.Repeat("a", 5001)+`","difficulty":6}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, map[string]string{
+		"description": "must be at most 5000 characters long",
+		"difficulty":  "must be at most 5",
+	}, fieldDetails(t, w))
+}
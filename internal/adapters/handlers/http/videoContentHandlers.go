@@ -0,0 +1,226 @@
+package http
+
+import (
+	"encoding/base64"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/videos"
+	schema "CrudPlatform/internal/core/domain/repository/schema/videos"
+	"CrudPlatform/internal/core/ports"
+)
+
+// tusResumable es la versión del protocolo tus que siguen los uploads por partes.
+const tusResumable = "1.0.0"
+
+// tusPartContentType es el tipo que tus exige en cada PATCH con una parte del archivo.
+const tusPartContentType = "application/offset+octet-stream"
+
+type managementVideoContentHandler struct {
+	Service ports.CommunicationVideoContentServices
+}
+
+func newVideoContentHandler(service ports.CommunicationVideoContentServices) *managementVideoContentHandler {
+	return &managementVideoContentHandler{
+		Service: service,
+	}
+}
+
+// postVideoContent recibe el archivo completo en la parte "file" de un multipart/form-data o,
+// si viene Upload-Length, inicia un upload por partes al estilo tus.
+func (o *managementVideoContentHandler) postVideoContent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Upload-Length") != "" {
+			o.createUpload(c)
+			return
+		}
+		if c.ContentType() != "multipart/form-data" {
+			serviceError(c, entity.NewError(entity.KindUnsupportedMediaType,
+				"send the video as multipart/form-data or start an upload with Upload-Length"), "Upload Video Content")
+			return
+		}
+		version, ok := ifMatchVersion(c, "Upload Video Content")
+		if !ok {
+			return
+		}
+
+		reader, err := c.Request.MultipartReader()
+		if err != nil {
+			serviceError(c, entity.WrapError(entity.KindValidation, err, "invalid multipart body"), "Upload Video Content")
+			return
+		}
+		part, err := filePart(reader)
+		if err != nil {
+			serviceError(c, err, "Upload Video Content")
+			return
+		}
+		defer part.Close()
+
+		entityResponse, err := o.Service.UploadContent(c.Request.Context(), &model.UploadContent{
+			ID:          c.Param("id"),
+			ContentType: part.Header.Get("Content-Type"),
+			Size:        -1,
+			Body:        part,
+			Version:     version,
+		})
+		if err != nil {
+			serviceError(c, err, "Upload Video Content")
+			return
+		}
+
+		setETag(c, entityResponse.Version)
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
+
+// createUpload crea el upload; el tipo del archivo viene en "filetype" dentro de Upload-Metadata.
+func (o *managementVideoContentHandler) createUpload(c *gin.Context) {
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil {
+		serviceError(c, entity.NewError(entity.KindValidation, "Upload-Length must be a number of bytes"), "Create Upload")
+		return
+	}
+
+	entityResponse, err := o.Service.CreateUpload(c.Request.Context(), &model.CreateUpload{
+		VideoID:     c.Param("id"),
+		Length:      length,
+		ContentType: uploadMetadata(c.GetHeader("Upload-Metadata"))["filetype"],
+	})
+	if err != nil {
+		serviceError(c, err, "Create Upload")
+		return
+	}
+
+	upload := entityResponse.Data.(*schema.UploadResponse)
+	c.Header("Location", "/video/"+upload.VideoID+"/content/uploads/"+upload.ID)
+	setUploadHeaders(c, upload)
+	c.Set("entityResponse", *entityResponse)
+	c.JSON(http.StatusCreated, entityResponse)
+}
+
+// headUpload informa cuántos bytes del upload ya se recibieron, para retomarlo desde ahí.
+func (o *managementVideoContentHandler) headUpload() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entityResponse, err := o.Service.SelectUpload(c.Request.Context(), &model.GetUpload{VideoID: c.Param("id"), ID: c.Param("upload")})
+		if err != nil {
+			serviceError(c, err, "Select Upload")
+			return
+		}
+
+		setUploadHeaders(c, entityResponse.Data.(*schema.UploadResponse))
+		c.Header("Cache-Control", "no-store")
+		c.Set("entityResponse", *entityResponse)
+		c.Status(http.StatusOK)
+	}
+}
+
+// patchUpload agrega una parte en Upload-Offset. La última parte responde también el video con su archivo.
+func (o *managementVideoContentHandler) patchUpload() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.ContentType() != tusPartContentType {
+			serviceError(c, entity.NewError(entity.KindUnsupportedMediaType, "upload parts must be sent as %s", tusPartContentType), "Append Upload")
+			return
+		}
+		offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+		if err != nil || offset < 0 {
+			serviceError(c, entity.NewError(entity.KindValidation, "Upload-Offset must be a number of bytes"), "Append Upload")
+			return
+		}
+
+		entityResponse, err := o.Service.AppendUpload(c.Request.Context(), &model.AppendUpload{
+			VideoID: c.Param("id"),
+			ID:      c.Param("upload"),
+			Offset:  offset,
+			Size:    c.Request.ContentLength,
+			Body:    c.Request.Body,
+		})
+		if err != nil {
+			serviceError(c, err, "Append Upload")
+			return
+		}
+
+		setUploadHeaders(c, entityResponse.Data.(*schema.UploadResponse))
+		setETag(c, entityResponse.Version)
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
+
+func (o *managementVideoContentHandler) deleteUpload() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entityResponse, err := o.Service.DeleteUpload(c.Request.Context(), &model.GetUpload{VideoID: c.Param("id"), ID: c.Param("upload")})
+		if err != nil {
+			serviceError(c, err, "Delete Upload")
+			return
+		}
+
+		c.Header("Tus-Resumable", tusResumable)
+		c.Set("entityResponse", *entityResponse)
+		c.JSON(http.StatusOK, entityResponse)
+	}
+}
+
+// getVideoContent descarga el archivo del video. http.ServeContent responde los Range, para que
+// un reproductor pueda saltar a cualquier posición, y los condicionales con el checksum como ETag.
+func (o *managementVideoContentHandler) getVideoContent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		content, err := o.Service.OpenContent(c.Request.Context(), &model.GetContent{ID: c.Param("id")})
+		if err != nil {
+			serviceError(c, err, "Get Video Content")
+			return
+		}
+		defer content.Body.Close()
+
+		c.Header("ETag", `"`+content.Checksum+`"`)
+		c.Header("Content-Type", content.ContentType)
+		http.ServeContent(c.Writer, c.Request, "", content.UpdatedAt, content.Body)
+	}
+}
+
+func setUploadHeaders(c *gin.Context, upload *schema.UploadResponse) {
+	c.Header("Tus-Resumable", tusResumable)
+	c.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(upload.Length, 10))
+}
+
+// filePart avanza hasta la parte "file" del formulario, descartando los demás campos.
+func filePart(reader *multipart.Reader) (*multipart.Part, error) {
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, entity.NewError(entity.KindValidation, "multipart body has no file part")
+		}
+		if err != nil {
+			return nil, entity.WrapError(entity.KindValidation, err, "invalid multipart body")
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+// uploadMetadata decodifica Upload-Metadata, pares "clave valor-en-base64" separados por comas.
+// Los pares mal formados se ignoran.
+func uploadMetadata(header string) map[string]string {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			continue
+		}
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		metadata[key] = string(value)
+	}
+	return metadata
+}
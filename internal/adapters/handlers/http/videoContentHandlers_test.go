@@ -0,0 +1,161 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"CrudPlatform/internal/adapters/handlers/http/middleware"
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/videos"
+	schema "CrudPlatform/internal/core/domain/repository/schema/videos"
+	mockPorts "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newVideoContentRouter(service *mockPorts.CommunicationVideoContentServices) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := newVideoContentHandler(service)
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.POST("/video/:id/content", handler.postVideoContent())
+	router.GET("/video/:id/content", handler.getVideoContent())
+	router.HEAD("/video/:id/content/uploads/:upload", handler.headUpload())
+	router.PATCH("/video/:id/content/uploads/:upload", handler.patchUpload())
+	return router
+}
+
+type closingReader struct {
+	*strings.Reader
+	closed bool
+}
+
+func (r *closingReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestGetVideoContent(t *testing.T) {
+	body := &closingReader{Reader: strings.NewReader("0123456789")}
+	service := mockPorts.NewCommunicationVideoContentServices(t)
+	service.On("OpenContent", mock.Anything, &model.GetContent{ID: "123"}).Return(&model.Content{
+		Body: body, Size: 10, ContentType: "video/mp4", Checksum: "abc", UpdatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}, nil)
+
+	w := serve(newVideoContentRouter(service), http.MethodGet, "/video/123/content", "", map[string]string{"Range": "bytes=2-5"})
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "2345", w.Body.String())
+	assert.Equal(t, "bytes 2-5/10", w.Header().Get("Content-Range"))
+	assert.Equal(t, "video/mp4", w.Header().Get("Content-Type"))
+	assert.Equal(t, `"abc"`, w.Header().Get("ETag"))
+	assert.True(t, body.closed)
+}
+
+func TestGetVideoContent_NotFound(t *testing.T) {
+	service := mockPorts.NewCommunicationVideoContentServices(t)
+	service.On("OpenContent", mock.Anything, mock.Anything).Return(nil, entity.NewError(entity.KindNotFound, "video with id 123 has no content"))
+
+	w := serve(newVideoContentRouter(service), http.MethodGet, "/video/123/content", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPostVideoContent_Multipart(t *testing.T) {
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	require.NoError(t, writer.WriteField("title", "ignored"))
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="file"; filename="clip.mp4"`},
+		"Content-Type":        {"video/mp4"},
+	})
+	require.NoError(t, err)
+	_, _ = part.Write([]byte("hello"))
+	require.NoError(t, writer.Close())
+
+	service := mockPorts.NewCommunicationVideoContentServices(t)
+	service.On("UploadContent", mock.Anything, mock.MatchedBy(func(request *model.UploadContent) bool {
+		data, _ := io.ReadAll(request.Body)
+		return request.ID == "123" && request.ContentType == "video/mp4" && string(data) == "hello" &&
+			request.Version != nil && *request.Version == 2
+	})).Return(&entity.Response{Data: &schema.VideosUpdateResponse{ID: "123", Version: 3}, Version: 3}, nil)
+
+	w := serve(newVideoContentRouter(service), http.MethodPost, "/video/123/content", form.String(),
+		map[string]string{"Content-Type": writer.FormDataContentType(), "If-Match": `"2"`})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+}
+
+func TestPostVideoContent_UnsupportedBody(t *testing.T) {
+	service := mockPorts.NewCommunicationVideoContentServices(t)
+
+	w := serve(newVideoContentRouter(service), http.MethodPost, "/video/123/content", "hello", map[string]string{"Content-Type": "video/mp4"})
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
+
+func TestResumableUploadHandlers(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		service := mockPorts.NewCommunicationVideoContentServices(t)
+		service.On("CreateUpload", mock.Anything, &model.CreateUpload{VideoID: "123", Length: 11, ContentType: "video/mp4"}).
+			Return(&entity.Response{Data: &schema.UploadResponse{ID: "up-1", VideoID: "123", Length: 11}}, nil)
+
+		w := serve(newVideoContentRouter(service), http.MethodPost, "/video/123/content", "", map[string]string{
+			"Tus-Resumable":   "1.0.0",
+			"Upload-Length":   "11",
+			"Upload-Metadata": "filename Y2xpcC5tcDQ=,filetype dmlkZW8vbXA0",
+		})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "/video/123/content/uploads/up-1", w.Header().Get("Location"))
+		assert.Equal(t, "0", w.Header().Get("Upload-Offset"))
+		assert.Equal(t, "1.0.0", w.Header().Get("Tus-Resumable"))
+	})
+
+	t.Run("Head", func(t *testing.T) {
+		service := mockPorts.NewCommunicationVideoContentServices(t)
+		service.On("SelectUpload", mock.Anything, &model.GetUpload{VideoID: "123", ID: "up-1"}).
+			Return(&entity.Response{Data: &schema.UploadResponse{ID: "up-1", VideoID: "123", Length: 11, Offset: 6}}, nil)
+
+		w := serve(newVideoContentRouter(service), http.MethodHead, "/video/123/content/uploads/up-1", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "6", w.Header().Get("Upload-Offset"))
+		assert.Equal(t, "11", w.Header().Get("Upload-Length"))
+	})
+
+	t.Run("Patch", func(t *testing.T) {
+		service := mockPorts.NewCommunicationVideoContentServices(t)
+		service.On("AppendUpload", mock.Anything, mock.MatchedBy(func(request *model.AppendUpload) bool {
+			return request.ID == "up-1" && request.Offset == 6 && request.Size == 5
+		})).Return(&entity.Response{Data: &schema.UploadResponse{ID: "up-1", VideoID: "123", Length: 11, Offset: 11}, Version: 4}, nil)
+
+		w := serve(newVideoContentRouter(service), http.MethodPatch, "/video/123/content/uploads/up-1", "world", map[string]string{
+			"Content-Type":  "application/offset+octet-stream",
+			"Upload-Offset": "6",
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "11", w.Header().Get("Upload-Offset"))
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("PatchWrongContentType", func(t *testing.T) {
+		service := mockPorts.NewCommunicationVideoContentServices(t)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/video/123/content/uploads/up-1", strings.NewReader("world"))
+		req.Header.Set("Upload-Offset", "6")
+		newVideoContentRouter(service).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+}
+
+func TestUploadMetadata(t *testing.T) {
+	metadata := uploadMetadata("filename Y2xpcC5tcDQ=, filetype dmlkZW8vbXA0,broken !!,empty")
+	assert.Equal(t, map[string]string{"filename": "clip.mp4", "filetype": "video/mp4", "empty": ""}, metadata)
+}
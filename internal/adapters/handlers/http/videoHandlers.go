@@ -1,101 +1,28 @@
 package http
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 
 	model "CrudPlatform/internal/core/domain/repository/model/videos"
 	"CrudPlatform/internal/core/ports"
 )
 
-type managementVideoHandler struct {
-	Service    ports.CommunicationVideoServices
-	Repository ports.DBRepositoryVideo
-}
-
-func newVideosHandler(service ports.CommunicationVideoServices, repo ports.DBRepositoryVideo) *managementVideoHandler {
-	return &managementVideoHandler{
-		Service:    service,
-		Repository: repo,
-	}
-}
-
-func (o *managementVideoHandler) postVideo() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var User model.Videos
-		if err := c.BindJSON(&User); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request"})
-			return
-		}
-		entityResponse, err := o.Service.CreateVideo(c, &User)
-		if err != nil {
-			c.JSON(http.StatusNotFound, err.Error())
-			return
-		}
+type videoHandler = crudHandler[model.Videos, model.UpdateVideo, model.ListVideos, model.ReplaceVideo]
 
-		c.Set("entityResponse", *entityResponse)
-		c.JSON(http.StatusOK, entityResponse)
-	}
+func newVideosHandler(service ports.CommunicationVideoServices) *videoHandler {
+	return newCrudHandler[model.Videos, model.UpdateVideo, model.ListVideos, model.ReplaceVideo](service, "Video", "Videos",
+		func(request *model.UpdateVideo, id string, version *int64) {
+			request.ID = id
+			request.Version = version
+		})
 }
 
-func (o *managementVideoHandler) getVideo() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var User model.GetVideo
-		User.ID = c.Param("id")
-		if err := c.ShouldBindQuery(&User); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
-			return
-		}
-		entityResponse, err := o.Service.SelectVideo(c, &User)
-		if err != nil {
-			c.JSON(http.StatusNotFound, err.Error())
-			return
-		}
-
-		c.Set("entityResponse", *entityResponse)
-		c.JSON(http.StatusOK, entityResponse)
-	}
+// userVideos lista los videos del usuario de la ruta, como GET /users/:id/videos.
+func userVideos(c *gin.Context, request *model.ListVideos) {
+	request.UserID = c.Param("id")
 }
 
-func (o *managementVideoHandler) putVideo() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var User model.UpdateVideo
-		User.ID = c.Param("id")
-		if err := c.ShouldBindQuery(&User); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
-			return
-		}
-		if err := c.BindJSON(&User); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
-			return
-		}
-		entityResponse, err := o.Service.UpdateVideo(c, &User)
-		if err != nil {
-			c.JSON(http.StatusNotFound, err.Error())
-			return
-		}
-
-		c.Set("entityResponse", *entityResponse)
-		c.JSON(http.StatusOK, entityResponse)
-	}
-}
-
-func (o *managementVideoHandler) deleteVideo() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var User model.DeleteVideo
-		User.ID = c.Param("id")
-		if err := c.ShouldBindQuery(&User); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Request "})
-			return
-		}
-		entityResponse, err := o.Service.DeleteVideo(c, &User)
-		if err != nil {
-			c.JSON(http.StatusNotFound, err.Error())
-			return
-		}
-
-		c.Set("entityResponse", *entityResponse)
-		c.JSON(http.StatusOK, entityResponse)
-	}
+// challengeVideos lista los videos que responden al challenge de la ruta, como GET /challenge/:id/videos.
+func challengeVideos(c *gin.Context, request *model.ListVideos) {
+	request.ChallengeID = c.Param("id")
 }
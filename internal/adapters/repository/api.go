@@ -1,39 +1,79 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
-	"sync"
+	"time"
+
+	"CrudPlatform/internal/core/domain/logging"
+	modelChallenges "CrudPlatform/internal/core/domain/repository/model/challenges"
+	modelUsers "CrudPlatform/internal/core/domain/repository/model/users"
+	modelVideos "CrudPlatform/internal/core/domain/repository/model/videos"
+	schemaChallenges "CrudPlatform/internal/core/domain/repository/schema/challenges"
+	schemaUsers "CrudPlatform/internal/core/domain/repository/schema/users"
+	schemaVideos "CrudPlatform/internal/core/domain/repository/schema/videos"
 )
 
+// Los repositorios no serializan sus operaciones: *sql.DB es un pool de conexiones seguro
+// para uso concurrente, y su tamaño se configura en config.DatabaseConfig.
+//
+// Cada operación usa el contexto recibido, por lo que se cancela si el cliente se desconecta,
+// y además se limita a timeout.
 type BDRepository struct {
-	db *sql.DB
-	mu sync.Mutex
+	crudRepository[modelUsers.User, modelUsers.UpdateUser, modelUsers.ListUsers, schemaUsers.UsersGetResponse, schemaUsers.UsersUpdateResponse]
 }
 
 type BDRepositoryChallenge struct {
-	db *sql.DB
-	mu sync.Mutex
+	crudRepository[modelChallenges.Challenge, modelChallenges.UpdateChallenge, modelChallenges.ListChallenges, schemaChallenges.ChallengeGetResponse, schemaChallenges.ChallengeUpdateResponse]
 }
 
 type BDRepositoryVideo struct {
-	db *sql.DB
-	mu sync.Mutex
+	crudRepository[modelVideos.Videos, modelVideos.UpdateVideo, modelVideos.ListVideos, schemaVideos.VideosGetResponse, schemaVideos.VideosUpdateResponse]
+}
+
+type BDRepositoryTokens struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+type BDRepositoryAudit struct {
+	db      *sql.DB
+	timeout time.Duration
+}
+
+func NewBdRepository(db *sql.DB, timeout time.Duration) *BDRepository {
+	return &BDRepository{crudRepository: newCrudRepository(db, timeout, usersSpec)}
+}
+
+func NewBdRepositoryChallenge(db *sql.DB, timeout time.Duration) *BDRepositoryChallenge {
+	return &BDRepositoryChallenge{crudRepository: newCrudRepository(db, timeout, challengesSpec)}
+}
+
+func NewBdRepositoryVideo(db *sql.DB, timeout time.Duration) *BDRepositoryVideo {
+	return &BDRepositoryVideo{crudRepository: newCrudRepository(db, timeout, videosSpec)}
 }
 
-func NewBdRepository(db *sql.DB) *BDRepository {
-	return &BDRepository{
-		db: db,
+func NewBdRepositoryTokens(db *sql.DB, timeout time.Duration) *BDRepositoryTokens {
+	return &BDRepositoryTokens{
+		db:      db,
+		timeout: timeout,
 	}
 }
 
-func NewBdRepositoryChallenge(db *sql.DB) *BDRepositoryChallenge {
-	return &BDRepositoryChallenge{
-		db: db,
+func NewBdRepositoryAudit(db *sql.DB, timeout time.Duration) *BDRepositoryAudit {
+	return &BDRepositoryAudit{
+		db:      db,
+		timeout: timeout,
 	}
 }
 
-func NewBdRepositoryVideo(db *sql.DB) *BDRepositoryVideo {
-	return &BDRepositoryVideo{
-		db: db,
+// withTimeout limita la duración de una operación; un timeout 0 solo respeta el contexto recibido.
+// attrs, como el id del registro, se agregan al logger del contexto para que las consultas
+// registradas en el log indiquen sobre qué registro se hicieron.
+func withTimeout(ctx context.Context, timeout time.Duration, attrs ...any) (context.Context, context.CancelFunc) {
+	ctx = logging.WithAttrs(ctx, attrs...)
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
 	}
+	return context.WithTimeout(ctx, timeout)
 }
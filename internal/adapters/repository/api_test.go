@@ -1,8 +1,8 @@
 package repository
 
 import (
-	"sync"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -14,10 +14,11 @@ func TestNewBdRepository(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	repo := NewBdRepository(db)
+	repo := NewBdRepository(db, time.Second)
 
 	assert.NotNil(t, repo)
 	assert.Equal(t, db, repo.db)
+	assert.Equal(t, time.Second, repo.timeout)
 }
 
 func TestNewBdRepositoryChallenge(t *testing.T) {
@@ -25,10 +26,11 @@ func TestNewBdRepositoryChallenge(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	repo := NewBdRepositoryChallenge(db)
+	repo := NewBdRepositoryChallenge(db, time.Second)
 
 	assert.NotNil(t, repo)
 	assert.Equal(t, db, repo.db)
+	assert.Equal(t, time.Second, repo.timeout)
 }
 
 func TestNewBdRepositoryVideo(t *testing.T) {
@@ -36,10 +38,11 @@ func TestNewBdRepositoryVideo(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	repo := NewBdRepositoryVideo(db)
+	repo := NewBdRepositoryVideo(db, time.Second)
 
 	assert.NotNil(t, repo)
 	assert.Equal(t, db, repo.db)
+	assert.Equal(t, time.Second, repo.timeout)
 }
 
 func TestRepositoryStructures(t *testing.T) {
@@ -48,20 +51,41 @@ func TestRepositoryStructures(t *testing.T) {
 	defer db.Close()
 
 	t.Run("BDRepository Structure", func(t *testing.T) {
-		repo := &BDRepository{db: db}
-		assert.IsType(t, &sync.Mutex{}, &repo.mu)
+		repo := NewBdRepository(db, 0)
 		assert.Equal(t, db, repo.db)
 	})
 
 	t.Run("BDRepositoryChallenge Structure", func(t *testing.T) {
-		repo := &BDRepositoryChallenge{db: db}
-		assert.IsType(t, &sync.Mutex{}, &repo.mu)
+		repo := NewBdRepositoryChallenge(db, 0)
 		assert.Equal(t, db, repo.db)
 	})
 
 	t.Run("BDRepositoryVideo Structure", func(t *testing.T) {
-		repo := &BDRepositoryVideo{db: db}
-		assert.IsType(t, &sync.Mutex{}, &repo.mu)
+		repo := NewBdRepositoryVideo(db, 0)
 		assert.Equal(t, db, repo.db)
 	})
 }
+
+func TestNewBdRepositoryTokens(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBdRepositoryTokens(db, time.Second)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+	assert.Equal(t, time.Second, repo.timeout)
+}
+
+func TestNewBdRepositoryAudit(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBdRepositoryAudit(db, time.Second)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+	assert.Equal(t, time.Second, repo.timeout)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/audit"
+	schema "CrudPlatform/internal/core/domain/repository/schema/audit"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WithinTx ejecuta fn en una transacción, para que los eventos se guarden junto con la escritura
+// que auditan en los repositorios que comparten la misma base de datos.
+func (p *BDRepositoryAudit) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, p.db, fn)
+}
+
+func (p *BDRepositoryAudit) CreateAuditEvent(ctx context.Context, event *model.Event) error {
+	ctx, cancel := withTimeout(ctx, p.timeout, "entity_type", event.EntityType, "entity_id", event.EntityID)
+	defer cancel()
+
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	query := `
+		INSERT INTO audit_events (id, actor, action, entity_type, entity_id, before, after, request_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := conn(ctx, p.db).ExecContext(ctx, query, event.ID, nullString(event.Actor), string(event.Action), event.EntityType, event.EntityID,
+		nullJSON(event.Before), nullJSON(event.After), nullString(event.RequestID), event.CreatedAt)
+	if err != nil {
+		return dbError(err, "error inserting audit event")
+	}
+
+	return nil
+}
+
+var auditEventsTable = tableSpec{
+	name:    "audit_events",
+	columns: "id, actor, action, entity_type, entity_id, before, after, request_id, created_at",
+	fields: map[string]string{
+		"id":         "id",
+		"created_at": "created_at",
+	},
+	filters: map[string]string{
+		"entity_type": "entity_type",
+		"entity_id":   "entity_id",
+		"actor":       "actor",
+	},
+}
+
+func (p *BDRepositoryAudit) ListAuditEvents(ctx context.Context, request *model.ListEvents) (*entity.Page[schema.EventResponse], error) {
+	ctx, cancel := withTimeout(ctx, p.timeout)
+	defer cancel()
+
+	return listPage(ctx, p.db, listSpec[schema.EventResponse]{
+		tableSpec: auditEventsTable,
+		scan: func(rows *sql.Rows) (row[schema.EventResponse], error) {
+			var response schema.EventResponse
+			var actor, requestID sql.NullString
+			var before, after []byte
+			var createdAt time.Time
+			err := rows.Scan(&response.ID, &actor, &response.Action, &response.Entity, &response.EntityID, &before, &after, &requestID, &createdAt)
+			response.Actor = actor.String
+			response.Before = json.RawMessage(before)
+			response.After = json.RawMessage(after)
+			response.RequestID = requestID.String
+			response.CreatedAt = formatTimestamp(createdAt)
+			return row[schema.EventResponse]{
+				item:   response,
+				id:     response.ID,
+				fields: map[string]any{"created_at": createdAt},
+			}, err
+		},
+	}, request.PageRequest, request.Query())
+}
+
+// nullJSON guarda como NULL los documentos vacíos, como el estado previo de una creación.
+func nullJSON(value json.RawMessage) any {
+	if len(value) == 0 {
+		return nil
+	}
+	return string(value)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/audit"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBDRepositoryAudit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := &BDRepositoryAudit{db: db}
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("CreateAuditEvent", func(t *testing.T) {
+		event := &model.Event{
+			Actor:      "user-1",
+			Action:     model.ActionUpdate,
+			EntityType: model.EntityVideo,
+			EntityID:   "video-1",
+			Before:     json.RawMessage(`{"title":"Old"}`),
+			After:      json.RawMessage(`{"title":"New"}`),
+			RequestID:  "req-1",
+			CreatedAt:  now,
+		}
+
+		mock.ExpectExec("INSERT INTO audit_events").
+			WithArgs(sqlmock.AnyArg(), "user-1", "update", "video", "video-1", `{"title":"Old"}`, `{"title":"New"}`, "req-1", now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.CreateAuditEvent(ctx, event)
+		require.NoError(t, err)
+		assert.NotEmpty(t, event.ID)
+	})
+
+	t.Run("CreateAuditEventWithoutState", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO audit_events").
+			WithArgs(sqlmock.AnyArg(), nil, "delete", "user", "user-2", nil, nil, nil, now).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.CreateAuditEvent(ctx, &model.Event{Action: model.ActionDelete, EntityType: model.EntityUser, EntityID: "user-2", CreatedAt: now})
+		require.NoError(t, err)
+	})
+
+	t.Run("CreateAuditEventError", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO audit_events").
+			WillReturnError(fmt.Errorf("insert error"))
+
+		err := repo.CreateAuditEvent(ctx, &model.Event{Action: model.ActionCreate, EntityType: model.EntityUser, EntityID: "user-3"})
+		assert.Equal(t, entity.KindInternal, entity.KindOf(err))
+		assert.Contains(t, err.Error(), "error inserting audit event")
+	})
+
+	t.Run("ListAuditEventsByEntity", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM audit_events WHERE entity_type = \\$1 AND entity_id = \\$2$").
+			WithArgs("video", "video-1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT (.+) FROM audit_events WHERE entity_type = \\$1 AND entity_id = \\$2 ORDER BY created_at DESC, id DESC").
+			WithArgs("video", "video-1", entity.MaxPageSize+1, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "actor", "action", "entity_type", "entity_id", "before", "after", "request_id", "created_at"}).
+				AddRow("event-1", "user-1", "update", "video", "video-1", []byte(`{"title":"Old"}`), []byte(`{"title":"New"}`), "req-1", now))
+
+		page, err := repo.ListAuditEvents(ctx, &model.ListEvents{Entity: "video", ID: "video-1"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, page.Total)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, "update", page.Items[0].Action)
+		assert.JSONEq(t, `{"title":"Old"}`, string(page.Items[0].Before))
+		assert.JSONEq(t, `{"title":"New"}`, string(page.Items[0].After))
+		assert.Equal(t, "req-1", page.Items[0].RequestID)
+		assert.Equal(t, formatTimestamp(now), page.Items[0].CreatedAt)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,100 +1,85 @@
 package repository
 
 import (
+	entity "CrudPlatform/internal/core/domain/repository"
 	model "CrudPlatform/internal/core/domain/repository/model/challenges"
 	schema "CrudPlatform/internal/core/domain/repository/schema/challenges"
 	"database/sql"
-	"fmt"
 	"time"
-
-	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
-func (p *BDRepositoryChallenge) CreateChallenge(ctx *gin.Context, request *model.Challenge) (string, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	id := uuid.NewString()
-	now := time.Now().UTC()
-
-	query := `
-		INSERT INTO challenges (id, title, description, difficulty, created_at, updated_at) 
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
-	_, err := p.db.Exec(query, id, request.Title, request.Description, request.Difficulty, now, now)
-	if err != nil {
-		return "", fmt.Errorf("error executing statement: %w", err)
-	}
-
-	return id, nil
+var challengesTable = tableSpec{
+	name:    "challenges",
+	columns: "id, title, description, difficulty, created_at, updated_at, version, deleted_at",
+	fields: map[string]string{
+		"id":         "id",
+		"title":      "title",
+		"difficulty": "difficulty",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	search:        "search_vector",
+	searchColumns: []string{"title", "description"},
+	softDelete:    true,
+	numeric:       map[string]bool{"difficulty": true},
 }
 
-func (p *BDRepositoryChallenge) SelectChallenge(ctx *gin.Context, request *model.GetChallenge) (*schema.ChallengeGetResponse, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	query := "SELECT title, description, difficulty, created_at, updated_at FROM challenges WHERE id = $1"
-	row := p.db.QueryRow(query, request.ID)
-
-	var response schema.ChallengeGetResponse
-
-	err := row.Scan(&response.Title, &response.Description, &response.Difficulty, &response.CreatedAt, &response.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("challenge with id %s not found", request.ID)
+var challengesSpec = &crudSpec[model.Challenge, model.UpdateChallenge, model.ListChallenges, schema.ChallengeGetResponse, schema.ChallengeUpdateResponse]{
+	table: "challenges",
+	noun:  "challenge",
+	insert: func(request *model.Challenge) ([]string, []any) {
+		return []string{"title", "description", "difficulty", "created_by"},
+			[]any{request.Title, request.Description, request.Difficulty, request.CreatedBy}
+	},
+	assign: func(b *queryBuilder, request *model.UpdateChallenge) (string, *int64) {
+		if request.Title != nil {
+			b.set("title", *request.Title)
 		}
-		return nil, fmt.Errorf("error scanning challenge row: %w", err)
-	}
-
-	return &response, nil
-}
-
-func (p *BDRepositoryChallenge) UpdateChallenge(ctx *gin.Context, request *model.UpdateChallenge) (*schema.ChallengeUpdateResponse, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	now := time.Now().UTC()
-
-	query := "UPDATE challenges SET title = $1, description = $2, difficulty = $3, updated_at = $4 WHERE id = $5"
-	_, err := p.db.Exec(query, request.Title, request.Description, request.Difficulty, now, request.ID)
-	if err != nil {
-		return nil, fmt.Errorf("error executing update: %w", err)
-	}
-
-	updatedQuery := "SELECT title, description, difficulty, updated_at FROM challenges WHERE id = $1"
-	updatedRow := p.db.QueryRow(updatedQuery, request.ID)
-
-	var response schema.ChallengeUpdateResponse
-	err = updatedRow.Scan(&response.Title, &response.Description, &response.Difficulty, &response.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("challenge with id %s not found after update", request.ID)
+		if request.Description != nil {
+			b.set("description", *request.Description)
 		}
-		return nil, fmt.Errorf("error scanning updated challenge row: %w", err)
-	}
-
-	return &response, nil
-}
-
-func (p *BDRepositoryChallenge) DeleteChallenge(ctx *gin.Context, request *model.DeleteChallenge) error {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	query := "DELETE FROM challenges WHERE id = $1"
-	result, err := p.db.Exec(query, request.ID)
-	if err != nil {
-		return err
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("challenge with id %s not found", request.ID)
-	}
-
-	return nil
+		if request.Difficulty != nil {
+			b.set("difficulty", *request.Difficulty)
+		}
+		return request.ID, request.Version
+	},
+	columns: "title, description, difficulty, created_by, created_at, updated_at, version, deleted_at",
+	scan: func(row *sql.Row, id string) (*schema.ChallengeGetResponse, error) {
+		response := schema.ChallengeGetResponse{ID: id}
+		var createdBy sql.NullString
+		err := row.Scan(&response.Title, &response.Description, &response.Difficulty, &createdBy, &response.CreatedAt, &response.UpdatedAt, &response.Version, &response.DeletedAt)
+		response.CreatedBy = createdBy.String
+		return &response, err
+	},
+	returning: "id, title, description, difficulty, created_at, updated_at, version",
+	scanWrite: func(row *sql.Row) (*schema.ChallengeUpdateResponse, error) {
+		var response schema.ChallengeUpdateResponse
+		err := row.Scan(&response.ID, &response.Title, &response.Description, &response.Difficulty, &response.CreatedAt, &response.UpdatedAt, &response.Version)
+		return &response, err
+	},
+	list: listSpec[schema.ChallengeGetResponse]{
+		tableSpec: challengesTable,
+		scan: func(rows *sql.Rows) (row[schema.ChallengeGetResponse], error) {
+			var response schema.ChallengeGetResponse
+			var createdAt, updatedAt time.Time
+			var deletedAt sql.NullTime
+			err := rows.Scan(&response.ID, &response.Title, &response.Description, &response.Difficulty, &createdAt, &updatedAt, &response.Version, &deletedAt)
+			response.CreatedAt = formatTimestamp(createdAt)
+			response.UpdatedAt = formatTimestamp(updatedAt)
+			response.DeletedAt = formatDeletedAt(deletedAt)
+			return row[schema.ChallengeGetResponse]{
+				item: response,
+				id:   response.ID,
+				fields: map[string]any{
+					"title":      response.Title,
+					"difficulty": response.Difficulty,
+					"created_at": createdAt,
+					"updated_at": updatedAt,
+				},
+			}, err
+		},
+	},
+	page: func(request *model.ListChallenges) (entity.PageRequest, entity.ListQuery) {
+		return request.PageRequest, request.Query()
+	},
 }
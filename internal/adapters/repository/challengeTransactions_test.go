@@ -1,14 +1,16 @@
 package repository
 
 import (
+	entity "CrudPlatform/internal/core/domain/repository"
 	"CrudPlatform/internal/core/domain/repository/model/challenges"
+	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,21 +21,22 @@ func TestBDRepositoryChallenge(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	repo := &BDRepositoryChallenge{db: db}
-	ctx := &gin.Context{}
+	repo := NewBdRepositoryChallenge(db, 0)
+	ctx := context.Background()
 
 	t.Run("CreateChallenge", func(t *testing.T) {
 		challenge := &challenges.Challenge{
 			Title:       "Test Challenge",
 			Description: "This is a test challenge",
 			Difficulty:  3,
+			CreatedBy:   "user-1",
 		}
 
 		mock.ExpectExec("INSERT INTO challenges").
-			WithArgs(sqlmock.AnyArg(), challenge.Title, challenge.Description, challenge.Difficulty, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), challenge.Title, challenge.Description, challenge.Difficulty, challenge.CreatedBy, sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		id, err := repo.CreateChallenge(ctx, challenge)
+		id, err := repo.Create(ctx, challenge)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, id)
 	})
@@ -43,194 +46,304 @@ func TestBDRepositoryChallenge(t *testing.T) {
 			Title:       "Test Challenge",
 			Description: "This is a test challenge",
 			Difficulty:  3,
+			CreatedBy:   "user-1",
 		}
 
 		mock.ExpectExec("INSERT INTO challenges").
-			WithArgs(sqlmock.AnyArg(), challenge.Title, challenge.Description, challenge.Difficulty, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), challenge.Title, challenge.Description, challenge.Difficulty, challenge.CreatedBy, sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnError(fmt.Errorf("error de ejecución"))
 
-		id, err := repo.CreateChallenge(ctx, challenge)
+		id, err := repo.Create(ctx, challenge)
 		assert.Error(t, err)
 		assert.Empty(t, id)
 		assert.Contains(t, err.Error(), "error executing statement: error de ejecución")
 	})
 
 	t.Run("SelectChallenge", func(t *testing.T) {
-		request := &challenges.GetChallenge{ID: "123"}
-		rows := sqlmock.NewRows([]string{"title", "description", "difficulty", "created_at", "updated_at"}).
-			AddRow("Test Challenge", "This is a test challenge", 3, time.Now(), time.Now())
+		request := &entity.GetRecord{ID: "123"}
+		rows := sqlmock.NewRows([]string{"title", "description", "difficulty", "created_by", "created_at", "updated_at", "version", "deleted_at"}).
+			AddRow("Test Challenge", "This is a test challenge", 3, "user-1", time.Now(), time.Now(), 1, nil)
 
 		mock.ExpectQuery("SELECT (.+) FROM challenges WHERE id = \\$1").
 			WithArgs(request.ID).
 			WillReturnRows(rows)
 
-		challenge, err := repo.SelectChallenge(ctx, request)
+		challenge, err := repo.Select(ctx, request)
 		assert.NoError(t, err)
 		assert.NotNil(t, challenge)
 		assert.Equal(t, "Test Challenge", challenge.Title)
 		assert.Equal(t, 3, challenge.Difficulty)
+		assert.Equal(t, "user-1", challenge.CreatedBy)
+	})
+
+	t.Run("SelectChallenge_ForUpdate", func(t *testing.T) {
+		request := &entity.GetRecord{ID: "123", ForUpdate: true}
+		rows := sqlmock.NewRows([]string{"title", "description", "difficulty", "created_by", "created_at", "updated_at", "version", "deleted_at"}).
+			AddRow("Test Challenge", "This is a test challenge", 3, "user-1", time.Now(), time.Now(), 1, nil)
+
+		mock.ExpectQuery("SELECT (.+) FROM challenges WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs(request.ID).
+			WillReturnRows(rows)
+
+		challenge, err := repo.Select(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", challenge.CreatedBy)
 	})
 
 	t.Run("SelectChallenge_NotFound", func(t *testing.T) {
-		request := &challenges.GetChallenge{ID: "999"}
+		request := &entity.GetRecord{ID: "999"}
 
 		mock.ExpectQuery("SELECT (.+) FROM challenges WHERE id = \\$1").
 			WithArgs(request.ID).
 			WillReturnError(sql.ErrNoRows)
 
-		challenge, err := repo.SelectChallenge(ctx, request)
+		challenge, err := repo.Select(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, challenge)
 		assert.Contains(t, err.Error(), "challenge with id 999 not found")
 	})
 
 	t.Run("SelectChallenge_ScanError", func(t *testing.T) {
-		request := &challenges.GetChallenge{ID: "123"}
+		request := &entity.GetRecord{ID: "123"}
 
 		mock.ExpectQuery("SELECT (.+) FROM challenges WHERE id = \\$1").
 			WithArgs(request.ID).
-			WillReturnRows(sqlmock.NewRows([]string{"title", "description", "difficulty", "created_at", "updated_at"}).
-				AddRow("Test Challenge", "This is a test challenge", "no es un número", time.Now(), time.Now()))
+			WillReturnRows(sqlmock.NewRows([]string{"title", "description", "difficulty", "created_by", "created_at", "updated_at", "version", "deleted_at"}).
+				AddRow("Test Challenge", "This is a test challenge", "no es un número", nil, time.Now(), time.Now(), 1, nil))
 
-		challenge, err := repo.SelectChallenge(ctx, request)
+		challenge, err := repo.Select(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, challenge)
 		assert.Contains(t, err.Error(), "error scanning challenge row")
 	})
 
 	t.Run("UpdateChallenge", func(t *testing.T) {
-		request := &challenges.UpdateChallenge{
-			ID:          "123",
+		request := challenges.ReplaceChallenge{
 			Title:       "Updated Challenge",
 			Description: "This is an updated test challenge",
 			Difficulty:  4,
-		}
-
-		mock.ExpectExec("UPDATE challenges").
-			WithArgs(request.Title, request.Description, request.Difficulty, sqlmock.AnyArg(), request.ID).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		}.Update("123")
+		createdAt := time.Now().Add(-time.Hour)
 
-		rows := sqlmock.NewRows([]string{"title", "description", "difficulty", "updated_at"}).
-			AddRow(request.Title, request.Description, request.Difficulty, time.Now())
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "difficulty", "created_at", "updated_at", "version"}).
+			AddRow(request.ID, *request.Title, *request.Description, *request.Difficulty, createdAt, time.Now(), 1)
 
-		mock.ExpectQuery("SELECT (.+) FROM challenges WHERE id = \\$1").
-			WithArgs(request.ID).
+		mock.ExpectQuery("UPDATE challenges SET (.+) RETURNING").
+			WithArgs(*request.Title, *request.Description, *request.Difficulty, sqlmock.AnyArg(), request.ID).
 			WillReturnRows(rows)
 
-		challenge, err := repo.UpdateChallenge(ctx, request)
+		challenge, err := repo.Update(ctx, request)
 		assert.NoError(t, err)
 		assert.NotNil(t, challenge)
-		assert.Equal(t, request.Title, challenge.Title)
-		assert.Equal(t, request.Difficulty, challenge.Difficulty)
+		assert.Equal(t, request.ID, challenge.ID)
+		assert.Equal(t, *request.Title, challenge.Title)
+		assert.Equal(t, *request.Difficulty, challenge.Difficulty)
+		assert.NotEmpty(t, challenge.CreatedAt)
+		assert.Equal(t, int64(1), challenge.Version)
 	})
 
 	t.Run("UpdateChallenge_ExecError", func(t *testing.T) {
-		request := &challenges.UpdateChallenge{
-			ID:          "123",
+		request := challenges.ReplaceChallenge{
 			Title:       "Updated Challenge",
 			Description: "This is an updated test challenge",
 			Difficulty:  4,
-		}
+		}.Update("123")
 
-		mock.ExpectExec("UPDATE challenges").
-			WithArgs(request.Title, request.Description, request.Difficulty, sqlmock.AnyArg(), request.ID).
-			WillReturnError(fmt.Errorf("error de ejecución"))
+		mock.ExpectQuery("UPDATE challenges SET (.+) RETURNING").
+			WithArgs(*request.Title, *request.Description, *request.Difficulty, sqlmock.AnyArg(), request.ID).
+			WillReturnError(fmt.Errorf("exec error"))
 
-		challenge, err := repo.UpdateChallenge(ctx, request)
+		challenge, err := repo.Update(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, challenge)
 		assert.Contains(t, err.Error(), "error executing update")
 	})
 
-	t.Run("UpdateChallenge_NotFoundAfterUpdate", func(t *testing.T) {
-		request := &challenges.UpdateChallenge{
-			ID:          "123",
+	t.Run("UpdateChallenge_NotFound", func(t *testing.T) {
+		request := challenges.ReplaceChallenge{
 			Title:       "Updated Challenge",
 			Description: "This is an updated test challenge",
 			Difficulty:  4,
-		}
-
-		mock.ExpectExec("UPDATE challenges").
-			WithArgs(request.Title, request.Description, request.Difficulty, sqlmock.AnyArg(), request.ID).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		}.Update("123")
 
-		mock.ExpectQuery("SELECT (.+) FROM challenges WHERE id = \\$1").
-			WithArgs(request.ID).
+		mock.ExpectQuery("UPDATE challenges SET (.+) RETURNING").
+			WithArgs(*request.Title, *request.Description, *request.Difficulty, sqlmock.AnyArg(), request.ID).
 			WillReturnError(sql.ErrNoRows)
 
-		challenge, err := repo.UpdateChallenge(ctx, request)
+		challenge, err := repo.Update(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, challenge)
-		assert.Contains(t, err.Error(), "challenge with id 123 not found after update")
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+		assert.Contains(t, err.Error(), "challenge with id 123 not found")
 	})
 
 	t.Run("UpdateChallenge_ScanError", func(t *testing.T) {
-		request := &challenges.UpdateChallenge{
-			ID:          "123",
+		request := challenges.ReplaceChallenge{
 			Title:       "Updated Challenge",
 			Description: "This is an updated test challenge",
 			Difficulty:  4,
-		}
+		}.Update("123")
+		createdAt := time.Now().Add(-time.Hour)
 
-		mock.ExpectExec("UPDATE challenges").
-			WithArgs(request.Title, request.Description, request.Difficulty, sqlmock.AnyArg(), request.ID).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "difficulty", "created_at", "updated_at", "version"}).
+			AddRow(request.ID, *request.Title, *request.Description, "no es un número", createdAt, time.Now(), 1)
 
-		mock.ExpectQuery("SELECT (.+) FROM challenges WHERE id = \\$1").
-			WithArgs(request.ID).
-			WillReturnRows(sqlmock.NewRows([]string{"title", "description", "difficulty", "updated_at"}).
-				AddRow(request.Title, request.Description, "no es un número", time.Now()))
+		mock.ExpectQuery("UPDATE challenges SET (.+) RETURNING").
+			WithArgs(*request.Title, *request.Description, *request.Difficulty, sqlmock.AnyArg(), request.ID).
+			WillReturnRows(rows)
 
-		challenge, err := repo.UpdateChallenge(ctx, request)
+		challenge, err := repo.Update(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, challenge)
-		assert.Contains(t, err.Error(), "error scanning updated challenge row")
+		assert.Contains(t, err.Error(), "error executing update")
 	})
 
-	t.Run("DeleteChallenge", func(t *testing.T) {
-		request := &challenges.DeleteChallenge{ID: "123"}
+	t.Run("UpdateChallenge_Partial", func(t *testing.T) {
+		difficulty := 1
+		request := &challenges.UpdateChallenge{ID: "123", Difficulty: &difficulty}
+
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "difficulty", "created_at", "updated_at", "version"}).
+			AddRow(request.ID, "Test Challenge", "This is a test challenge", difficulty, time.Now().Add(-time.Hour), time.Now(), 1)
+
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE challenges SET difficulty = $1, updated_at = $2, version = version + 1 WHERE id = $3 AND deleted_at IS NULL RETURNING")).
+			WithArgs(difficulty, sqlmock.AnyArg(), request.ID).
+			WillReturnRows(rows)
+
+		challenge, err := repo.Update(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, "Test Challenge", challenge.Title)
+		assert.Equal(t, difficulty, challenge.Difficulty)
+	})
+
+	t.Run("UpdateChallenge_StaleVersion", func(t *testing.T) {
+		version := int64(2)
+		title := "Updated Challenge"
+		request := &challenges.UpdateChallenge{ID: "123", Title: &title, Version: &version}
+
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE challenges SET title = $1, updated_at = $2, version = version + 1 WHERE id = $3 AND version = $4 AND deleted_at IS NULL RETURNING")).
+			WithArgs(title, sqlmock.AnyArg(), request.ID, version).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM challenges WHERE id = $1 AND deleted_at IS NULL)")).
+			WithArgs(request.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		challenge, err := repo.Update(ctx, request)
+		assert.Nil(t, challenge)
+		assert.Equal(t, entity.KindPreconditionFailed, entity.KindOf(err))
+		assert.Contains(t, err.Error(), "record 123 was modified since version 2")
+	})
 
-		mock.ExpectExec("DELETE FROM challenges").
+	t.Run("UpdateChallenge_VersionedNotFound", func(t *testing.T) {
+		version := int64(2)
+		title := "Updated Challenge"
+		request := &challenges.UpdateChallenge{ID: "999", Title: &title, Version: &version}
+
+		mock.ExpectQuery("UPDATE challenges SET (.+) RETURNING").
+			WithArgs(title, sqlmock.AnyArg(), request.ID, version).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery("SELECT EXISTS").
 			WithArgs(request.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		challenge, err := repo.Update(ctx, request)
+		assert.Nil(t, challenge)
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	})
+
+	t.Run("DeleteChallenge", func(t *testing.T) {
+		request := &entity.DeleteRecord{ID: "123"}
+
+		mock.ExpectExec("UPDATE challenges SET deleted_at").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		err := repo.DeleteChallenge(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.NoError(t, err)
 	})
 
 	t.Run("DeleteChallenge_NotFound", func(t *testing.T) {
-		request := &challenges.DeleteChallenge{ID: "999"}
+		request := &entity.DeleteRecord{ID: "999"}
 
-		mock.ExpectExec("DELETE FROM challenges").
-			WithArgs(request.ID).
+		mock.ExpectExec("UPDATE challenges SET deleted_at").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
-		err := repo.DeleteChallenge(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "challenge with id 999 not found")
 	})
 
 	t.Run("DeleteChallenge_ExecError", func(t *testing.T) {
-		request := &challenges.DeleteChallenge{ID: "123"}
+		request := &entity.DeleteRecord{ID: "123"}
 
-		mock.ExpectExec("DELETE FROM challenges").
-			WithArgs(request.ID).
+		mock.ExpectExec("UPDATE challenges SET deleted_at").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnError(fmt.Errorf("error de ejecución"))
 
-		err := repo.DeleteChallenge(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error de ejecución")
 	})
 
 	t.Run("DeleteChallenge_RowsAffectedError", func(t *testing.T) {
-		request := &challenges.DeleteChallenge{ID: "123"}
+		request := &entity.DeleteRecord{ID: "123"}
 
-		mock.ExpectExec("DELETE FROM challenges").
-			WithArgs(request.ID).
+		mock.ExpectExec("UPDATE challenges SET deleted_at").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnResult(sqlmock.NewErrorResult(fmt.Errorf("error de filas afectadas")))
 
-		err := repo.DeleteChallenge(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error de filas afectadas")
 	})
+
+	t.Run("RestoreChallenge", func(t *testing.T) {
+		request := &entity.RestoreRecord{ID: "123"}
+
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE challenges SET deleted_at = NULL, updated_at = $1, version = version + 1 WHERE id = $2 AND deleted_at IS NOT NULL RETURNING")).
+			WithArgs(sqlmock.AnyArg(), request.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "difficulty", "created_at", "updated_at", "version"}).
+				AddRow("123", "Test Challenge", "Description", 3, time.Now().Add(-time.Hour), time.Now(), 3))
+
+		challenge, err := repo.Restore(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, "123", challenge.ID)
+		assert.Equal(t, int64(3), challenge.Version)
+	})
+
+	t.Run("RestoreChallenge_NotDeleted", func(t *testing.T) {
+		request := &entity.RestoreRecord{ID: "123"}
+
+		mock.ExpectQuery("UPDATE challenges SET deleted_at = NULL").
+			WithArgs(sqlmock.AnyArg(), request.ID).
+			WillReturnError(sql.ErrNoRows)
+
+		challenge, err := repo.Restore(ctx, request)
+		assert.Nil(t, challenge)
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	})
+}
+
+func TestBDRepositoryListChallenges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBdRepositoryChallenge(db, 0)
+	ctx := context.Background()
+	table := "challenges"
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM " + table).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM "+table+" WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC").
+		WithArgs(entity.MaxPageSize+1, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "difficulty", "created_at", "updated_at", "version", "deleted_at"}).AddRow("1", "Test Challenge", "Description", 3, time.Now(), time.Now(), 1, nil))
+
+	page, err := repo.List(ctx, &challenges.ListChallenges{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "1", page.Items[0].ID)
+	assert.Empty(t, page.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queryLatency simula el tiempo de ida y vuelta de una consulta a la base de datos.
+const queryLatency = 20 * time.Millisecond
+
+// newLatencyDB crea una base de datos simulada que responde n consultas SelectUser en cualquier orden.
+func newLatencyDB(t testing.TB, n int) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.MatchExpectationsInOrder(false)
+	db.SetMaxOpenConns(n)
+
+	for i := 0; i < n; i++ {
+		mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
+			WillDelayFor(queryLatency).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "email", "image_path", "role", "created_at", "updated_at", "version", "deleted_at"}).
+				AddRow("John Doe", "john@example.com", "", "viewer", time.Now(), time.Now(), 1, nil))
+	}
+	return db, mock
+}
+
+// TestBDRepository_ConcurrentAccess verifica que las consultas de un mismo repositorio se
+// ejecuten en paralelo: en serie tardarían n veces la latencia de una consulta.
+func TestBDRepository_ConcurrentAccess(t *testing.T) {
+	const n = 10
+	db, mock := newLatencyDB(t, n)
+	defer db.Close()
+
+	repo := NewBdRepository(db, time.Second)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.Select(context.Background(), &entity.GetRecord{ID: "123"})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Less(t, elapsed, n*queryLatency/2, "las consultas se ejecutaron en serie")
+}
+
+func TestBDRepository_QueryTimeout(t *testing.T) {
+	db, _ := newLatencyDB(t, 1)
+	defer db.Close()
+
+	repo := NewBdRepository(db, queryLatency/4)
+
+	start := time.Now()
+	_, err := repo.Select(context.Background(), &entity.GetRecord{ID: "123"})
+	assert.ErrorIs(t, err, sqlmock.ErrCancelled)
+	assert.Less(t, time.Since(start), queryLatency, "la consulta no se canceló al vencer el timeout")
+}
+
+func TestBDRepository_CanceledContext(t *testing.T) {
+	db, _ := newLatencyDB(t, 1)
+	defer db.Close()
+
+	repo := NewBdRepository(db, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.Select(ctx, &entity.GetRecord{ID: "123"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func BenchmarkBDRepository_SelectUserParallel(b *testing.B) {
+	db, _ := newLatencyDB(b, b.N)
+	defer db.Close()
+
+	repo := NewBdRepository(db, time.Second)
+
+	b.SetParallelism(16)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := repo.Select(context.Background(), &entity.GetRecord{ID: "123"}); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"CrudPlatform/cmd/config"
+	"CrudPlatform/cmd/config/db"
+	"CrudPlatform/internal/adapters/repository/repositorytest"
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func repositories(db *sql.DB) repositorytest.Repositories {
+	return repositorytest.Repositories{
+		Users:      NewBdRepository(db, time.Second),
+		Challenges: NewBdRepositoryChallenge(db, time.Second),
+		Videos:     NewBdRepositoryVideo(db, time.Second),
+	}
+}
+
+func TestConformance_SQLite(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		return repositories(newSQLiteDB(t))
+	})
+}
+
+// TestConformance_Postgres corre la suite contra la base de TEST_POSTGRES_DSN, que se vacía
+// revirtiendo todas las migraciones antes de cada subtest; sin la variable, el test se omite.
+func TestConformance_Postgres(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	postgresDB, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { postgresDB.Close() })
+
+	migrator, err := db.NewMigrator(postgresDB, config.DriverPostgres)
+	require.NoError(t, err)
+
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		ctx := context.Background()
+		require.NoError(t, migrator.To(ctx, 0))
+		require.NoError(t, migrator.Up(ctx))
+		return repositories(postgresDB)
+	})
+}
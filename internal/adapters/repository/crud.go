@@ -0,0 +1,187 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// crudSpec describe cómo se guarda una entidad en su tabla; crudRepository arma con él las
+// consultas comunes a todas las entidades con borrado lógico.
+type crudSpec[C, U, L, T, W any] struct {
+	table string
+	// noun nombra un registro en los mensajes de error, por ejemplo "user".
+	noun string
+	// insert retorna las columnas y valores propios de la entidad; id, created_at y updated_at
+	// los agrega crudRepository.
+	insert func(request *C) ([]string, []any)
+	// assign agrega al builder los campos presentes en la actualización y retorna el id del
+	// registro y la versión esperada.
+	assign func(b *queryBuilder, request *U) (string, *int64)
+	// columns se leen en Select, sin el id, y scan las escanea en un registro con ese id.
+	columns string
+	scan    func(row *sql.Row, id string) (*T, error)
+	// returning son las columnas que retornan Update y Restore, escaneadas con scanWrite.
+	returning string
+	scanWrite func(row *sql.Row) (*W, error)
+	list      listSpec[T]
+	page      func(request *L) (entity.PageRequest, entity.ListQuery)
+	// purge borra las filas eliminadas antes de before y retorna cuántas borró y las llaves de los
+	// archivos del BlobStore que usaban. Las entidades sin archivos lo dejan en nil y se purgan con
+	// purgeDeleted.
+	purge func(ctx context.Context, db querier, before time.Time) (int64, []string, error)
+}
+
+// crudRepository implementa ports.Repository sobre una tabla. Los repositorios de cada entidad
+// lo embeben y agregan sus operaciones propias.
+type crudRepository[C, U, L, T, W any] struct {
+	db      *sql.DB
+	timeout time.Duration
+	spec    *crudSpec[C, U, L, T, W]
+}
+
+func newCrudRepository[C, U, L, T, W any](db *sql.DB, timeout time.Duration, spec *crudSpec[C, U, L, T, W]) crudRepository[C, U, L, T, W] {
+	return crudRepository[C, U, L, T, W]{db: db, timeout: timeout, spec: spec}
+}
+
+func (r *crudRepository[C, U, L, T, W]) Create(ctx context.Context, request *C) (string, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	id := uuid.NewString()
+	now := time.Now().UTC()
+
+	columns, values := r.spec.insert(request)
+	columns = append(append([]string{"id"}, columns...), "created_at", "updated_at")
+	values = append(append([]any{id}, values...), now, now)
+
+	b := &queryBuilder{}
+	placeholders := make([]string, len(values))
+	for i, value := range values {
+		placeholders[i] = b.bind(value)
+	}
+
+	query := "INSERT INTO " + r.spec.table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, b.args...); err != nil {
+		return "", dbError(err, "error executing statement")
+	}
+
+	return id, nil
+}
+
+func (r *crudRepository[C, U, L, T, W]) Select(ctx context.Context, request *entity.GetRecord) (*T, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout, r.spec.noun+"_id", request.ID)
+	defer cancel()
+
+	query := "SELECT " + r.spec.columns + " FROM " + r.spec.table + " WHERE id = $1"
+	if !request.IncludeDeleted {
+		query += " AND deleted_at IS NULL"
+	}
+	// SQLite no tiene FOR UPDATE; sus transacciones ya toman el lock de escritura al empezar
+	// (_txlock=immediate).
+	if request.ForUpdate && dialectOf(r.db) == dialectPostgres {
+		query += " FOR UPDATE"
+	}
+
+	response, err := r.spec.scan(conn(ctx, r.db).QueryRowContext(ctx, query, request.ID), request.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, r.notFound(request.ID)
+		}
+		return nil, dbError(err, "error scanning %s row", r.spec.noun)
+	}
+
+	return response, nil
+}
+
+// Update escribe solo los campos presentes con un UPDATE ... RETURNING; sin fila, el id no
+// existe o la versión esperada ya no es la actual.
+func (r *crudRepository[C, U, L, T, W]) Update(ctx context.Context, request *U) (*W, error) {
+	b := &queryBuilder{}
+	id, version := r.spec.assign(b, request)
+
+	ctx, cancel := withTimeout(ctx, r.timeout, r.spec.noun+"_id", id)
+	defer cancel()
+
+	query := b.update(r.spec.table, id, version, time.Now().UTC(), r.spec.returning)
+	response, err := r.spec.scanWrite(conn(ctx, r.db).QueryRowContext(ctx, query, b.args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, missingOrStale(ctx, conn(ctx, r.db), r.spec.table, id, version, r.notFound(id))
+		}
+		return nil, dbError(err, "error executing update")
+	}
+
+	return response, nil
+}
+
+// Delete marca el registro como eliminado; Restore lo recupera hasta que se purga.
+func (r *crudRepository[C, U, L, T, W]) Delete(ctx context.Context, request *entity.DeleteRecord) error {
+	ctx, cancel := withTimeout(ctx, r.timeout, r.spec.noun+"_id", request.ID)
+	defer cancel()
+
+	b := &queryBuilder{}
+	result, err := conn(ctx, r.db).ExecContext(ctx, b.softDelete(r.spec.table, request.ID, request.Version, time.Now().UTC()), b.args...)
+	if err != nil {
+		return dbError(err, "error executing delete")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dbError(err, "error reading affected rows")
+	}
+
+	if rowsAffected == 0 {
+		return missingOrStale(ctx, conn(ctx, r.db), r.spec.table, request.ID, request.Version, r.notFound(request.ID))
+	}
+
+	return nil
+}
+
+func (r *crudRepository[C, U, L, T, W]) Restore(ctx context.Context, request *entity.RestoreRecord) (*W, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout, r.spec.noun+"_id", request.ID)
+	defer cancel()
+
+	b := &queryBuilder{}
+	query := b.restore(r.spec.table, request.ID, time.Now().UTC(), r.spec.returning)
+	response, err := r.spec.scanWrite(conn(ctx, r.db).QueryRowContext(ctx, query, b.args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entity.NewError(entity.KindNotFound, "no deleted %s found with id %s", r.spec.noun, request.ID)
+		}
+		// Un índice único, como el email de los usuarios, puede haber quedado tomado mientras
+		// el registro estaba eliminado.
+		return nil, dbError(err, "error executing restore")
+	}
+
+	return response, nil
+}
+
+func (r *crudRepository[C, U, L, T, W]) List(ctx context.Context, request *L) (*entity.Page[T], error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	page, query := r.spec.page(request)
+	return listPage(ctx, r.db, r.spec.list, page, query)
+}
+
+// PurgeDeleted elimina definitivamente los registros eliminados antes de before y retorna las
+// llaves de los archivos que usaban.
+func (r *crudRepository[C, U, L, T, W]) PurgeDeleted(ctx context.Context, before time.Time) (int64, []string, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if r.spec.purge != nil {
+		return r.spec.purge(ctx, r.db, before)
+	}
+	purged, err := purgeDeleted(ctx, r.db, r.spec.table, before, "", nil)
+	return purged, nil, err
+}
+
+func (r *crudRepository[C, U, L, T, W]) notFound(id string) error {
+	return entity.NewError(entity.KindNotFound, "%s with id %s not found", r.spec.noun, id)
+}
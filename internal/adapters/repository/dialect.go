@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+
+	"modernc.org/sqlite"
+)
+
+// dialect es el motor de la base de datos. Las consultas son las mismas en Postgres y SQLite,
+// que también acepta los parámetros $1, $2, ... y RETURNING; solo cambia la búsqueda de texto.
+type dialect int
+
+const (
+	dialectPostgres dialect = iota
+	dialectSQLite
+)
+
+// dialectOf reconoce el motor por el driver con el que se abrió db; cualquier otro, como el de
+// sqlmock en los tests, se trata como Postgres.
+func dialectOf(db *sql.DB) dialect {
+	if _, ok := db.Driver().(*sqlite.Driver); ok {
+		return dialectSQLite
+	}
+	return dialectPostgres
+}
+
+// search agrega la búsqueda de texto completo. Postgres usa la columna tsvector de la tabla; SQLite
+// exige que cada palabra aparezca, sin distinguir mayúsculas, en alguna de las columnas de texto.
+func (b *queryBuilder) search(spec tableSpec, text string) {
+	if b.dialect == dialectPostgres {
+		b.where(spec.search + " @@ plainto_tsquery('simple', " + b.bind(text) + ")")
+		return
+	}
+
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		pattern := b.bind("%" + escapeLike(word) + "%")
+		matches := make([]string, len(spec.searchColumns))
+		for i, column := range spec.searchColumns {
+			matches[i] = "LOWER(" + column + ") LIKE " + pattern + ` ESCAPE '\'`
+		}
+		b.where("(" + strings.Join(matches, " OR ") + ")")
+	}
+}
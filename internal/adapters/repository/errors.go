@@ -0,0 +1,80 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// dbError clasifica un error de la base de datos en un error de dominio con el mensaje indicado.
+func dbError(err error, format string, args ...any) error {
+	return entity.WrapError(dbErrorKind(err), err, format, args...)
+}
+
+// missingOrStale explica por qué una escritura condicionada a version no afectó filas: si el
+// registro existe, cambió desde esa versión (412); si no existe o está eliminado, retorna notFound.
+func missingOrStale(ctx context.Context, db querier, table, id string, version *int64, notFound error) error {
+	if version == nil {
+		return notFound
+	}
+
+	var exists bool
+	err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exists)
+	if err != nil {
+		return dbError(err, "error checking record version")
+	}
+	if !exists {
+		return notFound
+	}
+	return entity.NewError(entity.KindPreconditionFailed, "record %s was modified since version %d", id, *version)
+}
+
+func dbErrorKind(err error) entity.ErrorKind {
+	var pqErr *pq.Error
+	var sqliteErr *sqlite.Error
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return entity.KindNotFound
+	case errors.As(err, &pqErr):
+		switch pqErr.Code.Name() {
+		case "unique_violation", "exclusion_violation":
+			return entity.KindConflict
+		case "foreign_key_violation", "not_null_violation", "check_violation",
+			"invalid_text_representation", "string_data_right_truncation", "numeric_value_out_of_range":
+			return entity.KindValidation
+		}
+		switch pqErr.Code.Class() {
+		// 08: conexión, 53: recursos insuficientes, 57: intervención del operador (p. ej. apagado).
+		case "08", "53", "57":
+			return entity.KindUnavailable
+		}
+		return entity.KindInternal
+	case errors.As(err, &sqliteErr):
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return entity.KindConflict
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY, sqlite3.SQLITE_CONSTRAINT_NOTNULL, sqlite3.SQLITE_CONSTRAINT_CHECK:
+			return entity.KindValidation
+		}
+		// Los códigos extendidos guardan el código primario en el byte bajo.
+		switch sqliteErr.Code() & 0xff {
+		case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+			return entity.KindUnavailable
+		}
+		return entity.KindInternal
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone),
+		errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return entity.KindUnavailable
+	default:
+		return entity.KindInternal
+	}
+}
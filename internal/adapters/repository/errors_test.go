@@ -0,0 +1,44 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind entity.ErrorKind
+	}{
+		{"NoRows", sql.ErrNoRows, entity.KindNotFound},
+		{"UniqueViolation", &pq.Error{Code: "23505"}, entity.KindConflict},
+		{"ForeignKeyViolation", &pq.Error{Code: "23503"}, entity.KindValidation},
+		{"CheckViolation", &pq.Error{Code: "23514"}, entity.KindValidation},
+		{"InvalidText", &pq.Error{Code: "22P02"}, entity.KindValidation},
+		{"ConnectionFailure", &pq.Error{Code: "08006"}, entity.KindUnavailable},
+		{"TooManyConnections", &pq.Error{Code: "53300"}, entity.KindUnavailable},
+		{"AdminShutdown", &pq.Error{Code: "57P01"}, entity.KindUnavailable},
+		{"SyntaxError", &pq.Error{Code: "42601"}, entity.KindInternal},
+		{"BadConn", driver.ErrBadConn, entity.KindUnavailable},
+		{"Timeout", fmt.Errorf("query: %w", context.DeadlineExceeded), entity.KindUnavailable},
+		{"Unknown", errors.New("boom"), entity.KindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dbError(tt.err, "error executing statement")
+			assert.Equal(t, tt.kind, entity.KindOf(err))
+			assert.ErrorIs(t, err, tt.err)
+			assert.Contains(t, err.Error(), "error executing statement: ")
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/challenges"
+	schema "CrudPlatform/internal/core/domain/repository/schema/challenges"
+
+	"github.com/google/uuid"
+)
+
+type challenge struct {
+	id, title, description, createdBy string
+	difficulty                        int
+	createdAt, updatedAt              time.Time
+	deletedAt                         *time.Time
+	version                           int64
+}
+
+// Challenges implementa ports.DBRepositoryChallenge sobre un Store.
+type Challenges struct {
+	store *Store
+}
+
+func (p *Challenges) Create(ctx context.Context, request *model.Challenge) (string, error) {
+	if err := checkContext(ctx); err != nil {
+		return "", err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timestamp()
+	c := &challenge{
+		id:          uuid.NewString(),
+		title:       request.Title,
+		description: request.Description,
+		difficulty:  request.Difficulty,
+		createdBy:   request.CreatedBy,
+		createdAt:   now,
+		updatedAt:   now,
+		version:     1,
+	}
+	s.challenges[c.id] = c
+
+	return c.id, nil
+}
+
+func (p *Challenges) Select(ctx context.Context, request *entity.GetRecord) (*schema.ChallengeGetResponse, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.challenges[request.ID]
+	if !ok || (c.deletedAt != nil && !request.IncludeDeleted) {
+		return nil, entity.NewError(entity.KindNotFound, "challenge with id %s not found", request.ID)
+	}
+
+	response := c.get()
+	return &response, nil
+}
+
+func (p *Challenges) Update(ctx context.Context, request *model.UpdateChallenge) (*schema.ChallengeUpdateResponse, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.challenges[request.ID]
+	if !ok || c.deletedAt != nil {
+		return nil, entity.NewError(entity.KindNotFound, "challenge with id %s not found", request.ID)
+	}
+	if !matchesVersion(c.version, request.Version) {
+		return nil, stale(request.ID, *request.Version)
+	}
+
+	if request.Title != nil {
+		c.title = *request.Title
+	}
+	if request.Description != nil {
+		c.description = *request.Description
+	}
+	if request.Difficulty != nil {
+		c.difficulty = *request.Difficulty
+	}
+	c.updatedAt = s.timestamp()
+	c.version++
+
+	return c.update(), nil
+}
+
+func (p *Challenges) Delete(ctx context.Context, request *entity.DeleteRecord) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.challenges[request.ID]
+	if !ok || c.deletedAt != nil {
+		return entity.NewError(entity.KindNotFound, "challenge with id %s not found", request.ID)
+	}
+	if !matchesVersion(c.version, request.Version) {
+		return stale(request.ID, *request.Version)
+	}
+
+	now := s.timestamp()
+	c.deletedAt = &now
+	c.version++
+
+	return nil
+}
+
+func (p *Challenges) Restore(ctx context.Context, request *entity.RestoreRecord) (*schema.ChallengeUpdateResponse, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.challenges[request.ID]
+	if !ok || c.deletedAt == nil {
+		return nil, entity.NewError(entity.KindNotFound, "no deleted challenge found with id %s", request.ID)
+	}
+
+	c.deletedAt = nil
+	c.updatedAt = s.timestamp()
+	c.version++
+
+	return c.update(), nil
+}
+
+// PurgeDeleted borra los challenges eliminados antes de before y desvincula sus videos.
+func (p *Challenges) PurgeDeleted(ctx context.Context, before time.Time) (int64, []string, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var purged int64
+	for id, c := range s.challenges {
+		if c.deletedAt == nil || !c.deletedAt.Before(before) {
+			continue
+		}
+		delete(s.challenges, id)
+		for _, v := range s.videos {
+			if v.challengeID == id {
+				v.challengeID = ""
+			}
+		}
+		purged++
+	}
+	return purged, nil, nil
+}
+
+var challengesTable = table[*challenge, schema.ChallengeGetResponse]{
+	name: "challenges",
+	fields: map[string]func(*challenge) any{
+		"id":         func(c *challenge) any { return c.id },
+		"title":      func(c *challenge) any { return c.title },
+		"difficulty": func(c *challenge) any { return c.difficulty },
+		"created_at": func(c *challenge) any { return c.createdAt },
+		"updated_at": func(c *challenge) any { return c.updatedAt },
+	},
+	text:    func(c *challenge) string { return c.title + " " + c.description },
+	id:      func(c *challenge) string { return c.id },
+	deleted: func(c *challenge) bool { return c.deletedAt != nil },
+	item: func(c *challenge) schema.ChallengeGetResponse {
+		// El listado SQL no selecciona created_by.
+		response := c.get()
+		response.CreatedBy = ""
+		return response
+	},
+}
+
+func (p *Challenges) List(ctx context.Context, request *model.ListChallenges) (*entity.Page[schema.ChallengeGetResponse], error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := make([]*challenge, 0, len(s.challenges))
+	for _, c := range s.challenges {
+		rows = append(rows, c)
+	}
+	return list(challengesTable, rows, request.PageRequest, request.Query())
+}
+
+func (c *challenge) get() schema.ChallengeGetResponse {
+	return schema.ChallengeGetResponse{
+		ID:          c.id,
+		Title:       c.title,
+		Description: c.description,
+		Difficulty:  c.difficulty,
+		CreatedBy:   c.createdBy,
+		CreatedAt:   formatTimestamp(c.createdAt),
+		UpdatedAt:   formatTimestamp(c.updatedAt),
+		Version:     c.version,
+		DeletedAt:   formatDeletedAt(c.deletedAt),
+	}
+}
+
+func (c *challenge) update() *schema.ChallengeUpdateResponse {
+	return &schema.ChallengeUpdateResponse{
+		ID:          c.id,
+		Title:       c.title,
+		Description: c.description,
+		Difficulty:  c.difficulty,
+		CreatedAt:   formatTimestamp(c.createdAt),
+		UpdatedAt:   formatTimestamp(c.updatedAt),
+		Version:     c.version,
+	}
+}
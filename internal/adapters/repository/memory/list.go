@@ -0,0 +1,313 @@
+package memory
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+)
+
+// cursor es la posición de keyset de los cursores opacos, con el mismo formato que los del
+// repositorio SQL; los valores se guardan ya normalizados por key.
+type cursor struct {
+	Values []any  `json:"v"`
+	ID     string `json:"i"`
+	Sort   string `json:"s"`
+	Prev   bool   `json:"p,omitempty"`
+}
+
+func encodeCursor(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value string) (cursor, error) {
+	var c cursor
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return c, entity.NewError(entity.KindValidation, "invalid cursor")
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return c, entity.NewError(entity.KindValidation, "invalid cursor")
+	}
+	return c, nil
+}
+
+// table describe los campos de un tipo de registro que se pueden ordenar, filtrar y buscar,
+// como tableSpec en el repositorio SQL.
+type table[R any, T any] struct {
+	name string
+	// fields son los campos ordenables y filtrables; filters, los que solo se pueden filtrar.
+	fields  map[string]func(R) any
+	filters map[string]func(R) any
+	// text retorna el texto en el que busca Search; nil si la tabla no admite búsqueda.
+	text    func(R) string
+	id      func(R) string
+	deleted func(R) bool
+	item    func(R) T
+}
+
+func (t table[R, T]) field(name string) (func(R) any, error) {
+	if value, ok := t.fields[name]; ok {
+		return value, nil
+	}
+	return nil, entity.NewError(entity.KindValidation, "invalid field %s for %s", name, t.name)
+}
+
+func (t table[R, T]) filterField(name string) (func(R) any, error) {
+	if value, ok := t.filters[name]; ok {
+		return value, nil
+	}
+	return t.field(name)
+}
+
+// entry es un registro con los valores normalizados de sus campos de orden.
+type entry[R any] struct {
+	record R
+	id     string
+	keys   []any
+}
+
+// list retorna una página de rows con la semántica de listPage: offset sin cursor, keyset con
+// cursor, el total sin considerar el cursor y los cursores vecinos de la página.
+func list[R any, T any](spec table[R, T], rows []R, request entity.PageRequest, query entity.ListQuery) (*entity.Page[T], error) {
+	request.Normalize()
+	if len(query.Sort) == 0 {
+		query.Sort = entity.DefaultSort
+	}
+	sortKey := entity.SortString(query.Sort)
+
+	var position cursor
+	if request.Cursor != "" {
+		var err error
+		position, err = decodeCursor(request.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if position.Sort != sortKey {
+			return nil, entity.NewError(entity.KindValidation, "invalid cursor: sort changed")
+		}
+		if len(position.Values) != len(query.Sort) {
+			return nil, entity.NewError(entity.KindValidation, "invalid cursor")
+		}
+	}
+
+	values := make([]func(R) any, 0, len(query.Sort))
+	for _, key := range query.Sort {
+		value, err := spec.field(key.Field)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	match, err := matcher(spec, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entry[R]
+	for _, r := range rows {
+		if !match(r) {
+			continue
+		}
+		e := entry[R]{record: r, id: spec.id(r)}
+		for _, value := range values {
+			e.keys = append(e.keys, key(value(r)))
+		}
+		entries = append(entries, e)
+	}
+
+	less := func(keys []any, id string, other []any, otherID string) int {
+		for i, field := range query.Sort {
+			if c := compare(keys[i], other[i]); c != 0 {
+				if field.Desc {
+					return -c
+				}
+				return c
+			}
+		}
+		c := strings.Compare(id, otherID)
+		if query.Sort[0].Desc {
+			return -c
+		}
+		return c
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return less(entries[i].keys, entries[i].id, entries[j].keys, entries[j].id) < 0
+	})
+
+	total := len(entries)
+	var window []entry[R]
+	hasMore := false
+	switch {
+	case request.Cursor == "":
+		start := min(request.Offset(), len(entries))
+		end := min(start+request.PageSize, len(entries))
+		window, hasMore = entries[start:end], end < len(entries)
+	case position.Prev:
+		end := sort.Search(len(entries), func(i int) bool {
+			return less(entries[i].keys, entries[i].id, position.Values, position.ID) >= 0
+		})
+		start := max(end-request.PageSize, 0)
+		window, hasMore = entries[start:end], start > 0
+	default:
+		start := sort.Search(len(entries), func(i int) bool {
+			return less(entries[i].keys, entries[i].id, position.Values, position.ID) > 0
+		})
+		end := min(start+request.PageSize, len(entries))
+		window, hasMore = entries[start:end], end < len(entries)
+	}
+
+	page := &entity.Page[T]{Items: make([]T, 0, len(window)), Total: total}
+	for _, e := range window {
+		page.Items = append(page.Items, spec.item(e.record))
+	}
+	if len(window) == 0 {
+		return page, nil
+	}
+
+	at := func(e entry[R], prev bool) string {
+		return encodeCursor(cursor{Values: e.keys, ID: e.id, Sort: sortKey, Prev: prev})
+	}
+	first, last := window[0], window[len(window)-1]
+
+	switch {
+	case request.Cursor == "":
+		if hasMore {
+			page.NextCursor = at(last, false)
+		}
+		if request.Page > 1 {
+			page.PrevCursor = at(first, true)
+		}
+	case position.Prev:
+		page.NextCursor = at(last, false)
+		if hasMore {
+			page.PrevCursor = at(first, true)
+		}
+	default:
+		page.PrevCursor = at(first, true)
+		if hasMore {
+			page.NextCursor = at(last, false)
+		}
+	}
+
+	return page, nil
+}
+
+// matcher arma el predicado de los filtros, la búsqueda y la exclusión de los eliminados, y
+// valida los campos y operadores antes de recorrer los registros.
+func matcher[R any, T any](spec table[R, T], query entity.ListQuery) (func(R) bool, error) {
+	var conditions []func(R) bool
+	if !query.IncludeDeleted {
+		conditions = append(conditions, func(r R) bool { return !spec.deleted(r) })
+	}
+
+	for _, f := range query.Filters {
+		value, err := spec.filterField(f.Field)
+		if err != nil {
+			return nil, err
+		}
+		expected := key(f.Value)
+
+		switch f.Op {
+		case entity.OpEq:
+			conditions = append(conditions, func(r R) bool { return compare(key(value(r)), expected) == 0 })
+		case entity.OpGte:
+			conditions = append(conditions, func(r R) bool { return compare(key(value(r)), expected) >= 0 })
+		case entity.OpLte:
+			conditions = append(conditions, func(r R) bool { return compare(key(value(r)), expected) <= 0 })
+		case entity.OpEndsWith:
+			suffix := strings.ToLower(fmt.Sprint(f.Value))
+			conditions = append(conditions, func(r R) bool {
+				return strings.HasSuffix(strings.ToLower(fmt.Sprint(value(r))), suffix)
+			})
+		default:
+			return nil, entity.NewError(entity.KindValidation, "invalid filter operator %s", f.Op)
+		}
+	}
+
+	if query.Search != "" {
+		if spec.text == nil {
+			return nil, entity.NewError(entity.KindValidation, "full-text search is not supported for %s", spec.name)
+		}
+		terms := words(query.Search)
+		conditions = append(conditions, func(r R) bool {
+			found := map[string]bool{}
+			for _, word := range words(spec.text(r)) {
+				found[word] = true
+			}
+			for _, term := range terms {
+				if !found[term] {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	return func(r R) bool {
+		for _, condition := range conditions {
+			if !condition(r) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// words separa un texto en palabras en minúsculas, como la configuración 'simple' de Postgres.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// timeKey formatea las fechas con ancho fijo para que su orden como texto sea el cronológico.
+const timeKey = "2006-01-02T15:04:05.000000000Z"
+
+// key normaliza un valor para compararlo: las fechas como texto de ancho fijo y los números como
+// float64, que es como vuelven del JSON de un cursor.
+func key(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(timeKey)
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	default:
+		return v
+	}
+}
+
+// compare ordena dos valores normalizados; los de tipos distintos se comparan como texto.
+func compare(a, b any) int {
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y)
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"CrudPlatform/internal/adapters/repository/repositorytest"
+	entity "CrudPlatform/internal/core/domain/repository"
+	users "CrudPlatform/internal/core/domain/repository/model/users"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		store := NewStore()
+		return repositorytest.Repositories{Users: store.Users(), Challenges: store.Challenges(), Videos: store.Videos()}
+	})
+}
+
+func TestCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewStore().Users().Create(ctx, &users.User{Name: "Ana", Email: "ana@example.com", Role: "viewer"})
+	assert.Equal(t, entity.KindUnavailable, entity.KindOf(err))
+}
@@ -0,0 +1,79 @@
+// Package memory implementa los repositorios de usuarios, challenges y videos en memoria, con la
+// misma semántica que los repositorios SQL: email único entre los usuarios vigentes, llaves
+// foráneas, versiones, borrado lógico y listados con filtros, orden y cursores. Sirve para tests
+// de servicios que necesitan un repositorio real y para demos sin base de datos.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+)
+
+// Store guarda los registros de los tres repositorios, que comparten el lock para poder validar
+// las relaciones entre tablas como lo hacen las llaves foráneas.
+type Store struct {
+	mu         sync.RWMutex
+	users      map[string]*user
+	challenges map[string]*challenge
+	videos     map[string]*video
+
+	// now es el reloj de los timestamps; los tests lo reemplazan para controlar el orden.
+	now func() time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		users:      map[string]*user{},
+		challenges: map[string]*challenge{},
+		videos:     map[string]*video{},
+		now:        time.Now,
+	}
+}
+
+// Users retorna el repositorio de usuarios del store.
+func (s *Store) Users() *Users { return &Users{s} }
+
+// Challenges retorna el repositorio de challenges del store.
+func (s *Store) Challenges() *Challenges { return &Challenges{s} }
+
+// Videos retorna el repositorio de videos del store.
+func (s *Store) Videos() *Videos { return &Videos{s} }
+
+// timestamp retorna la hora actual en UTC con la precisión de microsegundos de Postgres.
+func (s *Store) timestamp() time.Time {
+	return s.now().UTC().Truncate(time.Microsecond)
+}
+
+// checkContext rechaza las operaciones cuyo contexto ya terminó, como un request cancelado,
+// igual que el driver de la base de datos.
+func checkContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return entity.WrapError(entity.KindUnavailable, err, "operation cancelled")
+	}
+	return nil
+}
+
+// stale es el error de una escritura condicionada a una versión que ya cambió.
+func stale(id string, version int64) error {
+	return entity.NewError(entity.KindPreconditionFailed, "record %s was modified since version %d", id, version)
+}
+
+// matchesVersion indica si la fila sigue en la versión esperada; sin versión, siempre coincide.
+func matchesVersion(current int64, expected *int64) bool {
+	return expected == nil || current == *expected
+}
+
+func formatTimestamp(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func formatDeletedAt(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	value := formatTimestamp(*t)
+	return &value
+}
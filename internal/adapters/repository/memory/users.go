@@ -0,0 +1,288 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelAuth "CrudPlatform/internal/core/domain/repository/model/auth"
+	model "CrudPlatform/internal/core/domain/repository/model/users"
+	schema "CrudPlatform/internal/core/domain/repository/schema/users"
+
+	"github.com/google/uuid"
+)
+
+type user struct {
+	id, name, email, imagePath, role, passwordHash string
+	createdAt, updatedAt                           time.Time
+	deletedAt                                      *time.Time
+	version                                        int64
+}
+
+// Users implementa ports.DBRepositoryUsers sobre un Store.
+type Users struct {
+	store *Store
+}
+
+// validRoles replica el CHECK de la columna role.
+var validRoles = map[string]bool{"admin": true, "creator": true, "viewer": true}
+
+func (p *Users) Create(ctx context.Context, request *model.User) (string, error) {
+	if err := checkContext(ctx); err != nil {
+		return "", err
+	}
+	if !validRoles[request.Role] {
+		return "", entity.NewError(entity.KindValidation, "invalid role %q", request.Role)
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.emailTaken(model.NormalizeEmail(request.Email), ""); err != nil {
+		return "", err
+	}
+
+	now := s.timestamp()
+	u := &user{
+		id:           uuid.NewString(),
+		name:         request.Name,
+		email:        model.NormalizeEmail(request.Email),
+		imagePath:    request.ImagePath,
+		role:         request.Role,
+		passwordHash: request.PasswordHash,
+		createdAt:    now,
+		updatedAt:    now,
+		version:      1,
+	}
+	s.users[u.id] = u
+
+	return u.id, nil
+}
+
+func (p *Users) Select(ctx context.Context, request *entity.GetRecord) (*schema.UsersGetResponse, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[request.ID]
+	if !ok || (u.deletedAt != nil && !request.IncludeDeleted) {
+		return nil, entity.NewError(entity.KindNotFound, "user with id %s not found", request.ID)
+	}
+
+	response := u.get()
+	return &response, nil
+}
+
+func (p *Users) Update(ctx context.Context, request *model.UpdateUser) (*schema.UsersUpdateResponse, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[request.Id]
+	if !ok || u.deletedAt != nil {
+		return nil, entity.NewError(entity.KindNotFound, "user with id %s not found", request.Id)
+	}
+	if !matchesVersion(u.version, request.Version) {
+		return nil, stale(request.Id, *request.Version)
+	}
+	if request.Email != nil {
+		if err := s.emailTaken(model.NormalizeEmail(*request.Email), u.id); err != nil {
+			return nil, err
+		}
+	}
+
+	if request.Name != nil {
+		u.name = *request.Name
+	}
+	if request.Email != nil {
+		u.email = model.NormalizeEmail(*request.Email)
+	}
+	if request.ImagePath != nil {
+		u.imagePath = *request.ImagePath
+	}
+	u.updatedAt = s.timestamp()
+	u.version++
+
+	return u.update(), nil
+}
+
+func (p *Users) Delete(ctx context.Context, request *entity.DeleteRecord) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[request.ID]
+	if !ok || u.deletedAt != nil {
+		return entity.NewError(entity.KindNotFound, "user with id %s not found", request.ID)
+	}
+	if !matchesVersion(u.version, request.Version) {
+		return stale(request.ID, *request.Version)
+	}
+
+	now := s.timestamp()
+	u.deletedAt = &now
+	u.version++
+
+	return nil
+}
+
+func (p *Users) Restore(ctx context.Context, request *entity.RestoreRecord) (*schema.UsersUpdateResponse, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[request.ID]
+	if !ok || u.deletedAt == nil {
+		return nil, entity.NewError(entity.KindNotFound, "no deleted user found with id %s", request.ID)
+	}
+	// Otro usuario vigente pudo registrarse con el mismo email mientras este estaba eliminado.
+	if err := s.emailTaken(u.email, u.id); err != nil {
+		return nil, err
+	}
+
+	u.deletedAt = nil
+	u.updatedAt = s.timestamp()
+	u.version++
+
+	return u.update(), nil
+}
+
+// PurgeDeleted elimina definitivamente los usuarios eliminados antes de before y retorna las
+// miniaturas de sus avatares; sus videos quedan sin usuario, como con ON DELETE SET NULL.
+func (p *Users) PurgeDeleted(ctx context.Context, before time.Time) (int64, []string, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var purged int64
+	var keys []string
+	for id, u := range s.users {
+		if u.deletedAt == nil || !u.deletedAt.Before(before) {
+			continue
+		}
+		delete(s.users, id)
+		keys = append(keys, model.AvatarKeys(id, u.imagePath)...)
+		for _, v := range s.videos {
+			if v.userID == id {
+				v.userID = ""
+			}
+		}
+		purged++
+	}
+	return purged, keys, nil
+}
+
+func (p *Users) SelectUserCredentials(ctx context.Context, request *model.GetUserCredentials) (*schema.UserCredentials, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if u.deletedAt != nil || u.email != model.NormalizeEmail(request.Email) {
+			continue
+		}
+		if u.passwordHash == "" {
+			return nil, fmt.Errorf("user with email %s has no password: %w", request.Email, modelAuth.ErrInvalidCredentials)
+		}
+		return &schema.UserCredentials{ID: u.id, Role: u.role, PasswordHash: u.passwordHash}, nil
+	}
+	return nil, fmt.Errorf("user with email %s not found: %w", request.Email, modelAuth.ErrInvalidCredentials)
+}
+
+var usersTable = table[*user, schema.UsersGetResponse]{
+	name: "users",
+	fields: map[string]func(*user) any{
+		"id":         func(u *user) any { return u.id },
+		"name":       func(u *user) any { return u.name },
+		"email":      func(u *user) any { return u.email },
+		"created_at": func(u *user) any { return u.createdAt },
+		"updated_at": func(u *user) any { return u.updatedAt },
+	},
+	id:      func(u *user) string { return u.id },
+	deleted: func(u *user) bool { return u.deletedAt != nil },
+	item: func(u *user) schema.UsersGetResponse {
+		// Como en el listado SQL, los elementos no incluyen el rol.
+		response := u.get()
+		response.Role = ""
+		return response
+	},
+}
+
+func (p *Users) List(ctx context.Context, request *model.ListUsers) (*entity.Page[schema.UsersGetResponse], error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := make([]*user, 0, len(s.users))
+	for _, u := range s.users {
+		rows = append(rows, u)
+	}
+	return list(usersTable, rows, request.PageRequest, request.Query())
+}
+
+// emailTaken replica el índice único parcial de LOWER(email): solo cuentan los usuarios vigentes
+// distintos de except. email ya viene normalizado.
+func (s *Store) emailTaken(email, except string) error {
+	for _, u := range s.users {
+		if u.id != except && u.deletedAt == nil && u.email == email {
+			return entity.NewError(entity.KindConflict, "email %s is already registered", email)
+		}
+	}
+	return nil
+}
+
+func (u *user) get() schema.UsersGetResponse {
+	return schema.UsersGetResponse{
+		ID:        u.id,
+		Name:      u.name,
+		Email:     u.email,
+		ImagePath: u.imagePath,
+		Role:      u.role,
+		CreatedAt: formatTimestamp(u.createdAt),
+		UpdatedAt: formatTimestamp(u.updatedAt),
+		Version:   u.version,
+		DeletedAt: formatDeletedAt(u.deletedAt),
+	}
+}
+
+func (u *user) update() *schema.UsersUpdateResponse {
+	return &schema.UsersUpdateResponse{
+		ID:        u.id,
+		Name:      u.name,
+		Email:     u.email,
+		ImagePath: u.imagePath,
+		CreatedAt: formatTimestamp(u.createdAt),
+		UpdatedAt: formatTimestamp(u.updatedAt),
+		Version:   u.version,
+	}
+}
@@ -0,0 +1,299 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/videos"
+	schema "CrudPlatform/internal/core/domain/repository/schema/videos"
+
+	"github.com/google/uuid"
+)
+
+type video struct {
+	id, title, description, createdBy string
+	// userID y challengeID vacíos equivalen a NULL: el video no tiene esa relación.
+	userID, challengeID  string
+	content              *schema.VideoContent
+	createdAt, updatedAt time.Time
+	deletedAt            *time.Time
+	version              int64
+}
+
+// Videos implementa ports.DBRepositoryVideo sobre un Store.
+type Videos struct {
+	store *Store
+}
+
+func (p *Videos) Create(ctx context.Context, request *model.Videos) (string, error) {
+	if err := checkContext(ctx); err != nil {
+		return "", err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkRelations(request.UserID, request.ChallengeID); err != nil {
+		return "", err
+	}
+
+	now := s.timestamp()
+	v := &video{
+		id:          uuid.NewString(),
+		title:       request.Title,
+		description: request.Description,
+		createdBy:   request.CreatedBy,
+		userID:      request.UserID,
+		challengeID: request.ChallengeID,
+		createdAt:   now,
+		updatedAt:   now,
+		version:     1,
+	}
+	s.videos[v.id] = v
+
+	return v.id, nil
+}
+
+func (p *Videos) Select(ctx context.Context, request *entity.GetRecord) (*schema.VideosGetResponse, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, ok := s.videos[request.ID]
+	if !ok || (v.deletedAt != nil && !request.IncludeDeleted) {
+		return nil, entity.NewError(entity.KindNotFound, "video with id %s not found", request.ID)
+	}
+
+	response := v.get()
+	return &response, nil
+}
+
+func (p *Videos) Update(ctx context.Context, request *model.UpdateVideo) (*schema.VideosUpdateResponse, error) {
+	return p.write(ctx, request.ID, request.Version, func(s *Store, v *video) error {
+		if request.ChallengeID != nil {
+			if err := s.checkRelations("", *request.ChallengeID); err != nil {
+				return err
+			}
+			v.challengeID = *request.ChallengeID
+		}
+		if request.Title != nil {
+			v.title = *request.Title
+		}
+		if request.Description != nil {
+			v.description = *request.Description
+		}
+		return nil
+	})
+}
+
+func (p *Videos) UpdateVideoContent(ctx context.Context, request *model.UpdateVideoContent) (*schema.VideosUpdateResponse, error) {
+	return p.write(ctx, request.ID, request.Version, func(_ *Store, v *video) error {
+		previous := ""
+		if v.content != nil {
+			previous = v.content.Key
+		}
+		if previous != request.Previous {
+			return entity.NewError(entity.KindConflict, "content of video %s was replaced by another upload", request.ID)
+		}
+		v.content = &schema.VideoContent{
+			Key:         request.Key,
+			Size:        request.Size,
+			ContentType: request.ContentType,
+			Checksum:    request.Checksum,
+		}
+		if request.Duration != nil {
+			duration := *request.Duration
+			v.content.Duration = &duration
+		}
+		return nil
+	})
+}
+
+// write aplica change al video vigente id, condicionado a version, y avanza su versión.
+func (p *Videos) write(ctx context.Context, id string, version *int64, change func(*Store, *video) error) (*schema.VideosUpdateResponse, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.videos[id]
+	if !ok || v.deletedAt != nil {
+		return nil, entity.NewError(entity.KindNotFound, "video with id %s not found", id)
+	}
+	if !matchesVersion(v.version, version) {
+		return nil, stale(id, *version)
+	}
+	if err := change(s, v); err != nil {
+		return nil, err
+	}
+	v.updatedAt = s.timestamp()
+	v.version++
+
+	return v.update(), nil
+}
+
+func (p *Videos) Delete(ctx context.Context, request *entity.DeleteRecord) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.videos[request.ID]
+	if !ok || v.deletedAt != nil {
+		return entity.NewError(entity.KindNotFound, "video with id %s not found", request.ID)
+	}
+	if !matchesVersion(v.version, request.Version) {
+		return stale(request.ID, *request.Version)
+	}
+
+	now := s.timestamp()
+	v.deletedAt = &now
+	v.version++
+
+	return nil
+}
+
+func (p *Videos) Restore(ctx context.Context, request *entity.RestoreRecord) (*schema.VideosUpdateResponse, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.videos[request.ID]
+	if !ok || v.deletedAt == nil {
+		return nil, entity.NewError(entity.KindNotFound, "no deleted video found with id %s", request.ID)
+	}
+
+	v.deletedAt = nil
+	v.updatedAt = s.timestamp()
+	v.version++
+
+	return v.update(), nil
+}
+
+// PurgeDeleted borra los videos eliminados antes de before y retorna sus archivos.
+func (p *Videos) PurgeDeleted(ctx context.Context, before time.Time) (int64, []string, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	s := p.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var purged int64
+	var keys []string
+	for id, v := range s.videos {
+		if v.deletedAt != nil && v.deletedAt.Before(before) {
+			delete(s.videos, id)
+			if v.content != nil {
+				keys = append(keys, v.content.Key)
+			}
+			purged++
+		}
+	}
+	return purged, keys, nil
+}
+
+var videosTable = table[*video, schema.VideosGetResponse]{
+	name: "videos",
+	fields: map[string]func(*video) any{
+		"id":         func(v *video) any { return v.id },
+		"title":      func(v *video) any { return v.title },
+		"created_at": func(v *video) any { return v.createdAt },
+		"updated_at": func(v *video) any { return v.updatedAt },
+	},
+	filters: map[string]func(*video) any{
+		"user_id":      func(v *video) any { return v.userID },
+		"challenge_id": func(v *video) any { return v.challengeID },
+	},
+	text:    func(v *video) string { return v.title + " " + v.description },
+	id:      func(v *video) string { return v.id },
+	deleted: func(v *video) bool { return v.deletedAt != nil },
+	item: func(v *video) schema.VideosGetResponse {
+		response := v.get()
+		response.CreatedBy = ""
+		return response
+	},
+}
+
+func (p *Videos) List(ctx context.Context, request *model.ListVideos) (*entity.Page[schema.VideosGetResponse], error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s := p.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := make([]*video, 0, len(s.videos))
+	for _, v := range s.videos {
+		rows = append(rows, v)
+	}
+	return list(videosTable, rows, request.PageRequest, request.Query())
+}
+
+// checkRelations replica las llaves foráneas de videos: un usuario o challenge eliminado pero no
+// purgado todavía existe, así que se puede referenciar.
+func (s *Store) checkRelations(userID, challengeID string) error {
+	if _, ok := s.users[userID]; userID != "" && !ok {
+		return entity.NewError(entity.KindValidation, "user %s does not exist", userID)
+	}
+	if _, ok := s.challenges[challengeID]; challengeID != "" && !ok {
+		return entity.NewError(entity.KindValidation, "challenge %s does not exist", challengeID)
+	}
+	return nil
+}
+
+func (v *video) get() schema.VideosGetResponse {
+	return schema.VideosGetResponse{
+		ID:          v.id,
+		Title:       v.title,
+		Description: v.description,
+		UserID:      v.userID,
+		ChallengeID: v.challengeID,
+		CreatedBy:   v.createdBy,
+		CreatedAt:   formatTimestamp(v.createdAt),
+		UpdatedAt:   formatTimestamp(v.updatedAt),
+		Version:     v.version,
+		DeletedAt:   formatDeletedAt(v.deletedAt),
+		Content:     v.contentCopy(),
+	}
+}
+
+func (v *video) update() *schema.VideosUpdateResponse {
+	return &schema.VideosUpdateResponse{
+		ID:          v.id,
+		Title:       v.title,
+		Description: v.description,
+		ChallengeID: v.challengeID,
+		CreatedAt:   formatTimestamp(v.createdAt),
+		UpdatedAt:   formatTimestamp(v.updatedAt),
+		Version:     v.version,
+		Content:     v.contentCopy(),
+	}
+}
+
+// contentCopy retorna una copia para que quien recibe la respuesta no modifique el registro.
+func (v *video) contentCopy() *schema.VideoContent {
+	if v.content == nil {
+		return nil
+	}
+	content := *v.content
+	return &content
+}
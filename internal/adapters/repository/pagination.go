@@ -0,0 +1,190 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// cursor es la posición de keyset codificada en los cursores opacos de los listados:
+// los valores de los campos de orden y el id del registro.
+type cursor struct {
+	Values []any  `json:"v"`
+	ID     string `json:"i"`
+	Sort   string `json:"s"`
+	Prev   bool   `json:"p,omitempty"`
+}
+
+func encodeCursor(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value string) (cursor, error) {
+	var c cursor
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return c, entity.NewError(entity.KindValidation, "invalid cursor")
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return c, entity.NewError(entity.KindValidation, "invalid cursor")
+	}
+	return c, nil
+}
+
+// row es un registro escaneado junto con los valores de sus campos ordenables.
+type row[T any] struct {
+	item   T
+	id     string
+	fields map[string]any
+}
+
+// listSpec describe cómo listar y escanear los registros de una tabla.
+type listSpec[T any] struct {
+	tableSpec
+	scan func(rows *sql.Rows) (row[T], error)
+}
+
+// listPage lista una página filtrada y ordenada según la consulta, usando offset o
+// keyset según si la solicitud trae cursor.
+func listPage[T any](ctx context.Context, db *sql.DB, spec listSpec[T], request entity.PageRequest, query entity.ListQuery) (*entity.Page[T], error) {
+	request.Normalize()
+	if len(query.Sort) == 0 {
+		query.Sort = entity.DefaultSort
+	}
+	sortKey := entity.SortString(query.Sort)
+
+	var position cursor
+	if request.Cursor != "" {
+		var err error
+		position, err = decodeCursor(request.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if position.Sort != sortKey {
+			return nil, entity.NewError(entity.KindValidation, "invalid cursor: sort changed")
+		}
+		if position.Values, err = cursorValues(spec.tableSpec, query.Sort, position.Values); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := orderBy(spec.tableSpec, query.Sort, position.Prev)
+	if err != nil {
+		return nil, err
+	}
+
+	count := &queryBuilder{dialect: dialectOf(db)}
+	if err := count.filter(spec.tableSpec, query); err != nil {
+		return nil, err
+	}
+
+	var total int
+	if err := conn(ctx, db).QueryRowContext(ctx, "SELECT COUNT(*) FROM "+spec.name+count.whereClause(), count.args...).Scan(&total); err != nil {
+		return nil, dbError(err, "error counting %s", spec.name)
+	}
+
+	list := &queryBuilder{dialect: count.dialect}
+	if err := list.filter(spec.tableSpec, query); err != nil {
+		return nil, err
+	}
+	if request.Cursor != "" {
+		if err := list.keyset(spec.tableSpec, query.Sort, position); err != nil {
+			return nil, err
+		}
+	}
+
+	statement := "SELECT " + spec.columns + " FROM " + spec.name + list.whereClause() + order + " LIMIT " + list.bind(request.PageSize+1)
+	if request.Cursor == "" {
+		statement += " OFFSET " + list.bind(request.Offset())
+	}
+
+	rows, err := conn(ctx, db).QueryContext(ctx, statement, list.args...)
+	if err != nil {
+		return nil, dbError(err, "error listing %s", spec.name)
+	}
+	defer rows.Close()
+
+	var scanned []row[T]
+	for rows.Next() {
+		r, err := spec.scan(rows)
+		if err != nil {
+			return nil, dbError(err, "error scanning %s row", spec.name)
+		}
+		scanned = append(scanned, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dbError(err, "error listing %s", spec.name)
+	}
+
+	hasMore := len(scanned) > request.PageSize
+	if hasMore {
+		scanned = scanned[:request.PageSize]
+	}
+
+	if position.Prev {
+		for i, j := 0, len(scanned)-1; i < j; i, j = i+1, j-1 {
+			scanned[i], scanned[j] = scanned[j], scanned[i]
+		}
+	}
+
+	page := &entity.Page[T]{Items: make([]T, 0, len(scanned)), Total: total}
+	for _, r := range scanned {
+		page.Items = append(page.Items, r.item)
+	}
+	if len(scanned) == 0 {
+		return page, nil
+	}
+
+	at := func(r row[T], prev bool) string {
+		c := cursor{ID: r.id, Sort: sortKey, Prev: prev}
+		for _, key := range query.Sort {
+			c.Values = append(c.Values, r.fields[key.Field])
+		}
+		return encodeCursor(c)
+	}
+	first, last := scanned[0], scanned[len(scanned)-1]
+
+	switch {
+	case request.Cursor == "":
+		if hasMore {
+			page.NextCursor = at(last, false)
+		}
+		if request.Page > 1 {
+			page.PrevCursor = at(first, true)
+		}
+	case position.Prev:
+		page.NextCursor = at(last, false)
+		if hasMore {
+			page.PrevCursor = at(first, true)
+		}
+	default:
+		page.PrevCursor = at(first, true)
+		if hasMore {
+			page.NextCursor = at(last, false)
+		}
+	}
+
+	return page, nil
+}
+
+func formatTimestamp(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// formatDeletedAt formatea deleted_at, que solo tienen los registros eliminados.
+func formatDeletedAt(t sql.NullTime) *string {
+	if !t.Valid {
+		return nil
+	}
+	value := formatTimestamp(t.Time)
+	return &value
+}
+
+// nullString guarda los valores opcionales vacíos como NULL, por ejemplo las llaves foráneas sin relación.
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"CrudPlatform/internal/core/domain/repository/model/users"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		original := cursor{Values: []any{"2024-01-02T03:04:05Z", float64(3)}, ID: "abc", Sort: "-created_at,difficulty", Prev: true}
+
+		decoded, err := decodeCursor(encodeCursor(original))
+		require.NoError(t, err)
+		assert.Equal(t, original, decoded)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := decodeCursor("not a cursor")
+		assert.Error(t, err)
+
+		_, err = decodeCursor(encodeCursor(cursor{}))
+		assert.Error(t, err)
+	})
+}
+
+func userRows(now time.Time, ids ...string) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "image_path", "created_at", "updated_at", "version", "deleted_at"})
+	for i, id := range ids {
+		createdAt := now.Add(-time.Duration(i) * time.Minute)
+		rows.AddRow(id, "User "+id, id+"@example.com", "", createdAt, createdAt, 1, nil)
+	}
+	return rows
+}
+
+func TestListUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBdRepository(db, 0)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	t.Run("Offset_FirstPage", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery("SELECT (.+) FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \\$1 OFFSET \\$2").
+			WithArgs(3, 0).
+			WillReturnRows(userRows(now, "a", "b", "c"))
+
+		page, err := repo.List(ctx, &users.ListUsers{PageRequest: entity.PageRequest{Page: 1, PageSize: 2}})
+		require.NoError(t, err)
+		assert.Equal(t, 3, page.Total)
+		require.Len(t, page.Items, 2)
+		assert.Equal(t, "a", page.Items[0].ID)
+		assert.Equal(t, "b", page.Items[1].ID)
+		assert.NotEmpty(t, page.NextCursor)
+		assert.Empty(t, page.PrevCursor)
+
+		next, err := decodeCursor(page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "b", next.ID)
+		assert.False(t, next.Prev)
+	})
+
+	t.Run("Offset_SecondPage", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery("SELECT (.+) FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \\$1 OFFSET \\$2").
+			WithArgs(3, 2).
+			WillReturnRows(userRows(now, "c"))
+
+		page, err := repo.List(ctx, &users.ListUsers{PageRequest: entity.PageRequest{Page: 2, PageSize: 2}})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Empty(t, page.NextCursor)
+		assert.NotEmpty(t, page.PrevCursor)
+	})
+
+	t.Run("Cursor_Next", func(t *testing.T) {
+		position := cursor{Values: []any{now}, ID: "b", Sort: "-created_at"}
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery("SELECT (.+) FROM users WHERE deleted_at IS NULL AND \\(\\(created_at < \\$1\\) OR \\(created_at = \\$2 AND id < \\$3\\)\\) ORDER BY created_at DESC, id DESC LIMIT \\$4").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "b", 3).
+			WillReturnRows(userRows(now, "c"))
+
+		page, err := repo.List(ctx, &users.ListUsers{PageRequest: entity.PageRequest{PageSize: 2, Cursor: encodeCursor(position)}})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Empty(t, page.NextCursor)
+
+		prev, err := decodeCursor(page.PrevCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "c", prev.ID)
+		assert.True(t, prev.Prev)
+	})
+
+	t.Run("Cursor_Prev", func(t *testing.T) {
+		position := cursor{Values: []any{now}, ID: "c", Sort: "-created_at", Prev: true}
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery("SELECT (.+) FROM users WHERE deleted_at IS NULL AND \\(\\(created_at > \\$1\\) OR \\(created_at = \\$2 AND id > \\$3\\)\\) ORDER BY created_at ASC, id ASC LIMIT \\$4").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "c", 3).
+			WillReturnRows(userRows(now, "b", "a"))
+
+		page, err := repo.List(ctx, &users.ListUsers{PageRequest: entity.PageRequest{PageSize: 2, Cursor: encodeCursor(position)}})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 2)
+		assert.Equal(t, "a", page.Items[0].ID)
+		assert.Equal(t, "b", page.Items[1].ID)
+		assert.NotEmpty(t, page.NextCursor)
+		assert.Empty(t, page.PrevCursor)
+	})
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		page, err := repo.List(ctx, &users.ListUsers{PageRequest: entity.PageRequest{Cursor: "???"}})
+		assert.Error(t, err)
+		assert.Nil(t, page)
+		assert.Contains(t, err.Error(), "invalid cursor")
+	})
+
+	t.Run("TamperedCursor", func(t *testing.T) {
+		// Un cursor editado a mano con un número en lugar de la fecha se rechaza antes de consultar.
+		position := cursor{Values: []any{float64(42)}, ID: "c", Sort: "-created_at"}
+
+		page, err := repo.List(ctx, &users.ListUsers{PageRequest: entity.PageRequest{Cursor: encodeCursor(position)}})
+		assert.Nil(t, page)
+		assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SortChangedCursor", func(t *testing.T) {
+		position := cursor{Values: []any{now}, ID: "c", Sort: "-created_at"}
+
+		request := &users.ListUsers{
+			PageRequest: entity.PageRequest{Cursor: encodeCursor(position)},
+			ListFilter:  entity.ListFilter{Sort: "name"},
+		}
+		page, err := repo.List(ctx, request)
+		assert.Error(t, err)
+		assert.Nil(t, page)
+		assert.Contains(t, err.Error(), "sort changed")
+	})
+
+	t.Run("Filtered", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users WHERE deleted_at IS NULL AND LOWER\\(email\\) LIKE \\$1").
+			WithArgs("%@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT (.+) FROM users WHERE deleted_at IS NULL AND LOWER\\(email\\) LIKE \\$1 (.+) ORDER BY name ASC, id ASC LIMIT \\$2 OFFSET \\$3").
+			WithArgs("%@example.com", 11, 0).
+			WillReturnRows(userRows(now, "a"))
+
+		request := &users.ListUsers{
+			ListFilter:  entity.ListFilter{Sort: "name"},
+			EmailDomain: "example.com",
+		}
+		page, err := repo.List(ctx, request)
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+	})
+
+	t.Run("InvalidSortField", func(t *testing.T) {
+		page, err := repo.List(ctx, &users.ListUsers{ListFilter: entity.ListFilter{Sort: "password"}})
+		assert.Error(t, err)
+		assert.Nil(t, page)
+		assert.Contains(t, err.Error(), "invalid field password for users")
+	})
+
+	t.Run("CountError", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users").
+			WillReturnError(fmt.Errorf("count error"))
+
+		page, err := repo.List(ctx, &users.ListUsers{})
+		assert.Error(t, err)
+		assert.Nil(t, page)
+		assert.Contains(t, err.Error(), "error counting users")
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// purgeDeleted borra definitivamente las filas de table eliminadas antes de before y retorna cuántas
+// borró. Con returning, scan lee esas columnas de cada fila borrada, por ejemplo las llaves de sus
+// archivos; sin returning no se lee nada.
+func purgeDeleted(ctx context.Context, db querier, table string, before time.Time, returning string, scan func(rows *sql.Rows) error) (int64, error) {
+	return deleteBefore(ctx, db, table, "deleted_at", before, returning, scan)
+}
+
+// deleteBefore borra las filas de table cuyo column es anterior a before, como purgeDeleted.
+func deleteBefore(ctx context.Context, db querier, table, column string, before time.Time, returning string, scan func(rows *sql.Rows) error) (int64, error) {
+	query := "DELETE FROM " + table + " WHERE " + column + " < $1"
+	if returning == "" {
+		result, err := db.ExecContext(ctx, query, before)
+		if err != nil {
+			return 0, dbError(err, "error purging %s", table)
+		}
+
+		purged, err := result.RowsAffected()
+		if err != nil {
+			return 0, dbError(err, "error reading affected rows")
+		}
+		return purged, nil
+	}
+
+	rows, err := db.QueryContext(ctx, query+" RETURNING "+returning, before)
+	if err != nil {
+		return 0, dbError(err, "error purging %s", table)
+	}
+	defer rows.Close()
+
+	var purged int64
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return 0, dbError(err, "error scanning purged %s row", table)
+		}
+		purged++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, dbError(err, "error purging %s", table)
+	}
+	return purged, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelUsers "CrudPlatform/internal/core/domain/repository/model/users"
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBdRepositoryChallenge(db, 0)
+	ctx := context.Background()
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("DeletesExpiredRows", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM challenges WHERE deleted_at < $1")).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+
+		purged, blobs, err := repo.PurgeDeleted(ctx, before)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), purged)
+		assert.Empty(t, blobs)
+	})
+
+	t.Run("ExecError", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM challenges").
+			WithArgs(before).
+			WillReturnError(fmt.Errorf("exec error"))
+
+		purged, _, err := repo.PurgeDeleted(ctx, before)
+		assert.Zero(t, purged)
+		assert.Equal(t, entity.KindInternal, entity.KindOf(err))
+		assert.Contains(t, err.Error(), "error purging challenges")
+	})
+
+	t.Run("ReturnsAvatarBlobs", func(t *testing.T) {
+		users := NewBdRepository(db, 0)
+		avatar := "https://api.example.com/users/u1/avatar/" + avatarFile
+
+		mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM users WHERE deleted_at < $1 RETURNING id, image_path")).
+			WithArgs(before).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "image_path"}).AddRow("u1", avatar).AddRow("u2", "https://cdn.example.com/me.png"))
+
+		purged, blobs, err := users.PurgeDeleted(ctx, before)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+		assert.Equal(t, modelUsers.AvatarKeys("u1", avatar), blobs)
+	})
+
+	t.Run("ReturnsVideoAndPartBlobs", func(t *testing.T) {
+		videos := NewBdRepositoryVideo(db, 0)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT u.video_id, p.blob_key FROM video_upload_parts p JOIN video_uploads u ON u.id = p.upload_id JOIN videos v ON v.id = u.video_id WHERE v.deleted_at < $1")).
+			WithArgs(before).
+			WillReturnRows(sqlmock.NewRows([]string{"video_id", "blob_key"}).AddRow("v1", "uploads/a/1").AddRow("v3", "uploads/b/1"))
+		// v3 fue restaurado entre la lectura y el DELETE: sus partes siguen en uso.
+		mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM videos WHERE deleted_at < $1 RETURNING id, content_key")).
+			WithArgs(before).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "content_key"}).AddRow("v1", "videos/v1/a").AddRow("v2", nil))
+
+		purged, blobs, err := videos.PurgeDeleted(ctx, before)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+		assert.Equal(t, []string{"videos/v1/a", "uploads/a/1"}, blobs)
+	})
+
+	t.Run("ExpireVideoUploads", func(t *testing.T) {
+		videos := NewBdRepositoryVideo(db, 0)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT u.id, p.blob_key FROM video_upload_parts p JOIN video_uploads u ON u.id = p.upload_id WHERE u.created_at < $1")).
+			WithArgs(before).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "blob_key"}).AddRow("up-1", "uploads/up-1/a").AddRow("up-1", "uploads/up-1/b"))
+		mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM video_uploads WHERE created_at < $1 RETURNING id")).
+			WithArgs(before).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("up-1").AddRow("up-2"))
+
+		expired, blobs, err := videos.ExpireVideoUploads(ctx, before)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), expired)
+		assert.Equal(t, []string{"uploads/up-1/a", "uploads/up-1/b"}, blobs)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// avatarFile es el archivo de un avatar guardado, un uuid con su extensión.
+const avatarFile = "0b6f1b52-7c4e-4d8f-9a43-5f0a6c1e2d3b.png"
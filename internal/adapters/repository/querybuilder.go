@@ -0,0 +1,255 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tableSpec describe qué campos de la API se pueden filtrar y ordenar en una tabla y
+// con qué columna se corresponden. Solo los campos listados llegan al SQL.
+type tableSpec struct {
+	name    string
+	columns string
+	fields  map[string]string
+	// filters son campos que se pueden filtrar pero no ordenar, como las llaves foráneas.
+	filters map[string]string
+	// search es la columna tsvector de Postgres; searchColumns, las columnas de texto en las que
+	// busca SQLite.
+	search        string
+	searchColumns []string
+	// softDelete indica que la tabla marca los registros eliminados con deleted_at.
+	softDelete bool
+	// numeric son los campos ordenables enteros; los demás son texto, salvo las fechas.
+	numeric map[string]bool
+}
+
+func (s tableSpec) column(field string) (string, error) {
+	column, ok := s.fields[field]
+	if !ok {
+		return "", entity.NewError(entity.KindValidation, "invalid field %s for %s", field, s.name)
+	}
+	return column, nil
+}
+
+func (s tableSpec) filterColumn(field string) (string, error) {
+	if column, ok := s.filters[field]; ok {
+		return column, nil
+	}
+	return s.column(field)
+}
+
+// queryBuilder acumula condiciones, asignaciones y sus argumentos numerados ($1, $2, ...).
+type queryBuilder struct {
+	conditions  []string
+	assignments []string
+	args        []any
+	dialect     dialect
+}
+
+func (b *queryBuilder) bind(value any) string {
+	b.args = append(b.args, value)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+func (b *queryBuilder) where(condition string) {
+	b.conditions = append(b.conditions, condition)
+}
+
+// set agrega una columna al SET de un UPDATE parcial.
+func (b *queryBuilder) set(column string, value any) {
+	b.assignments = append(b.assignments, column+" = "+b.bind(value))
+}
+
+// update arma un UPDATE de la fila id que escribe solo las columnas asignadas, updated_at y la
+// siguiente versión. Con version, la fila solo se actualiza si sigue en esa versión. Las filas
+// eliminadas no se actualizan.
+func (b *queryBuilder) update(table, id string, version *int64, now time.Time, returning string) string {
+	b.set("updated_at", now)
+	b.whereRow(id, version)
+	b.where("deleted_at IS NULL")
+	return b.updateStatement(table) + " RETURNING " + returning
+}
+
+// softDelete marca la fila id como eliminada, condicionado a version cuando se indica. La fila
+// sigue en la tabla hasta que se restaura o se purga.
+func (b *queryBuilder) softDelete(table, id string, version *int64, now time.Time) string {
+	b.set("deleted_at", now)
+	b.whereRow(id, version)
+	b.where("deleted_at IS NULL")
+	return b.updateStatement(table)
+}
+
+// restore quita la marca de eliminación de la fila id; sin fila, el id no existe o no está eliminado.
+func (b *queryBuilder) restore(table, id string, now time.Time, returning string) string {
+	b.assignments = append(b.assignments, "deleted_at = NULL")
+	b.set("updated_at", now)
+	b.whereRow(id, nil)
+	b.where("deleted_at IS NOT NULL")
+	return b.updateStatement(table) + " RETURNING " + returning
+}
+
+// updateStatement arma el UPDATE con las asignaciones acumuladas; toda escritura incrementa la versión.
+func (b *queryBuilder) updateStatement(table string) string {
+	assignments := append(append([]string{}, b.assignments...), "version = version + 1")
+	return "UPDATE " + table + " SET " + strings.Join(assignments, ", ") + b.whereClause()
+}
+
+func (b *queryBuilder) whereRow(id string, version *int64) {
+	b.where("id = " + b.bind(id))
+	if version != nil {
+		b.where("version = " + b.bind(*version))
+	}
+}
+
+func (b *queryBuilder) whereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// filter agrega los filtros y la búsqueda de texto completo de la consulta. En las tablas con
+// borrado lógico, los registros eliminados se excluyen salvo que la consulta los incluya.
+func (b *queryBuilder) filter(spec tableSpec, query entity.ListQuery) error {
+	if spec.softDelete && !query.IncludeDeleted {
+		b.where("deleted_at IS NULL")
+	}
+
+	for _, f := range query.Filters {
+		column, err := spec.filterColumn(f.Field)
+		if err != nil {
+			return err
+		}
+
+		switch f.Op {
+		case entity.OpEq:
+			b.where(column + " = " + b.bind(f.Value))
+		case entity.OpGte:
+			b.where(column + " >= " + b.bind(f.Value))
+		case entity.OpLte:
+			b.where(column + " <= " + b.bind(f.Value))
+		case entity.OpEndsWith:
+			pattern := "%" + escapeLike(strings.ToLower(fmt.Sprint(f.Value)))
+			b.where("LOWER(" + column + ") LIKE " + b.bind(pattern) + ` ESCAPE '\'`)
+		default:
+			return entity.NewError(entity.KindValidation, "invalid filter operator %s", f.Op)
+		}
+	}
+
+	if query.Search != "" {
+		if spec.search == "" {
+			return entity.NewError(entity.KindValidation, "full-text search is not supported for %s", spec.name)
+		}
+		b.search(spec, query.Search)
+	}
+
+	return nil
+}
+
+// keyset agrega la condición que continúa un listado después (o antes) de la posición del cursor.
+//
+// Para un orden (a DESC, b ASC, id DESC) y una posición (x, y, z) genera
+// (a < x) OR (a = x AND b > y) OR (a = x AND b = y AND id < z).
+func (b *queryBuilder) keyset(spec tableSpec, sort []entity.SortField, position cursor) error {
+	keys := withTiebreaker(sort)
+	if len(position.Values) != len(sort) {
+		return entity.NewError(entity.KindValidation, "invalid cursor")
+	}
+	values := append(append([]any{}, position.Values...), position.ID)
+
+	var alternatives []string
+	for i, key := range keys {
+		var terms []string
+		for j := 0; j < i; j++ {
+			column, err := spec.column(keys[j].Field)
+			if err != nil {
+				return err
+			}
+			terms = append(terms, column+" = "+b.bind(values[j]))
+		}
+
+		column, err := spec.column(key.Field)
+		if err != nil {
+			return err
+		}
+		operator := ">"
+		if key.Desc != position.Prev {
+			operator = "<"
+		}
+		terms = append(terms, column+" "+operator+" "+b.bind(values[i]))
+
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+
+	b.where("(" + strings.Join(alternatives, " OR ") + ")")
+	return nil
+}
+
+// cursorValues valida los valores de un cursor contra los campos de orden y los convierte al tipo
+// de su columna. El cursor llega del cliente: un valor de otro tipo es un cursor inválido y no debe
+// llegar a la base de datos. Las fechas, que en el JSON son texto, se recuperan como time.Time:
+// Postgres convertiría el texto solo, pero SQLite lo compararía con su propio formato de fecha.
+func cursorValues(spec tableSpec, sort []entity.SortField, values []any) ([]any, error) {
+	invalid := entity.NewError(entity.KindValidation, "invalid cursor")
+	if len(values) != len(sort) {
+		return nil, invalid
+	}
+
+	typed := make([]any, len(values))
+	for i, key := range sort {
+		switch value := values[i].(type) {
+		case string:
+			switch {
+			case key.Field == "created_at" || key.Field == "updated_at":
+				t, err := time.Parse(time.RFC3339Nano, value)
+				if err != nil {
+					return nil, invalid
+				}
+				typed[i] = t
+			case spec.numeric[key.Field]:
+				return nil, invalid
+			default:
+				typed[i] = value
+			}
+		case float64:
+			if !spec.numeric[key.Field] || value != math.Trunc(value) || math.Abs(value) > 1<<53 {
+				return nil, invalid
+			}
+			typed[i] = int64(value)
+		default:
+			return nil, invalid
+		}
+	}
+	return typed, nil
+}
+
+// orderBy construye la cláusula ORDER BY; reverse invierte cada dirección para recorrer hacia atrás.
+func orderBy(spec tableSpec, sort []entity.SortField, reverse bool) (string, error) {
+	var parts []string
+	for _, key := range withTiebreaker(sort) {
+		column, err := spec.column(key.Field)
+		if err != nil {
+			return "", err
+		}
+		direction := "ASC"
+		if key.Desc != reverse {
+			direction = "DESC"
+		}
+		parts = append(parts, column+" "+direction)
+	}
+	return " ORDER BY " + strings.Join(parts, ", "), nil
+}
+
+// withTiebreaker agrega id como último criterio para que el orden sea total.
+func withTiebreaker(sort []entity.SortField) []entity.SortField {
+	desc := len(sort) > 0 && sort[0].Desc
+	return append(append([]entity.SortField{}, sort...), entity.SortField{Field: "id", Desc: desc})
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
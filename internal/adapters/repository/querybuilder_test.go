@@ -0,0 +1,174 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	t.Run("Filters", func(t *testing.T) {
+		b := &queryBuilder{}
+		err := b.filter(challengesTable, entity.ListQuery{
+			Filters: []entity.Filter{
+				{Field: "difficulty", Op: entity.OpGte, Value: 2},
+				{Field: "difficulty", Op: entity.OpLte, Value: 4},
+			},
+			Search: "go backend",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, " WHERE deleted_at IS NULL AND difficulty >= $1 AND difficulty <= $2 AND search_vector @@ plainto_tsquery('simple', $3)", b.whereClause())
+		assert.Equal(t, []any{2, 4, "go backend"}, b.args)
+	})
+
+	t.Run("SQLiteSearch", func(t *testing.T) {
+		b := &queryBuilder{dialect: dialectSQLite}
+		err := b.filter(videosTable, entity.ListQuery{Search: "Go  100%"})
+		require.NoError(t, err)
+		assert.Equal(t, ` WHERE deleted_at IS NULL`+
+			` AND (LOWER(title) LIKE $1 ESCAPE '\' OR LOWER(description) LIKE $1 ESCAPE '\')`+
+			` AND (LOWER(title) LIKE $2 ESCAPE '\' OR LOWER(description) LIKE $2 ESCAPE '\')`, b.whereClause())
+		assert.Equal(t, []any{"%go%", `%100\%%`}, b.args)
+	})
+
+	t.Run("EndsWithEscapesWildcards", func(t *testing.T) {
+		b := &queryBuilder{}
+		err := b.filter(usersTable, entity.ListQuery{
+			Filters: []entity.Filter{{Field: "email", Op: entity.OpEndsWith, Value: "@Ex_ample%.com"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ` WHERE deleted_at IS NULL AND LOWER(email) LIKE $1 ESCAPE '\'`, b.whereClause())
+		assert.Equal(t, []any{`%@ex\_ample\%.com`}, b.args)
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		b := &queryBuilder{}
+		err := b.filter(videosTable, entity.ListQuery{
+			Filters: []entity.Filter{{Field: "title; DROP TABLE videos", Op: entity.OpEq, Value: "x"}},
+		})
+		assert.Error(t, err)
+		assert.Empty(t, b.args)
+	})
+
+	t.Run("SearchNotSupported", func(t *testing.T) {
+		b := &queryBuilder{}
+		err := b.filter(usersTable, entity.ListQuery{Search: "john"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "full-text search is not supported for users")
+	})
+
+	t.Run("KeysetMixedDirections", func(t *testing.T) {
+		sort := entity.ParseSort("-created_at,title")
+		at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		b := &queryBuilder{}
+		err := b.keyset(challengesTable, sort, cursor{Values: []any{at, "Go"}, ID: "abc"})
+		require.NoError(t, err)
+		assert.Equal(t,
+			" WHERE ((created_at < $1) OR (created_at = $2 AND title > $3) OR (created_at = $4 AND title = $5 AND id < $6))",
+			b.whereClause())
+		assert.Equal(t, []any{at, at, "Go", at, "Go", "abc"}, b.args)
+	})
+
+	t.Run("KeysetPrev", func(t *testing.T) {
+		b := &queryBuilder{}
+		err := b.keyset(videosTable, entity.DefaultSort, cursor{Values: []any{"2024-01-01T00:00:00Z"}, ID: "abc", Prev: true})
+		require.NoError(t, err)
+		assert.Equal(t, " WHERE ((created_at > $1) OR (created_at = $2 AND id > $3))", b.whereClause())
+	})
+
+	t.Run("KeysetValuesMismatch", func(t *testing.T) {
+		b := &queryBuilder{}
+		err := b.keyset(videosTable, entity.DefaultSort, cursor{ID: "abc"})
+		assert.Error(t, err)
+	})
+
+	t.Run("CursorValues", func(t *testing.T) {
+		sort := entity.ParseSort("-created_at,difficulty,title")
+		values, err := cursorValues(challengesTable, sort, []any{"2024-01-01T00:00:00Z", float64(3), "Go"})
+		require.NoError(t, err)
+		assert.Equal(t, []any{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), int64(3), "Go"}, values)
+	})
+
+	t.Run("CursorValuesTampered", func(t *testing.T) {
+		sort := entity.ParseSort("-created_at,difficulty,title")
+		for name, values := range map[string][]any{
+			"DateNotText":    {float64(1), float64(3), "Go"},
+			"DateNotRFC3339": {"yesterday", float64(3), "Go"},
+			"NumberAsText":   {"2024-01-01T00:00:00Z", "3; DROP TABLE", "Go"},
+			"NotInteger":     {"2024-01-01T00:00:00Z", 3.5, "Go"},
+			"TextAsNumber":   {"2024-01-01T00:00:00Z", float64(3), float64(7)},
+			"Object":         {"2024-01-01T00:00:00Z", float64(3), map[string]any{"a": "b"}},
+			"Null":           {"2024-01-01T00:00:00Z", nil, "Go"},
+			"Missing":        {"2024-01-01T00:00:00Z"},
+		} {
+			_, err := cursorValues(challengesTable, sort, values)
+			assert.Equal(t, entity.KindValidation, entity.KindOf(err), name)
+		}
+	})
+	t.Run("UpdateOnlyAssignedColumns", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		b := &queryBuilder{}
+		b.set("title", "Go")
+		b.set("difficulty", 3)
+		query := b.update("challenges", "abc", nil, now, "id, title")
+		assert.Equal(t, "UPDATE challenges SET title = $1, difficulty = $2, updated_at = $3, version = version + 1 WHERE id = $4 AND deleted_at IS NULL RETURNING id, title", query)
+		assert.Equal(t, []any{"Go", 3, now, "abc"}, b.args)
+	})
+
+	t.Run("UpdateExpectedVersion", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		version := int64(3)
+		b := &queryBuilder{}
+		b.set("title", "Go")
+		query := b.update("challenges", "abc", &version, now, "id")
+		assert.Equal(t, "UPDATE challenges SET title = $1, updated_at = $2, version = version + 1 WHERE id = $3 AND version = $4 AND deleted_at IS NULL RETURNING id", query)
+		assert.Equal(t, []any{"Go", now, "abc", int64(3)}, b.args)
+	})
+
+	t.Run("SoftDeleteExpectedVersion", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		version := int64(3)
+		b := &queryBuilder{}
+		query := b.softDelete("videos", "abc", &version, now)
+		assert.Equal(t, "UPDATE videos SET deleted_at = $1, version = version + 1 WHERE id = $2 AND version = $3 AND deleted_at IS NULL", query)
+		assert.Equal(t, []any{now, "abc", int64(3)}, b.args)
+	})
+
+	t.Run("RestoreOnlyDeletedRows", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		b := &queryBuilder{}
+		query := b.restore("users", "abc", now, "id")
+		assert.Equal(t, "UPDATE users SET deleted_at = NULL, updated_at = $1, version = version + 1 WHERE id = $2 AND deleted_at IS NOT NULL RETURNING id", query)
+		assert.Equal(t, []any{now, "abc"}, b.args)
+	})
+
+	t.Run("FilterExcludesDeleted", func(t *testing.T) {
+		b := &queryBuilder{}
+		require.NoError(t, b.filter(videosTable, entity.ListQuery{}))
+		assert.Equal(t, " WHERE deleted_at IS NULL", b.whereClause())
+
+		b = &queryBuilder{}
+		require.NoError(t, b.filter(videosTable, entity.ListQuery{IncludeDeleted: true}))
+		assert.Equal(t, "", b.whereClause())
+
+		b = &queryBuilder{}
+		require.NoError(t, b.filter(auditEventsTable, entity.ListQuery{}))
+		assert.Equal(t, "", b.whereClause())
+	})
+}
+
+func TestOrderBy(t *testing.T) {
+	order, err := orderBy(challengesTable, entity.ParseSort("-difficulty,title"), false)
+	require.NoError(t, err)
+	assert.Equal(t, " ORDER BY difficulty DESC, title ASC, id DESC", order)
+
+	order, err = orderBy(challengesTable, entity.ParseSort("-difficulty,title"), true)
+	require.NoError(t, err)
+	assert.Equal(t, " ORDER BY difficulty ASC, title DESC, id ASC", order)
+
+	_, err = orderBy(usersTable, entity.ParseSort("difficulty"), false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,599 @@
+// Package repositorytest contiene la suite de conformidad que todo adaptador de los puertos
+// DBRepository* debe pasar, sea Postgres, SQLite o el repositorio en memoria. La suite fija la
+// semántica que los servicios esperan: errores por tipo, versiones, borrado lógico y listados.
+package repositorytest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelAuth "CrudPlatform/internal/core/domain/repository/model/auth"
+	challenges "CrudPlatform/internal/core/domain/repository/model/challenges"
+	users "CrudPlatform/internal/core/domain/repository/model/users"
+	videos "CrudPlatform/internal/core/domain/repository/model/videos"
+	schemaChallenges "CrudPlatform/internal/core/domain/repository/schema/challenges"
+	"CrudPlatform/internal/core/ports"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Repositories son los adaptadores bajo prueba, que deben compartir el mismo almacenamiento
+// porque los videos referencian usuarios y challenges.
+type Repositories struct {
+	Users      ports.DBRepositoryUsers
+	Challenges ports.DBRepositoryChallenge
+	Videos     ports.DBRepositoryVideo
+}
+
+// Run ejecuta la suite; open se llama en cada subtest y debe retornar repositorios vacíos.
+func Run(t *testing.T, open func(t *testing.T) Repositories) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, r Repositories)
+	}{
+		{"Users", testUsers},
+		{"UserEmailUnique", testUserEmailUnique},
+		{"UserCredentials", testUserCredentials},
+		{"Challenges", testChallenges},
+		{"Videos", testVideos},
+		{"NotFound", testNotFound},
+		{"Purge", testPurge},
+		{"ListPagination", testListPagination},
+		{"ListFilters", testListFilters},
+		{"ConcurrentWrites", testConcurrentWrites},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, open(t))
+		})
+	}
+}
+
+// assertTimestamp verifica que value sea una fecha RFC 3339 entre from y to, con un margen para
+// la precisión de cada base de datos.
+func assertTimestamp(t *testing.T, value string, from, to time.Time) time.Time {
+	t.Helper()
+	at, err := time.Parse(time.RFC3339Nano, value)
+	require.NoError(t, err, "timestamp %q", value)
+	assert.False(t, at.Before(from.Add(-time.Millisecond)), "%s is before %s", at, from)
+	assert.False(t, at.After(to.Add(time.Millisecond)), "%s is after %s", at, to)
+	return at
+}
+
+func createUser(t *testing.T, r Repositories, email string) string {
+	t.Helper()
+	id, err := r.Users.Create(context.Background(), &users.User{Name: "User " + email, Email: email, Role: "viewer"})
+	require.NoError(t, err)
+	return id
+}
+
+func testUsers(t *testing.T, r Repositories) {
+	ctx := context.Background()
+
+	before := time.Now()
+	id, err := r.Users.Create(ctx, &users.User{Name: "Ana", Email: "ana@example.com", ImagePath: "https://example.com/ana.png", Role: "creator"})
+	require.NoError(t, err)
+	after := time.Now()
+	_, err = uuid.Parse(id)
+	assert.NoError(t, err)
+
+	user, err := r.Users.Select(ctx, &entity.GetRecord{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, id, user.ID)
+	assert.Equal(t, "Ana", user.Name)
+	assert.Equal(t, "ana@example.com", user.Email)
+	assert.Equal(t, "https://example.com/ana.png", user.ImagePath)
+	assert.Equal(t, "creator", user.Role)
+	assert.Equal(t, int64(1), user.Version)
+	assert.Nil(t, user.DeletedAt)
+	createdAt := assertTimestamp(t, user.CreatedAt, before, after)
+	assert.True(t, createdAt.Equal(assertTimestamp(t, user.UpdatedAt, before, after)))
+
+	_, err = r.Users.Create(ctx, &users.User{Name: "Root", Email: "root@example.com", Role: "root"})
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+
+	name := "Ana María"
+	stale := int64(7)
+	_, err = r.Users.Update(ctx, &users.UpdateUser{Id: id, Name: &name, Version: &stale})
+	assert.Equal(t, entity.KindPreconditionFailed, entity.KindOf(err))
+
+	updated, err := r.Users.Update(ctx, &users.UpdateUser{Id: id, Name: &name, Version: &user.Version})
+	require.NoError(t, err)
+	assert.Equal(t, id, updated.ID)
+	assert.Equal(t, name, updated.Name)
+	assert.Equal(t, "ana@example.com", updated.Email, "fields without value keep their value")
+	assert.Equal(t, int64(2), updated.Version)
+	assert.True(t, createdAt.Equal(assertTimestamp(t, updated.CreatedAt, before, after)))
+	assertTimestamp(t, updated.UpdatedAt, createdAt, time.Now())
+
+	version := updated.Version
+	require.NoError(t, r.Users.Delete(ctx, &entity.DeleteRecord{ID: id, Version: &version}))
+	_, err = r.Users.Select(ctx, &entity.GetRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	_, err = r.Users.Update(ctx, &users.UpdateUser{Id: id, Name: &name})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(r.Users.Delete(ctx, &entity.DeleteRecord{ID: id})))
+
+	deleted, err := r.Users.Select(ctx, &entity.GetRecord{ID: id, IncludeDeleted: true})
+	require.NoError(t, err)
+	require.NotNil(t, deleted.DeletedAt)
+	assertTimestamp(t, *deleted.DeletedAt, createdAt, time.Now())
+	assert.Equal(t, int64(3), deleted.Version)
+
+	restored, err := r.Users.Restore(ctx, &entity.RestoreRecord{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, name, restored.Name)
+	assert.Equal(t, int64(4), restored.Version)
+
+	_, err = r.Users.Restore(ctx, &entity.RestoreRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err), "only deleted records can be restored")
+}
+
+func testUserEmailUnique(t *testing.T, r Repositories) {
+	ctx := context.Background()
+
+	first := createUser(t, r, "ana@example.com")
+	_, err := r.Users.Create(ctx, &users.User{Name: "Other", Email: "ana@example.com", Role: "viewer"})
+	assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+
+	other := createUser(t, r, "other@example.com")
+	email := "ana@example.com"
+	_, err = r.Users.Update(ctx, &users.UpdateUser{Id: other, Email: &email})
+	assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+
+	// Un usuario eliminado libera su email, pero no puede volver mientras otro lo use.
+	require.NoError(t, r.Users.Delete(ctx, &entity.DeleteRecord{ID: first}))
+	second := createUser(t, r, "ana@example.com")
+	_, err = r.Users.Restore(ctx, &entity.RestoreRecord{ID: first})
+	assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+
+	require.NoError(t, r.Users.Delete(ctx, &entity.DeleteRecord{ID: second}))
+	_, err = r.Users.Restore(ctx, &entity.RestoreRecord{ID: first})
+	assert.NoError(t, err)
+
+	// El email no distingue mayúsculas: se guarda en minúsculas y choca con el de otro usuario
+	// aunque se escriba distinto.
+	_, err = r.Users.Create(ctx, &users.User{Name: "Other", Email: "ANA@Example.com", Role: "viewer"})
+	assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+	mixed := "Ana@Example.COM"
+	_, err = r.Users.Update(ctx, &users.UpdateUser{Id: other, Email: &mixed})
+	assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+
+	bob := createUser(t, r, "Bob@Example.com")
+	user, err := r.Users.Select(ctx, &entity.GetRecord{ID: bob})
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", user.Email)
+	renamed := "Robert@Example.com"
+	updated, err := r.Users.Update(ctx, &users.UpdateUser{Id: bob, Email: &renamed})
+	require.NoError(t, err)
+	assert.Equal(t, "robert@example.com", updated.Email)
+}
+
+func testUserCredentials(t *testing.T, r Repositories) {
+	ctx := context.Background()
+
+	id, err := r.Users.Create(ctx, &users.User{Name: "Ana", Email: "Ana@Example.com", Role: "admin", PasswordHash: "hash"})
+	require.NoError(t, err)
+	createUser(t, r, "nopassword@example.com")
+
+	credentials, err := r.Users.SelectUserCredentials(ctx, &users.GetUserCredentials{Email: "ana@example.COM"})
+	require.NoError(t, err)
+	assert.Equal(t, id, credentials.ID)
+	assert.Equal(t, "admin", credentials.Role)
+	assert.Equal(t, "hash", credentials.PasswordHash)
+
+	_, err = r.Users.SelectUserCredentials(ctx, &users.GetUserCredentials{Email: "nopassword@example.com"})
+	assert.ErrorIs(t, err, modelAuth.ErrInvalidCredentials)
+	_, err = r.Users.SelectUserCredentials(ctx, &users.GetUserCredentials{Email: "missing@example.com"})
+	assert.ErrorIs(t, err, modelAuth.ErrInvalidCredentials)
+
+	require.NoError(t, r.Users.Delete(ctx, &entity.DeleteRecord{ID: id}))
+	_, err = r.Users.SelectUserCredentials(ctx, &users.GetUserCredentials{Email: "ana@example.com"})
+	assert.ErrorIs(t, err, modelAuth.ErrInvalidCredentials)
+}
+
+func testChallenges(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	creator := createUser(t, r, "creator@example.com")
+
+	before := time.Now()
+	id, err := r.Challenges.Create(ctx, &challenges.Challenge{Title: "Go basics", Description: "Variables", Difficulty: 2, CreatedBy: creator})
+	require.NoError(t, err)
+
+	challenge, err := r.Challenges.Select(ctx, &entity.GetRecord{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, id, challenge.ID)
+	assert.Equal(t, "Go basics", challenge.Title)
+	assert.Equal(t, "Variables", challenge.Description)
+	assert.Equal(t, 2, challenge.Difficulty)
+	assert.Equal(t, creator, challenge.CreatedBy)
+	assert.Equal(t, int64(1), challenge.Version)
+	assertTimestamp(t, challenge.CreatedAt, before, time.Now())
+
+	difficulty := 4
+	stale := int64(5)
+	_, err = r.Challenges.Update(ctx, &challenges.UpdateChallenge{ID: id, Difficulty: &difficulty, Version: &stale})
+	assert.Equal(t, entity.KindPreconditionFailed, entity.KindOf(err))
+
+	updated, err := r.Challenges.Update(ctx, &challenges.UpdateChallenge{ID: id, Difficulty: &difficulty, Version: &challenge.Version})
+	require.NoError(t, err)
+	assert.Equal(t, "Go basics", updated.Title)
+	assert.Equal(t, 4, updated.Difficulty)
+	assert.Equal(t, int64(2), updated.Version)
+
+	assert.Equal(t, entity.KindPreconditionFailed, entity.KindOf(r.Challenges.Delete(ctx, &entity.DeleteRecord{ID: id, Version: &stale})))
+	require.NoError(t, r.Challenges.Delete(ctx, &entity.DeleteRecord{ID: id, Version: &updated.Version}))
+	_, err = r.Challenges.Select(ctx, &entity.GetRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+
+	// Una versión sobre un registro eliminado es un not found, no un conflicto de versión.
+	_, err = r.Challenges.Update(ctx, &challenges.UpdateChallenge{ID: id, Difficulty: &difficulty, Version: &stale})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+
+	restored, err := r.Challenges.Restore(ctx, &entity.RestoreRecord{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), restored.Version)
+	_, err = r.Challenges.Restore(ctx, &entity.RestoreRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+}
+
+func testVideos(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	owner := createUser(t, r, "owner@example.com")
+	challengeID, err := r.Challenges.Create(ctx, &challenges.Challenge{Title: "Go basics", Difficulty: 1})
+	require.NoError(t, err)
+
+	_, err = r.Videos.Create(ctx, &videos.Videos{Title: "Orphan", UserID: uuid.NewString()})
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err), "user_id must reference a user")
+	_, err = r.Videos.Create(ctx, &videos.Videos{Title: "Orphan", ChallengeID: uuid.NewString()})
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err), "challenge_id must reference a challenge")
+
+	id, err := r.Videos.Create(ctx, &videos.Videos{Title: "Solution", Description: "My take", UserID: owner, ChallengeID: challengeID, CreatedBy: owner})
+	require.NoError(t, err)
+
+	video, err := r.Videos.Select(ctx, &entity.GetRecord{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, "Solution", video.Title)
+	assert.Equal(t, "My take", video.Description)
+	assert.Equal(t, owner, video.UserID)
+	assert.Equal(t, challengeID, video.ChallengeID)
+	assert.Equal(t, owner, video.CreatedBy)
+	assert.Equal(t, int64(1), video.Version)
+	assert.Nil(t, video.Content)
+
+	missing := uuid.NewString()
+	_, err = r.Videos.Update(ctx, &videos.UpdateVideo{ID: id, ChallengeID: &missing})
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+
+	unlinked := ""
+	updated, err := r.Videos.Update(ctx, &videos.UpdateVideo{ID: id, ChallengeID: &unlinked, Version: &video.Version})
+	require.NoError(t, err)
+	assert.Empty(t, updated.ChallengeID)
+	assert.Equal(t, "Solution", updated.Title)
+	assert.Equal(t, int64(2), updated.Version)
+
+	duration := 12.5
+	withContent, err := r.Videos.UpdateVideoContent(ctx, &videos.UpdateVideoContent{
+		ID: id, Key: "videos/" + id, Size: 2048, ContentType: "video/mp4", Checksum: "abc", Duration: &duration, Version: &updated.Version,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, withContent.Content)
+	assert.Equal(t, int64(3), withContent.Version)
+
+	video, err = r.Videos.Select(ctx, &entity.GetRecord{ID: id})
+	require.NoError(t, err)
+	require.NotNil(t, video.Content)
+	assert.Equal(t, "videos/"+id, video.Content.Key)
+	assert.Equal(t, int64(2048), video.Content.Size)
+	assert.Equal(t, "video/mp4", video.Content.ContentType)
+	assert.Equal(t, "abc", video.Content.Checksum)
+	require.NotNil(t, video.Content.Duration)
+	assert.Equal(t, 12.5, *video.Content.Duration)
+
+	_, err = r.Videos.UpdateVideoContent(ctx, &videos.UpdateVideoContent{ID: id, Key: "other", Version: &updated.Version, Previous: "videos/" + id})
+	assert.Equal(t, entity.KindPreconditionFailed, entity.KindOf(err))
+
+	// Solo se reemplaza el archivo que el video todavía tiene; si otro upload ganó, es un conflicto.
+	_, err = r.Videos.UpdateVideoContent(ctx, &videos.UpdateVideoContent{ID: id, Key: "late"})
+	assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+	replaced, err := r.Videos.UpdateVideoContent(ctx, &videos.UpdateVideoContent{ID: id, Key: "videos/" + id + "/2", Previous: "videos/" + id})
+	require.NoError(t, err)
+	assert.Equal(t, "videos/"+id+"/2", replaced.Content.Key)
+	_, err = r.Videos.UpdateVideoContent(ctx, &videos.UpdateVideoContent{ID: id, Key: "late", Previous: "videos/" + id})
+	assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+
+	// Un usuario eliminado, pero no purgado, todavía se puede referenciar.
+	require.NoError(t, r.Users.Delete(ctx, &entity.DeleteRecord{ID: owner}))
+	_, err = r.Videos.Create(ctx, &videos.Videos{Title: "Another", UserID: owner})
+	assert.NoError(t, err)
+
+	require.NoError(t, r.Videos.Delete(ctx, &entity.DeleteRecord{ID: id}))
+	_, err = r.Videos.Select(ctx, &entity.GetRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	restored, err := r.Videos.Restore(ctx, &entity.RestoreRecord{ID: id})
+	require.NoError(t, err)
+	require.NotNil(t, restored.Content)
+	assert.Equal(t, int64(6), restored.Version)
+}
+
+func testNotFound(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	id := uuid.NewString()
+	name := "Nobody"
+	version := int64(1)
+
+	_, err := r.Users.Select(ctx, &entity.GetRecord{ID: id, IncludeDeleted: true})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	_, err = r.Users.Update(ctx, &users.UpdateUser{Id: id, Name: &name})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	_, err = r.Users.Update(ctx, &users.UpdateUser{Id: id, Name: &name, Version: &version})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(r.Users.Delete(ctx, &entity.DeleteRecord{ID: id, Version: &version})))
+	_, err = r.Users.Restore(ctx, &entity.RestoreRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+
+	_, err = r.Challenges.Select(ctx, &entity.GetRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	_, err = r.Challenges.Update(ctx, &challenges.UpdateChallenge{ID: id, Title: &name})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(r.Challenges.Delete(ctx, &entity.DeleteRecord{ID: id})))
+	_, err = r.Challenges.Restore(ctx, &entity.RestoreRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+
+	_, err = r.Videos.Select(ctx, &entity.GetRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	_, err = r.Videos.Update(ctx, &videos.UpdateVideo{ID: id, Title: &name})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	_, err = r.Videos.UpdateVideoContent(ctx, &videos.UpdateVideoContent{ID: id, Key: "key"})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(r.Videos.Delete(ctx, &entity.DeleteRecord{ID: id})))
+	_, err = r.Videos.Restore(ctx, &entity.RestoreRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+}
+
+func testPurge(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	owner := createUser(t, r, "owner@example.com")
+	kept := createUser(t, r, "kept@example.com")
+	challengeID, err := r.Challenges.Create(ctx, &challenges.Challenge{Title: "Go basics", Difficulty: 1})
+	require.NoError(t, err)
+	videoID, err := r.Videos.Create(ctx, &videos.Videos{Title: "Solution", UserID: owner, ChallengeID: challengeID})
+	require.NoError(t, err)
+
+	// La purga retorna los archivos de los registros borrados para que se borren del BlobStore.
+	avatar := "https://api.example.com/users/" + owner + "/avatar/" + uuid.NewString() + ".png"
+	_, err = r.Users.Update(ctx, &users.UpdateUser{Id: owner, ImagePath: &avatar})
+	require.NoError(t, err)
+	_, err = r.Videos.UpdateVideoContent(ctx, &videos.UpdateVideoContent{ID: videoID, Key: "videos/" + videoID, Size: 1, ContentType: "video/mp4", Checksum: "abc"})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Users.Delete(ctx, &entity.DeleteRecord{ID: owner}))
+	require.NoError(t, r.Challenges.Delete(ctx, &entity.DeleteRecord{ID: challengeID}))
+
+	purged, blobs, err := r.Users.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, purged, "records deleted after the cutoff are kept")
+	assert.Empty(t, blobs)
+
+	purged, blobs, err = r.Users.PurgeDeleted(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	assert.ElementsMatch(t, users.AvatarKeys(owner, avatar), blobs)
+	require.NotEmpty(t, blobs)
+	purged, blobs, err = r.Challenges.PurgeDeleted(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	assert.Empty(t, blobs)
+
+	_, err = r.Users.Select(ctx, &entity.GetRecord{ID: owner, IncludeDeleted: true})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	_, err = r.Users.Select(ctx, &entity.GetRecord{ID: kept})
+	assert.NoError(t, err)
+
+	// Los videos quedan sin las relaciones purgadas, como con ON DELETE SET NULL.
+	video, err := r.Videos.Select(ctx, &entity.GetRecord{ID: videoID})
+	require.NoError(t, err)
+	assert.Empty(t, video.UserID)
+	assert.Empty(t, video.ChallengeID)
+
+	require.NoError(t, r.Videos.Delete(ctx, &entity.DeleteRecord{ID: videoID}))
+	purged, blobs, err = r.Videos.PurgeDeleted(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	assert.Equal(t, []string{"videos/" + videoID}, blobs)
+	_, err = r.Videos.Restore(ctx, &entity.RestoreRecord{ID: videoID})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+}
+
+// seedChallenges crea challenges con dificultades distintas para que el orden sea determinista.
+func seedChallenges(t *testing.T, r Repositories) map[string]string {
+	t.Helper()
+	ids := map[string]string{}
+	for _, c := range []challenges.Challenge{
+		{Title: "Go basics", Description: "Variables and types", Difficulty: 1},
+		{Title: "Python tips", Description: "Idioms", Difficulty: 2},
+		{Title: "Go concurrency", Description: "Channels", Difficulty: 3},
+		{Title: "Rust ownership", Description: "Borrowing", Difficulty: 4},
+		{Title: "Intro to SQL", Description: "Joins", Difficulty: 5},
+	} {
+		id, err := r.Challenges.Create(context.Background(), &c)
+		require.NoError(t, err)
+		ids[c.Title] = id
+	}
+	return ids
+}
+
+func titles(page *entity.Page[schemaChallenges.ChallengeGetResponse]) []string {
+	var result []string
+	for _, item := range page.Items {
+		result = append(result, item.Title)
+	}
+	return result
+}
+
+func testListPagination(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedChallenges(t, r)
+	list := func(request challenges.ListChallenges) *entity.Page[schemaChallenges.ChallengeGetResponse] {
+		t.Helper()
+		page, err := r.Challenges.List(ctx, &request)
+		require.NoError(t, err)
+		return page
+	}
+	byDifficulty := entity.ListFilter{Sort: "difficulty"}
+
+	first := list(challenges.ListChallenges{PageRequest: entity.PageRequest{Page: 1, PageSize: 2}, ListFilter: byDifficulty})
+	assert.Equal(t, 5, first.Total)
+	assert.Equal(t, []string{"Go basics", "Python tips"}, titles(first))
+	assert.NotEmpty(t, first.NextCursor)
+	assert.Empty(t, first.PrevCursor)
+
+	offset := list(challenges.ListChallenges{PageRequest: entity.PageRequest{Page: 3, PageSize: 2}, ListFilter: byDifficulty})
+	assert.Equal(t, []string{"Intro to SQL"}, titles(offset))
+	assert.Empty(t, offset.NextCursor)
+	assert.NotEmpty(t, offset.PrevCursor)
+
+	second := list(challenges.ListChallenges{PageRequest: entity.PageRequest{PageSize: 2, Cursor: first.NextCursor}, ListFilter: byDifficulty})
+	assert.Equal(t, 5, second.Total)
+	assert.Equal(t, []string{"Go concurrency", "Rust ownership"}, titles(second))
+	assert.NotEmpty(t, second.PrevCursor)
+	assert.NotEmpty(t, second.NextCursor)
+
+	last := list(challenges.ListChallenges{PageRequest: entity.PageRequest{PageSize: 2, Cursor: second.NextCursor}, ListFilter: byDifficulty})
+	assert.Equal(t, []string{"Intro to SQL"}, titles(last))
+	assert.Empty(t, last.NextCursor)
+
+	back := list(challenges.ListChallenges{PageRequest: entity.PageRequest{PageSize: 2, Cursor: last.PrevCursor}, ListFilter: byDifficulty})
+	assert.Equal(t, []string{"Go concurrency", "Rust ownership"}, titles(back))
+	assert.NotEmpty(t, back.PrevCursor)
+	assert.NotEmpty(t, back.NextCursor)
+
+	start := list(challenges.ListChallenges{PageRequest: entity.PageRequest{PageSize: 2, Cursor: back.PrevCursor}, ListFilter: byDifficulty})
+	assert.Equal(t, []string{"Go basics", "Python tips"}, titles(start))
+	assert.Empty(t, start.PrevCursor)
+
+	descending := list(challenges.ListChallenges{PageRequest: entity.PageRequest{PageSize: 3}, ListFilter: entity.ListFilter{Sort: "-difficulty"}})
+	assert.Equal(t, []string{"Intro to SQL", "Rust ownership", "Go concurrency"}, titles(descending))
+	rest := list(challenges.ListChallenges{PageRequest: entity.PageRequest{PageSize: 3, Cursor: descending.NextCursor}, ListFilter: entity.ListFilter{Sort: "-difficulty"}})
+	assert.Equal(t, []string{"Python tips", "Go basics"}, titles(rest))
+
+	_, err := r.Challenges.List(ctx, &challenges.ListChallenges{PageRequest: entity.PageRequest{Cursor: first.NextCursor}, ListFilter: entity.ListFilter{Sort: "-difficulty"}})
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err), "a cursor only continues the sort it was created with")
+	_, err = r.Challenges.List(ctx, &challenges.ListChallenges{PageRequest: entity.PageRequest{Cursor: "not a cursor"}})
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+	_, err = r.Challenges.List(ctx, &challenges.ListChallenges{ListFilter: entity.ListFilter{Sort: "description"}})
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+}
+
+func testListFilters(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	ids := seedChallenges(t, r)
+
+	low, high := 2, 4
+	page, err := r.Challenges.List(ctx, &challenges.ListChallenges{ListFilter: entity.ListFilter{Sort: "difficulty"}, DifficultyMin: &low, DifficultyMax: &high})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	assert.Equal(t, []string{"Python tips", "Go concurrency", "Rust ownership"}, titles(page))
+
+	page, err = r.Challenges.List(ctx, &challenges.ListChallenges{ListFilter: entity.ListFilter{Sort: "difficulty"}, Q: "go"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Go basics", "Go concurrency"}, titles(page))
+
+	page, err = r.Challenges.List(ctx, &challenges.ListChallenges{Q: "Go channels"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Go concurrency"}, titles(page), "every word must match, in the title or the description")
+
+	require.NoError(t, r.Challenges.Delete(ctx, &entity.DeleteRecord{ID: ids["Go basics"]}))
+	page, err = r.Challenges.List(ctx, &challenges.ListChallenges{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, page.Total)
+	page, err = r.Challenges.List(ctx, &challenges.ListChallenges{ListFilter: entity.ListFilter{Sort: "difficulty", IncludeDeleted: true}})
+	require.NoError(t, err)
+	assert.Equal(t, 5, page.Total)
+	require.NotNil(t, page.Items[0].DeletedAt)
+	assert.Nil(t, page.Items[1].DeletedAt)
+
+	createUser(t, r, "ana@example.com")
+	createUser(t, r, "bob@Example.com")
+	createUser(t, r, "eve@other.com")
+	userPage, err := r.Users.List(ctx, &users.ListUsers{ListFilter: entity.ListFilter{Sort: "email"}, EmailDomain: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, userPage.Total)
+	require.Len(t, userPage.Items, 2)
+	assert.Equal(t, "ana@example.com", userPage.Items[0].Email)
+
+	future := time.Now().Add(time.Hour)
+	userPage, err = r.Users.List(ctx, &users.ListUsers{ListFilter: entity.ListFilter{CreatedFrom: future}})
+	require.NoError(t, err)
+	assert.Zero(t, userPage.Total)
+	assert.Empty(t, userPage.Items)
+
+	owner := createUser(t, r, "owner@example.com")
+	_, err = r.Videos.Create(ctx, &videos.Videos{Title: "Go solution", UserID: owner, ChallengeID: ids["Go concurrency"]})
+	require.NoError(t, err)
+	_, err = r.Videos.Create(ctx, &videos.Videos{Title: "Rust solution", ChallengeID: ids["Rust ownership"]})
+	require.NoError(t, err)
+
+	videoPage, err := r.Videos.List(ctx, &videos.ListVideos{UserID: owner})
+	require.NoError(t, err)
+	require.Len(t, videoPage.Items, 1)
+	assert.Equal(t, "Go solution", videoPage.Items[0].Title)
+	videoPage, err = r.Videos.List(ctx, &videos.ListVideos{ChallengeID: ids["Rust ownership"], Q: "solution"})
+	require.NoError(t, err)
+	require.Len(t, videoPage.Items, 1)
+	assert.Equal(t, "Rust solution", videoPage.Items[0].Title)
+}
+
+// testConcurrentWrites verifica que las restricciones se cumplan con escrituras simultáneas: un
+// solo usuario por email y una sola escritura por versión.
+func testConcurrentWrites(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	const n = 8
+
+	kinds := func(write func() error) map[entity.ErrorKind]int {
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		counts := map[entity.ErrorKind]int{}
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := write()
+				kind := entity.ErrorKind("")
+				if err != nil {
+					kind = entity.KindOf(err)
+				}
+				mu.Lock()
+				counts[kind]++
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		return counts
+	}
+
+	counts := kinds(func() error {
+		_, err := r.Users.Create(ctx, &users.User{Name: "Ana", Email: "ana@example.com", Role: "viewer"})
+		return err
+	})
+	assert.Equal(t, map[entity.ErrorKind]int{"": 1, entity.KindConflict: n - 1}, counts)
+
+	id, err := r.Challenges.Create(ctx, &challenges.Challenge{Title: "Go basics", Difficulty: 1})
+	require.NoError(t, err)
+	counts = kinds(func() error {
+		version := int64(1)
+		title := "Go " + uuid.NewString()
+		_, err := r.Challenges.Update(ctx, &challenges.UpdateChallenge{ID: id, Title: &title, Version: &version})
+		return err
+	})
+	assert.Equal(t, map[entity.ErrorKind]int{"": 1, entity.KindPreconditionFailed: n - 1}, counts)
+
+	challenge, err := r.Challenges.Select(ctx, &entity.GetRecord{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), challenge.Version)
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"CrudPlatform/cmd/config"
+	"CrudPlatform/cmd/config/db"
+	entity "CrudPlatform/internal/core/domain/repository"
+	auditModel "CrudPlatform/internal/core/domain/repository/model/audit"
+	challenges "CrudPlatform/internal/core/domain/repository/model/challenges"
+	users "CrudPlatform/internal/core/domain/repository/model/users"
+	videos "CrudPlatform/internal/core/domain/repository/model/videos"
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSQLiteDB abre un archivo SQLite nuevo con todas las migraciones aplicadas.
+func newSQLiteDB(t *testing.T) *sql.DB {
+	sqliteDB, err := db.Open(config.DatabaseConfig{Driver: config.DriverSQLite, Path: filepath.Join(t.TempDir(), "task.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { sqliteDB.Close() })
+
+	migrator, err := db.NewMigrator(sqliteDB, config.DriverSQLite)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(context.Background()))
+	return sqliteDB
+}
+
+func TestSQLite_Users(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBdRepository(newSQLiteDB(t), time.Second)
+
+	id, err := repo.Create(ctx, &users.User{Name: "Ana", Email: "ana@example.com", Role: "viewer"})
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, &users.User{Name: "Other", Email: "ana@example.com", Role: "viewer"})
+	assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+	_, err = repo.Create(ctx, &users.User{Name: "Root", Email: "root@example.com", Role: "root"})
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+
+	user, err := repo.Select(ctx, &entity.GetRecord{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, "ana@example.com", user.Email)
+	assert.Equal(t, int64(1), user.Version)
+
+	name := "Ana María"
+	stale := int64(7)
+	_, err = repo.Update(ctx, &users.UpdateUser{Id: id, Name: &name, Version: &stale})
+	assert.Equal(t, entity.KindPreconditionFailed, entity.KindOf(err))
+
+	updated, err := repo.Update(ctx, &users.UpdateUser{Id: id, Name: &name, Version: &user.Version})
+	require.NoError(t, err)
+	assert.Equal(t, name, updated.Name)
+	assert.Equal(t, int64(2), updated.Version)
+
+	require.NoError(t, repo.Delete(ctx, &entity.DeleteRecord{ID: id}))
+	_, err = repo.Select(ctx, &entity.GetRecord{ID: id})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+
+	// El email de un usuario eliminado se puede volver a registrar.
+	_, err = repo.Create(ctx, &users.User{Name: "Ana", Email: "ana@example.com", Role: "viewer"})
+	require.NoError(t, err)
+	_, err = repo.Restore(ctx, &entity.RestoreRecord{ID: id})
+	assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+
+	purged, _, err := repo.PurgeDeleted(ctx, time.Now().UTC().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+}
+
+func TestSQLite_ListAndSearch(t *testing.T) {
+	ctx := context.Background()
+	repo := NewBdRepositoryChallenge(newSQLiteDB(t), time.Second)
+
+	for _, title := range []string{"Go basics", "Rust basics", "Advanced Go", "SQL joins"} {
+		_, err := repo.Create(ctx, &challenges.Challenge{Title: title, Description: "Practice", Difficulty: 2})
+		require.NoError(t, err)
+	}
+
+	found, err := repo.List(ctx, &challenges.ListChallenges{Q: "GO"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.Total)
+
+	// Recorre todo el listado con cursores, del más reciente al más antiguo.
+	var titles []string
+	request := &challenges.ListChallenges{PageRequest: entity.PageRequest{PageSize: 3}}
+	for {
+		page, err := repo.List(ctx, request)
+		require.NoError(t, err)
+		for _, item := range page.Items {
+			titles = append(titles, item.Title)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		request.Cursor = page.NextCursor
+	}
+	assert.Equal(t, []string{"SQL joins", "Advanced Go", "Rust basics", "Go basics"}, titles)
+}
+
+func TestSQLite_Videos(t *testing.T) {
+	ctx := context.Background()
+	sqliteDB := newSQLiteDB(t)
+	repo := NewBdRepositoryVideo(sqliteDB, time.Second)
+
+	_, err := repo.Create(ctx, &videos.Videos{Title: "Demo", UserID: "00000000-0000-0000-0000-000000000000"})
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+
+	userID, err := NewBdRepository(sqliteDB, time.Second).Create(ctx, &users.User{Name: "Ana", Email: "ana@example.com", Role: "creator"})
+	require.NoError(t, err)
+	id, err := repo.Create(ctx, &videos.Videos{Title: "Demo", UserID: userID})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CreateVideoUpload(ctx, &videos.VideoUpload{ID: "u1", VideoID: id, Length: 10, ContentType: "video/mp4", CreatedAt: time.Now().UTC()}))
+	offset, err := repo.AppendVideoUploadPart(ctx, &videos.UploadPart{UploadID: "u1", Offset: 0, Size: 6, Key: "parts/u1/0"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), offset)
+	_, err = repo.AppendVideoUploadPart(ctx, &videos.UploadPart{UploadID: "u1", Offset: 0, Size: 4, Key: "parts/u1/0b"})
+	assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+
+	upload, err := repo.SelectVideoUpload(ctx, &videos.GetUpload{VideoID: id, ID: "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), upload.Offset)
+	require.Len(t, upload.Parts, 1)
+
+	duration := 1.5
+	video, err := repo.UpdateVideoContent(ctx, &videos.UpdateVideoContent{ID: id, Key: "videos/" + id, Size: 10, ContentType: "video/mp4", Checksum: "abc", Duration: &duration})
+	require.NoError(t, err)
+	require.NotNil(t, video.Content)
+	assert.Equal(t, &duration, video.Content.Duration)
+
+	// Un upload abandonado expira con sus partes; el que sigue vigente se purga con el video.
+	require.NoError(t, repo.CreateVideoUpload(ctx, &videos.VideoUpload{ID: "u2", VideoID: id, Length: 10, ContentType: "video/mp4", CreatedAt: time.Now().UTC().Add(-48 * time.Hour)}))
+	_, err = repo.AppendVideoUploadPart(ctx, &videos.UploadPart{UploadID: "u2", Offset: 0, Size: 3, Key: "parts/u2/0"})
+	require.NoError(t, err)
+	expired, blobs, err := repo.ExpireVideoUploads(ctx, time.Now().UTC().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), expired)
+	assert.Equal(t, []string{"parts/u2/0"}, blobs)
+	_, err = repo.SelectVideoUpload(ctx, &videos.GetUpload{VideoID: id, ID: "u2"})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+
+	require.NoError(t, repo.Delete(ctx, &entity.DeleteRecord{ID: id}))
+	purged, blobs, err := repo.PurgeDeleted(ctx, time.Now().UTC().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	assert.ElementsMatch(t, []string{"videos/" + id, "parts/u1/0"}, blobs)
+	_, err = repo.SelectVideoUpload(ctx, &videos.GetUpload{VideoID: id, ID: "u1"})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+}
+
+func TestSQLite_WithinTx(t *testing.T) {
+	ctx := context.Background()
+	sqliteDB := newSQLiteDB(t)
+	repo := NewBdRepository(sqliteDB, time.Second)
+	audit := NewBdRepositoryAudit(sqliteDB, time.Second)
+
+	id, err := repo.Create(ctx, &users.User{Name: "Ana", Email: "ana@example.com", Role: "viewer"})
+	require.NoError(t, err)
+	require.NoError(t, audit.CreateAuditEvent(ctx, &auditModel.Event{ID: "e1", Action: auditModel.ActionCreate, EntityType: "user", EntityID: id}))
+
+	t.Run("AuditErrorRollsBackWrite", func(t *testing.T) {
+		name := "Ana María"
+		err := audit.WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := repo.Update(ctx, &users.UpdateUser{Id: id, Name: &name}); err != nil {
+				return err
+			}
+			// El id repetido hace fallar el evento después de la escritura.
+			return audit.CreateAuditEvent(ctx, &auditModel.Event{ID: "e1", Action: auditModel.ActionUpdate, EntityType: "user", EntityID: id})
+		})
+		assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+
+		user, err := repo.Select(ctx, &entity.GetRecord{ID: id})
+		require.NoError(t, err)
+		assert.Equal(t, "Ana", user.Name)
+		assert.Equal(t, int64(1), user.Version)
+	})
+
+	t.Run("ListSeesUncommittedWrites", func(t *testing.T) {
+		err := audit.WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := repo.Create(ctx, &users.User{Name: "Luis", Email: "luis@example.com", Role: "viewer"}); err != nil {
+				return err
+			}
+			page, err := repo.List(ctx, &users.ListUsers{})
+			if err != nil {
+				return err
+			}
+			assert.Equal(t, 2, page.Total)
+			return errors.New("rollback")
+		})
+		assert.EqualError(t, err, "rollback")
+
+		page, err := repo.List(ctx, &users.ListUsers{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, page.Total)
+	})
+
+	t.Run("CommitsWriteAndEvent", func(t *testing.T) {
+		err := audit.WithinTx(ctx, func(ctx context.Context) error {
+			// SQLite no tiene FOR UPDATE; la lectura bloqueada es una lectura normal en la transacción.
+			if _, err := repo.Select(ctx, &entity.GetRecord{ID: id, ForUpdate: true}); err != nil {
+				return err
+			}
+			if err := repo.Delete(ctx, &entity.DeleteRecord{ID: id}); err != nil {
+				return err
+			}
+			return audit.CreateAuditEvent(ctx, &auditModel.Event{Action: auditModel.ActionDelete, EntityType: "user", EntityID: id})
+		})
+		require.NoError(t, err)
+
+		_, err = repo.Select(ctx, &entity.GetRecord{ID: id})
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+		events, err := audit.ListAuditEvents(ctx, &auditModel.ListEvents{})
+		require.NoError(t, err)
+		assert.Len(t, events.Items, 2)
+	})
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	modelAuth "CrudPlatform/internal/core/domain/repository/model/auth"
+	"context"
+	"database/sql"
+	"time"
+)
+
+func (p *BDRepositoryTokens) CreateRefreshToken(ctx context.Context, token *modelAuth.RefreshToken) error {
+	ctx, cancel := withTimeout(ctx, p.timeout, "user_id", token.UserID, "token_family", token.FamilyID)
+	defer cancel()
+
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := p.db.ExecContext(ctx, query, token.ID, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return dbError(err, "error executing statement")
+	}
+
+	return nil
+}
+
+func (p *BDRepositoryTokens) SelectRefreshToken(ctx context.Context, tokenHash string) (*modelAuth.RefreshToken, error) {
+	ctx, cancel := withTimeout(ctx, p.timeout)
+	defer cancel()
+
+	query := `
+		SELECT id, user_id, family_id, token_hash, expires_at, created_at, revoked_at
+		FROM refresh_tokens WHERE token_hash = $1
+	`
+	row := p.db.QueryRowContext(ctx, query, tokenHash)
+
+	var token modelAuth.RefreshToken
+	var revokedAt sql.NullTime
+
+	err := row.Scan(&token.ID, &token.UserID, &token.FamilyID, &token.TokenHash, &token.ExpiresAt, &token.CreatedAt, &revokedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, modelAuth.ErrInvalidRefreshToken
+		}
+		return nil, dbError(err, "error scanning refresh token row")
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+
+	return &token, nil
+}
+
+// RotateRefreshToken revoca el token actual y guarda su reemplazo en una sola transacción.
+// Si el token ya fue revocado por otro request concurrente retorna ErrInvalidRefreshToken.
+func (p *BDRepositoryTokens) RotateRefreshToken(ctx context.Context, current *modelAuth.RefreshToken, next *modelAuth.RefreshToken) error {
+	ctx, cancel := withTimeout(ctx, p.timeout, "user_id", current.UserID, "token_family", current.FamilyID)
+	defer cancel()
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbError(err, "error starting transaction")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = $1, replaced_by = $2 WHERE id = $3 AND revoked_at IS NULL",
+		next.CreatedAt, next.ID, current.ID,
+	)
+	if err != nil {
+		return dbError(err, "error revoking refresh token")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dbError(err, "error reading affected rows")
+	}
+	if rowsAffected == 0 {
+		return modelAuth.ErrInvalidRefreshToken
+	}
+
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = tx.ExecContext(ctx, query, next.ID, next.UserID, next.FamilyID, next.TokenHash, next.ExpiresAt, next.CreatedAt)
+	if err != nil {
+		return dbError(err, "error executing statement")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dbError(err, "error committing transaction")
+	}
+
+	return nil
+}
+
+func (p *BDRepositoryTokens) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	ctx, cancel := withTimeout(ctx, p.timeout, "token_family", familyID)
+	defer cancel()
+
+	query := "UPDATE refresh_tokens SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL"
+	_, err := p.db.ExecContext(ctx, query, time.Now().UTC(), familyID)
+	if err != nil {
+		return dbError(err, "error revoking refresh tokens")
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"CrudPlatform/internal/core/domain/repository/model/auth"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBDRepositoryTokens(t *testing.T) {
+	// Setup
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := &BDRepositoryTokens{db: db}
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	current := &auth.RefreshToken{
+		ID:        "token-1",
+		UserID:    "user-1",
+		FamilyID:  "family-1",
+		TokenHash: "hash-1",
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+	next := &auth.RefreshToken{
+		ID:        "token-2",
+		UserID:    "user-1",
+		FamilyID:  "family-1",
+		TokenHash: "hash-2",
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+
+	t.Run("CreateRefreshToken", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO refresh_tokens").
+			WithArgs(current.ID, current.UserID, current.FamilyID, current.TokenHash, current.ExpiresAt, current.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.CreateRefreshToken(ctx, current)
+		assert.NoError(t, err)
+	})
+
+	t.Run("CreateRefreshToken_ExecError", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO refresh_tokens").
+			WillReturnError(fmt.Errorf("exec error"))
+
+		err := repo.CreateRefreshToken(ctx, current)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error executing statement: exec error")
+	})
+
+	t.Run("SelectRefreshToken", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "user_id", "family_id", "token_hash", "expires_at", "created_at", "revoked_at"}).
+			AddRow(current.ID, current.UserID, current.FamilyID, current.TokenHash, current.ExpiresAt, current.CreatedAt, now)
+
+		mock.ExpectQuery("SELECT (.+) FROM refresh_tokens WHERE token_hash = \\$1").
+			WithArgs("hash-1").
+			WillReturnRows(rows)
+
+		token, err := repo.SelectRefreshToken(ctx, "hash-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", token.UserID)
+		assert.Equal(t, "family-1", token.FamilyID)
+		require.NotNil(t, token.RevokedAt)
+	})
+
+	t.Run("SelectRefreshToken_NotFound", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM refresh_tokens").
+			WithArgs("unknown").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.SelectRefreshToken(ctx, "unknown")
+		assert.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("RotateRefreshToken", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE refresh_tokens SET revoked_at = \\$1, replaced_by = \\$2 WHERE id = \\$3 AND revoked_at IS NULL").
+			WithArgs(next.CreatedAt, next.ID, current.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO refresh_tokens").
+			WithArgs(next.ID, next.UserID, next.FamilyID, next.TokenHash, next.ExpiresAt, next.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.RotateRefreshToken(ctx, current, next)
+		assert.NoError(t, err)
+	})
+
+	t.Run("RotateRefreshToken_AlreadyRevoked", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE refresh_tokens SET revoked_at").
+			WithArgs(next.CreatedAt, next.ID, current.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.RotateRefreshToken(ctx, current, next)
+		assert.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("RotateRefreshToken_InsertError", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE refresh_tokens SET revoked_at").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO refresh_tokens").
+			WillReturnError(fmt.Errorf("exec error"))
+		mock.ExpectRollback()
+
+		err := repo.RotateRefreshToken(ctx, current, next)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "exec error")
+	})
+
+	t.Run("RevokeRefreshTokenFamily", func(t *testing.T) {
+		mock.ExpectExec("UPDATE refresh_tokens SET revoked_at = \\$1 WHERE family_id = \\$2 AND revoked_at IS NULL").
+			WithArgs(sqlmock.AnyArg(), "family-1").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.RevokeRefreshTokenFamily(ctx, "family-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("RevokeRefreshTokenFamily_ExecError", func(t *testing.T) {
+		mock.ExpectExec("UPDATE refresh_tokens SET revoked_at").
+			WillReturnError(fmt.Errorf("exec error"))
+
+		err := repo.RevokeRefreshTokenFamily(ctx, "family-1")
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// querier es lo común a *sql.DB y *sql.Tx, para que una operación corra dentro o fuera de una
+// transacción sin cambiar.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// txScope es la transacción abierta por withinTx junto con el pool sobre el que se abrió.
+type txScope struct {
+	db *sql.DB
+	tx *sql.Tx
+}
+
+// conn retorna la transacción del contexto si se abrió sobre db y, si no, el propio db.
+func conn(ctx context.Context, db *sql.DB) querier {
+	if scope, ok := ctx.Value(txKey{}).(txScope); ok && scope.db == db {
+		return scope.tx
+	}
+	return db
+}
+
+// withinTx ejecuta fn en una transacción sobre db que se confirma solo si fn no falla. Las
+// operaciones que usan conn con el contexto de fn corren en ella; si ya hay una transacción
+// abierta sobre db, fn se une a ella.
+func withinTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := conn(ctx, db).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbError(err, "error starting transaction")
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, txScope{db: db, tx: tx})); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return dbError(err, "error committing transaction")
+	}
+	return nil
+}
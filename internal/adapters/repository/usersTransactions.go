@@ -1,100 +1,128 @@
 package repository
 
 import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelAuth "CrudPlatform/internal/core/domain/repository/model/auth"
 	model "CrudPlatform/internal/core/domain/repository/model/users"
 	schema "CrudPlatform/internal/core/domain/repository/schema/users"
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
-
-	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
-func (p *BDRepository) CreateUser(ctx *gin.Context, request *model.User) (string, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	id := uuid.NewString()
-	now := time.Now().UTC()
-
-	query := `
-		INSERT INTO users (id, name, email, image_path, created_at, updated_at) 
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
-	_, err := p.db.Exec(query, id, request.Name, request.Email, request.ImagePath, now, now)
-	if err != nil {
-		return "", fmt.Errorf("error executing statement: %w", err)
-	}
-
-	return id, nil
+// usersSpec guarda los usuarios; la contraseña solo se lee en SelectUserCredentials.
+var usersSpec = &crudSpec[model.User, model.UpdateUser, model.ListUsers, schema.UsersGetResponse, schema.UsersUpdateResponse]{
+	table: "users",
+	noun:  "user",
+	insert: func(request *model.User) ([]string, []any) {
+		// Los usuarios sin contraseña quedan con password_hash NULL y no pueden iniciar sesión.
+		passwordHash := sql.NullString{String: request.PasswordHash, Valid: request.PasswordHash != ""}
+		return []string{"name", "email", "image_path", "role", "password_hash"},
+			[]any{request.Name, model.NormalizeEmail(request.Email), request.ImagePath, request.Role, passwordHash}
+	},
+	assign: func(b *queryBuilder, request *model.UpdateUser) (string, *int64) {
+		if request.Name != nil {
+			b.set("name", *request.Name)
+		}
+		if request.Email != nil {
+			b.set("email", model.NormalizeEmail(*request.Email))
+		}
+		if request.ImagePath != nil {
+			b.set("image_path", *request.ImagePath)
+		}
+		return request.Id, request.Version
+	},
+	columns: "name, email, image_path, role, created_at, updated_at, version, deleted_at",
+	scan: func(row *sql.Row, id string) (*schema.UsersGetResponse, error) {
+		response := schema.UsersGetResponse{ID: id}
+		err := row.Scan(&response.Name, &response.Email, &response.ImagePath, &response.Role, &response.CreatedAt, &response.UpdatedAt, &response.Version, &response.DeletedAt)
+		return &response, err
+	},
+	returning: "id, name, email, image_path, created_at, updated_at, version",
+	scanWrite: func(row *sql.Row) (*schema.UsersUpdateResponse, error) {
+		var response schema.UsersUpdateResponse
+		err := row.Scan(&response.ID, &response.Name, &response.Email, &response.ImagePath, &response.CreatedAt, &response.UpdatedAt, &response.Version)
+		return &response, err
+	},
+	list: listSpec[schema.UsersGetResponse]{
+		tableSpec: usersTable,
+		scan: func(rows *sql.Rows) (row[schema.UsersGetResponse], error) {
+			var response schema.UsersGetResponse
+			var createdAt, updatedAt time.Time
+			var deletedAt sql.NullTime
+			err := rows.Scan(&response.ID, &response.Name, &response.Email, &response.ImagePath, &createdAt, &updatedAt, &response.Version, &deletedAt)
+			response.CreatedAt = formatTimestamp(createdAt)
+			response.UpdatedAt = formatTimestamp(updatedAt)
+			response.DeletedAt = formatDeletedAt(deletedAt)
+			return row[schema.UsersGetResponse]{
+				item: response,
+				id:   response.ID,
+				fields: map[string]any{
+					"name":       response.Name,
+					"email":      response.Email,
+					"created_at": createdAt,
+					"updated_at": updatedAt,
+				},
+			}, err
+		},
+	},
+	page: func(request *model.ListUsers) (entity.PageRequest, entity.ListQuery) {
+		return request.PageRequest, request.Query()
+	},
+	purge: purgeUsers,
 }
 
-func (p *BDRepository) SelectUser(ctx *gin.Context, request *model.GetUser) (*schema.UsersGetResponse, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	query := "SELECT name, email, image_path, created_at, updated_at FROM users WHERE id = $1"
-	row := p.db.QueryRow(query, request.Id)
-
-	var response schema.UsersGetResponse
-
-	err := row.Scan(&response.Name, &response.Email, &response.ImagePath, &response.CreatedAt, &response.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user with id %s not found", request.Id)
+// purgeUsers borra los usuarios eliminados antes de before y retorna las miniaturas de sus avatares.
+func purgeUsers(ctx context.Context, db querier, before time.Time) (int64, []string, error) {
+	var keys []string
+	purged, err := purgeDeleted(ctx, db, "users", before, "id, image_path", func(rows *sql.Rows) error {
+		var id, imagePath string
+		if err := rows.Scan(&id, &imagePath); err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("error scanning user row: %w", err)
-	}
-
-	return &response, nil
+		keys = append(keys, model.AvatarKeys(id, imagePath)...)
+		return nil
+	})
+	return purged, keys, err
 }
 
-func (p *BDRepository) UpdateUser(ctx *gin.Context, request *model.UpdateUser) (*schema.UsersUpdateResponse, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	now := time.Now().UTC()
+func (p *BDRepository) SelectUserCredentials(ctx context.Context, request *model.GetUserCredentials) (*schema.UserCredentials, error) {
+	ctx, cancel := withTimeout(ctx, p.timeout, "email", request.Email)
+	defer cancel()
 
-	query := "UPDATE users SET name = $1, email = $2, image_path = $3, updated_at = $4 WHERE id = $5"
-	_, err := p.db.Exec(query, request.Name, request.Email, request.ImagePath, now, request.Id)
-	if err != nil {
-		return nil, fmt.Errorf("error executing update: %w", err)
-	}
+	// LOWER(email) usa el índice único users_email_key.
+	query := "SELECT id, role, password_hash FROM users WHERE LOWER(email) = $1 AND deleted_at IS NULL"
+	row := p.db.QueryRowContext(ctx, query, model.NormalizeEmail(request.Email))
 
-	updatedQuery := "SELECT name, email, image_path, updated_at FROM users WHERE id = $1"
-	updatedRow := p.db.QueryRow(updatedQuery, request.Id)
+	var response schema.UserCredentials
+	var passwordHash sql.NullString
 
-	var response schema.UsersUpdateResponse
-	err = updatedRow.Scan(&response.Name, &response.Email, &response.ImagePath, &response.UpdatedAt)
+	err := row.Scan(&response.ID, &response.Role, &passwordHash)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user with id %s not found after update", request.Id)
+			return nil, fmt.Errorf("user with email %s not found: %w", request.Email, modelAuth.ErrInvalidCredentials)
 		}
-		return nil, fmt.Errorf("error scanning updated user row: %w", err)
+		return nil, dbError(err, "error scanning user credentials")
 	}
-
-	return &response, nil
-}
-
-func (p *BDRepository) DeleteUser(ctx *gin.Context, request *model.DeleteUser) error {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	query := "DELETE FROM users WHERE id = $1"
-	result, err := p.db.Exec(query, request.Id)
-	if err != nil {
-		return err
+	if !passwordHash.Valid {
+		return nil, fmt.Errorf("user with email %s has no password: %w", request.Email, modelAuth.ErrInvalidCredentials)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+	response.PasswordHash = passwordHash.String
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("no user found with id %s", request.Id)
-	}
+	return &response, nil
+}
 
-	return nil
+var usersTable = tableSpec{
+	name:    "users",
+	columns: "id, name, email, image_path, created_at, updated_at, version, deleted_at",
+	fields: map[string]string{
+		"id":         "id",
+		"name":       "name",
+		"email":      "email",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	softDelete: true,
 }
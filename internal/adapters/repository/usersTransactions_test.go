@@ -1,14 +1,18 @@
 package repository
 
 import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"CrudPlatform/internal/core/domain/repository/model/auth"
 	"CrudPlatform/internal/core/domain/repository/model/users"
+	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,21 +23,22 @@ func TestBDRepository(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	repo := &BDRepository{db: db}
-	ctx := &gin.Context{}
+	repo := NewBdRepository(db, 0)
+	ctx := context.Background()
 
 	t.Run("CreateUser", func(t *testing.T) {
 		user := &users.User{
 			Name:      "John Doe",
 			Email:     "john@example.com",
 			ImagePath: "/path/to/image.jpg",
+			Role:      "creator",
 		}
 
 		mock.ExpectExec("INSERT INTO users").
-			WithArgs(sqlmock.AnyArg(), user.Name, user.Email, user.ImagePath, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), user.Name, user.Email, user.ImagePath, user.Role, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		id, err := repo.CreateUser(ctx, user)
+		id, err := repo.Create(ctx, user)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, id)
 	})
@@ -43,196 +48,285 @@ func TestBDRepository(t *testing.T) {
 			Name:      "John Doe",
 			Email:     "john@example.com",
 			ImagePath: "/path/to/image.jpg",
+			Role:      "creator",
 		}
 
 		mock.ExpectExec("INSERT INTO users").
-			WithArgs(sqlmock.AnyArg(), user.Name, user.Email, user.ImagePath, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), user.Name, user.Email, user.ImagePath, user.Role, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnError(fmt.Errorf("exec error"))
 
-		id, err := repo.CreateUser(ctx, user)
+		id, err := repo.Create(ctx, user)
 		assert.Error(t, err)
 		assert.Empty(t, id)
 		assert.Contains(t, err.Error(), "error executing statement: exec error")
 	})
 
 	t.Run("SelectUser", func(t *testing.T) {
-		request := &users.GetUser{Id: "123"}
-		rows := sqlmock.NewRows([]string{"name", "email", "image_path", "created_at", "updated_at"}).
-			AddRow("John Doe", "john@example.com", "/path/to/image.jpg", time.Now(), time.Now())
+		request := &entity.GetRecord{ID: "123"}
+		rows := sqlmock.NewRows([]string{"name", "email", "image_path", "role", "created_at", "updated_at", "version", "deleted_at"}).
+			AddRow("John Doe", "john@example.com", "/path/to/image.jpg", "creator", time.Now(), time.Now(), 1, nil)
 
 		mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
-			WithArgs(request.Id).
+			WithArgs(request.ID).
 			WillReturnRows(rows)
 
-		user, err := repo.SelectUser(ctx, request)
+		user, err := repo.Select(ctx, request)
 		assert.NoError(t, err)
 		assert.NotNil(t, user)
 		assert.Equal(t, "John Doe", user.Name)
+		assert.Equal(t, "creator", user.Role)
 	})
 
 	t.Run("SelectUser_NotFound", func(t *testing.T) {
-		request := &users.GetUser{Id: "999"}
+		request := &entity.GetRecord{ID: "999"}
 
 		mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
-			WithArgs(request.Id).
+			WithArgs(request.ID).
 			WillReturnError(sql.ErrNoRows)
 
-		user, err := repo.SelectUser(ctx, request)
+		user, err := repo.Select(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, user)
 		assert.Contains(t, err.Error(), "user with id 999 not found")
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
 	})
+	t.Run("SelectUser_IncludeDeleted", func(t *testing.T) {
+		request := &entity.GetRecord{ID: "123", IncludeDeleted: true}
+		deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		rows := sqlmock.NewRows([]string{"name", "email", "image_path", "role", "created_at", "updated_at", "version", "deleted_at"}).
+			AddRow("John Doe", "john@example.com", "", "viewer", time.Now(), time.Now(), 2, deletedAt)
+
+		mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE id = $1") + "$").
+			WithArgs(request.ID).
+			WillReturnRows(rows)
+
+		user, err := repo.Select(ctx, request)
+		require.NoError(t, err)
+		require.NotNil(t, user.DeletedAt)
+		assert.Equal(t, "2024-01-02T03:04:05Z", *user.DeletedAt)
+	})
+
+	t.Run("RestoreUser_EmailTaken", func(t *testing.T) {
+		request := &entity.RestoreRecord{ID: "123"}
+
+		mock.ExpectQuery("UPDATE users SET deleted_at = NULL").
+			WithArgs(sqlmock.AnyArg(), request.ID).
+			WillReturnError(&pq.Error{Code: "23505"})
+
+		user, err := repo.Restore(ctx, request)
+		assert.Nil(t, user)
+		assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+	})
+
 	t.Run("SelectUser_ScanError", func(t *testing.T) {
-		request := &users.GetUser{Id: "123"}
+		request := &entity.GetRecord{ID: "123"}
 
 		// Agregamos una columna extra para provocar un error de escaneo
-		rows := sqlmock.NewRows([]string{"name", "email", "image_path", "created_at", "updated_at", "extra_column"}).
-			AddRow("John Doe", "john@example.com", "/path/to/image.jpg", time.Now(), time.Now(), "extra_data")
+		rows := sqlmock.NewRows([]string{"name", "email", "image_path", "role", "created_at", "updated_at", "version", "deleted_at", "extra_column"}).
+			AddRow("John Doe", "john@example.com", "/path/to/image.jpg", "viewer", time.Now(), time.Now(), 1, nil, "extra_data")
 
 		mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
-			WithArgs(request.Id).
+			WithArgs(request.ID).
 			WillReturnRows(rows)
 
-		user, err := repo.SelectUser(ctx, request)
+		user, err := repo.Select(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, user)
 		assert.Contains(t, err.Error(), "error scanning user row")
 	})
 
 	t.Run("UpdateUser", func(t *testing.T) {
-		request := &users.UpdateUser{
-			Id:        "123",
+		request := users.ReplaceUser{
 			Name:      "Jane Doe",
 			Email:     "jane@example.com",
 			ImagePath: "/new/path/to/image.jpg",
-		}
+		}.Update("123")
+		createdAt := time.Now().Add(-time.Hour)
 
-		mock.ExpectExec("UPDATE users SET").
-			WithArgs(request.Name, request.Email, request.ImagePath, sqlmock.AnyArg(), request.Id).
-			WillReturnResult(sqlmock.NewResult(0, 1))
-
-		rows := sqlmock.NewRows([]string{"name", "email", "image_path", "updated_at"}).
-			AddRow(request.Name, request.Email, request.ImagePath, time.Now())
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "image_path", "created_at", "updated_at", "version"}).
+			AddRow(request.Id, *request.Name, *request.Email, *request.ImagePath, createdAt, time.Now(), 1)
 
-		mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
-			WithArgs(request.Id).
+		mock.ExpectQuery("UPDATE users SET (.+) RETURNING").
+			WithArgs(*request.Name, *request.Email, *request.ImagePath, sqlmock.AnyArg(), request.Id).
 			WillReturnRows(rows)
 
-		user, err := repo.UpdateUser(ctx, request)
+		user, err := repo.Update(ctx, request)
 		assert.NoError(t, err)
 		assert.NotNil(t, user)
-		assert.Equal(t, request.Name, user.Name)
+		assert.Equal(t, request.Id, user.ID)
+		assert.Equal(t, *request.Name, user.Name)
+		assert.NotEmpty(t, user.CreatedAt)
 	})
 
 	t.Run("UpdateUser_ExecError", func(t *testing.T) {
-		request := &users.UpdateUser{
-			Id:        "123",
+		request := users.ReplaceUser{
 			Name:      "Jane Doe",
 			Email:     "jane@example.com",
 			ImagePath: "/new/path/to/image.jpg",
-		}
+		}.Update("123")
 
-		mock.ExpectExec("UPDATE users SET").
-			WithArgs(request.Name, request.Email, request.ImagePath, sqlmock.AnyArg(), request.Id).
+		mock.ExpectQuery("UPDATE users SET (.+) RETURNING").
+			WithArgs(*request.Name, *request.Email, *request.ImagePath, sqlmock.AnyArg(), request.Id).
 			WillReturnError(fmt.Errorf("exec error"))
 
-		user, err := repo.UpdateUser(ctx, request)
+		user, err := repo.Update(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, user)
 		assert.Contains(t, err.Error(), "error executing update")
 	})
 
 	t.Run("UpdateUser_NotFound", func(t *testing.T) {
-		request := &users.UpdateUser{
-			Id:        "123",
+		request := users.ReplaceUser{
 			Name:      "Jane Doe",
 			Email:     "jane@example.com",
 			ImagePath: "/new/path/to/image.jpg",
-		}
-
-		mock.ExpectExec("UPDATE users SET").
-			WithArgs(request.Name, request.Email, request.ImagePath, sqlmock.AnyArg(), request.Id).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		}.Update("123")
 
-		mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
-			WithArgs(request.Id).
+		mock.ExpectQuery("UPDATE users SET (.+) RETURNING").
+			WithArgs(*request.Name, *request.Email, *request.ImagePath, sqlmock.AnyArg(), request.Id).
 			WillReturnError(sql.ErrNoRows)
 
-		user, err := repo.UpdateUser(ctx, request)
+		user, err := repo.Update(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, user)
-		assert.Contains(t, err.Error(), "user with id 123 not found after update")
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+		assert.Contains(t, err.Error(), "user with id 123 not found")
 	})
 
 	t.Run("UpdateUser_ScanError", func(t *testing.T) {
-		request := &users.UpdateUser{
-			Id:        "123",
+		request := users.ReplaceUser{
 			Name:      "Jane Doe",
 			Email:     "jane@example.com",
 			ImagePath: "/new/path/to/image.jpg",
-		}
+		}.Update("123")
+		createdAt := time.Now().Add(-time.Hour)
 
-		mock.ExpectExec("UPDATE users SET").
-			WithArgs(request.Name, request.Email, request.ImagePath, sqlmock.AnyArg(), request.Id).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "image_path", "created_at", "updated_at", "version", "extra_column"}).
+			AddRow(request.Id, *request.Name, *request.Email, *request.ImagePath, createdAt, time.Now(), 1, "extra_data")
 
-		rows := sqlmock.NewRows([]string{"name", "email", "image_path", "updated_at", "extra_column"}).
-			AddRow(request.Name, request.Email, request.ImagePath, time.Now(), "extra_data")
-
-		mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
-			WithArgs(request.Id).
+		mock.ExpectQuery("UPDATE users SET (.+) RETURNING").
+			WithArgs(*request.Name, *request.Email, *request.ImagePath, sqlmock.AnyArg(), request.Id).
 			WillReturnRows(rows)
 
-		user, err := repo.UpdateUser(ctx, request)
+		user, err := repo.Update(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, user)
-		assert.Contains(t, err.Error(), "error scanning updated user row")
+		assert.Contains(t, err.Error(), "error executing update")
+	})
+
+	t.Run("UpdateUser_Partial", func(t *testing.T) {
+		email := "jane.doe@example.com"
+		request := &users.UpdateUser{Id: "123", Email: &email}
+
+		rows := sqlmock.NewRows([]string{"id", "name", "email", "image_path", "created_at", "updated_at", "version"}).
+			AddRow(request.Id, "Jane Doe", email, "https://cdn.example.com/jane.png", time.Now().Add(-time.Hour), time.Now(), 1)
+
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE users SET email = $1, updated_at = $2, version = version + 1 WHERE id = $3 AND deleted_at IS NULL RETURNING")).
+			WithArgs(email, sqlmock.AnyArg(), request.Id).
+			WillReturnRows(rows)
+
+		user, err := repo.Update(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, "Jane Doe", user.Name)
+		assert.Equal(t, email, user.Email)
+		assert.Equal(t, "https://cdn.example.com/jane.png", user.ImagePath)
 	})
 
 	t.Run("DeleteUser", func(t *testing.T) {
-		request := &users.DeleteUser{Id: "123"}
+		request := &entity.DeleteRecord{ID: "123"}
 
-		mock.ExpectExec("DELETE FROM users WHERE id = \\$1").
-			WithArgs(request.Id).
+		mock.ExpectExec("UPDATE users SET deleted_at = \\$1, version = version \\+ 1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		err := repo.DeleteUser(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.NoError(t, err)
 	})
 
 	t.Run("DeleteUser_ExecError", func(t *testing.T) {
-		request := &users.DeleteUser{Id: "123"}
+		request := &entity.DeleteRecord{ID: "123"}
 
-		mock.ExpectExec("DELETE FROM users WHERE id = \\$1").
-			WithArgs(request.Id).
+		mock.ExpectExec("UPDATE users SET deleted_at = \\$1, version = version \\+ 1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnError(fmt.Errorf("exec error"))
 
-		err := repo.DeleteUser(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "exec error")
 	})
 
 	t.Run("DeleteUser_NoRowsAffected", func(t *testing.T) {
-		request := &users.DeleteUser{Id: "123"}
+		request := &entity.DeleteRecord{ID: "123"}
 
-		mock.ExpectExec("DELETE FROM users WHERE id = \\$1").
-			WithArgs(request.Id).
+		mock.ExpectExec("UPDATE users SET deleted_at = \\$1, version = version \\+ 1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
-		err := repo.DeleteUser(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "no user found with id 123")
+		assert.Contains(t, err.Error(), "user with id 123 not found")
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
 	})
 
 	t.Run("DeleteUser_RowsAffectedError", func(t *testing.T) {
-		request := &users.DeleteUser{Id: "123"}
+		request := &entity.DeleteRecord{ID: "123"}
 
-		mock.ExpectExec("DELETE FROM users WHERE id = \\$1").
-			WithArgs(request.Id).
+		mock.ExpectExec("UPDATE users SET deleted_at = \\$1, version = version \\+ 1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnResult(sqlmock.NewErrorResult(fmt.Errorf("rows affected error")))
 
-		err := repo.DeleteUser(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "rows affected error")
 	})
+
+	t.Run("SelectUserCredentials", func(t *testing.T) {
+		request := &users.GetUserCredentials{Email: "John@Example.com"}
+		rows := sqlmock.NewRows([]string{"id", "role", "password_hash"}).AddRow("123", "admin", "$2a$10$hash")
+
+		mock.ExpectQuery("SELECT id, role, password_hash FROM users WHERE LOWER\\(email\\) = \\$1").
+			WithArgs("john@example.com").
+			WillReturnRows(rows)
+
+		credentials, err := repo.SelectUserCredentials(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, "123", credentials.ID)
+		assert.Equal(t, "$2a$10$hash", credentials.PasswordHash)
+		assert.Equal(t, "admin", credentials.Role)
+	})
+
+	t.Run("SelectUserCredentials_NotFound", func(t *testing.T) {
+		request := &users.GetUserCredentials{Email: "missing@example.com"}
+
+		mock.ExpectQuery("SELECT id, role, password_hash FROM users").
+			WithArgs(request.Email).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.SelectUserCredentials(ctx, request)
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("SelectUserCredentials_WithoutPassword", func(t *testing.T) {
+		request := &users.GetUserCredentials{Email: "john@example.com"}
+		rows := sqlmock.NewRows([]string{"id", "role", "password_hash"}).AddRow("123", "viewer", nil)
+
+		mock.ExpectQuery("SELECT id, role, password_hash FROM users").
+			WithArgs(request.Email).
+			WillReturnRows(rows)
+
+		_, err := repo.SelectUserCredentials(ctx, request)
+		assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("SelectUserCredentials_QueryError", func(t *testing.T) {
+		request := &users.GetUserCredentials{Email: "john@example.com"}
+
+		mock.ExpectQuery("SELECT id, role, password_hash FROM users").
+			WithArgs(request.Email).
+			WillReturnError(fmt.Errorf("query error"))
+
+		_, err := repo.SelectUserCredentials(ctx, request)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, auth.ErrInvalidCredentials)
+	})
 }
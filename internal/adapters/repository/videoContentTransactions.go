@@ -0,0 +1,250 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/videos"
+	schema "CrudPlatform/internal/core/domain/repository/schema/videos"
+	"context"
+	"database/sql"
+	"time"
+)
+
+func (p *BDRepositoryVideo) UpdateVideoContent(ctx context.Context, request *model.UpdateVideoContent) (*schema.VideosUpdateResponse, error) {
+	ctx, cancel := withTimeout(ctx, p.timeout, "video_id", request.ID)
+	defer cancel()
+
+	duration := sql.NullFloat64{}
+	if request.Duration != nil {
+		duration = sql.NullFloat64{Float64: *request.Duration, Valid: true}
+	}
+
+	b := &queryBuilder{}
+	b.set("content_key", request.Key)
+	b.set("content_size", request.Size)
+	b.set("content_type", request.ContentType)
+	b.set("content_checksum", request.Checksum)
+	b.set("content_duration", duration)
+	if request.Previous == "" {
+		b.where("content_key IS NULL")
+	} else {
+		b.where("content_key = " + b.bind(request.Previous))
+	}
+
+	query := b.update("videos", request.ID, request.Version, time.Now().UTC(), videoUpdateColumns)
+	response, err := scanVideoUpdate(conn(ctx, p.db).QueryRowContext(ctx, query, b.args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, p.contentNotStored(ctx, request)
+		}
+		return nil, dbError(err, "error executing update")
+	}
+
+	return response, nil
+}
+
+// contentNotStored explica por qué UpdateVideoContent no afectó filas: el video no existe, cambió
+// desde la versión esperada (412) o ya no tiene el archivo que se iba a reemplazar (409).
+func (p *BDRepositoryVideo) contentNotStored(ctx context.Context, request *model.UpdateVideoContent) error {
+	var version int64
+	err := conn(ctx, p.db).QueryRowContext(ctx, "SELECT version FROM videos WHERE id = $1 AND deleted_at IS NULL", request.ID).Scan(&version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.NewError(entity.KindNotFound, "video with id %s not found", request.ID)
+		}
+		return dbError(err, "error checking record version")
+	}
+	if request.Version != nil && *request.Version != version {
+		return entity.NewError(entity.KindPreconditionFailed, "record %s was modified since version %d", request.ID, *request.Version)
+	}
+	return entity.NewError(entity.KindConflict, "content of video %s was replaced by another upload", request.ID)
+}
+
+func (p *BDRepositoryVideo) CreateVideoUpload(ctx context.Context, upload *model.VideoUpload) error {
+	ctx, cancel := withTimeout(ctx, p.timeout, "video_id", upload.VideoID, "upload_id", upload.ID)
+	defer cancel()
+
+	query := `
+		INSERT INTO video_uploads (id, video_id, upload_length, upload_offset, content_type, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := p.db.ExecContext(ctx, query, upload.ID, upload.VideoID, upload.Length, upload.Offset, upload.ContentType, nullString(upload.CreatedBy), upload.CreatedAt)
+	if err != nil {
+		return dbError(err, "error executing statement")
+	}
+
+	return nil
+}
+
+// SelectVideoUpload carga el upload junto con sus partes ordenadas por posición.
+func (p *BDRepositoryVideo) SelectVideoUpload(ctx context.Context, request *model.GetUpload) (*model.VideoUpload, error) {
+	ctx, cancel := withTimeout(ctx, p.timeout, "video_id", request.VideoID, "upload_id", request.ID)
+	defer cancel()
+
+	upload := model.VideoUpload{ID: request.ID, VideoID: request.VideoID}
+	var createdBy sql.NullString
+	err := p.db.QueryRowContext(ctx,
+		"SELECT upload_length, upload_offset, content_type, created_by, created_at FROM video_uploads WHERE id = $1 AND video_id = $2",
+		request.ID, request.VideoID,
+	).Scan(&upload.Length, &upload.Offset, &upload.ContentType, &createdBy, &upload.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entity.NewError(entity.KindNotFound, "upload with id %s not found", request.ID)
+		}
+		return nil, dbError(err, "error scanning upload row")
+	}
+	upload.CreatedBy = createdBy.String
+
+	rows, err := p.db.QueryContext(ctx, "SELECT part_offset, size, blob_key FROM video_upload_parts WHERE upload_id = $1 ORDER BY part_offset", request.ID)
+	if err != nil {
+		return nil, dbError(err, "error listing upload parts")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		part := model.UploadPart{UploadID: request.ID}
+		if err := rows.Scan(&part.Offset, &part.Size, &part.Key); err != nil {
+			return nil, dbError(err, "error scanning upload part row")
+		}
+		upload.Parts = append(upload.Parts, part)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dbError(err, "error listing upload parts")
+	}
+
+	return &upload, nil
+}
+
+// AppendVideoUploadPart registra una parte y avanza el offset del upload en la misma transacción.
+// Si otra parte ya avanzó el upload, o la parte supera su largo, no se registra y es KindConflict.
+func (p *BDRepositoryVideo) AppendVideoUploadPart(ctx context.Context, part *model.UploadPart) (int64, error) {
+	ctx, cancel := withTimeout(ctx, p.timeout, "upload_id", part.UploadID)
+	defer cancel()
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, dbError(err, "error starting transaction")
+	}
+	defer tx.Rollback()
+
+	offset := part.Offset + part.Size
+	result, err := tx.ExecContext(ctx,
+		"UPDATE video_uploads SET upload_offset = $1 WHERE id = $2 AND upload_offset = $3 AND upload_length >= $1",
+		offset, part.UploadID, part.Offset)
+	if err != nil {
+		return 0, dbError(err, "error executing update")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, dbError(err, "error reading affected rows")
+	}
+	if rowsAffected == 0 {
+		return 0, entity.NewError(entity.KindConflict, "upload %s is not at offset %d or the part exceeds its length", part.UploadID, part.Offset)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO video_upload_parts (upload_id, part_offset, blob_key, size) VALUES ($1, $2, $3, $4)",
+		part.UploadID, part.Offset, part.Key, part.Size)
+	if err != nil {
+		return 0, dbError(err, "error executing statement")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, dbError(err, "error committing transaction")
+	}
+
+	return offset, nil
+}
+
+// DeleteVideoUpload borra el upload y, en cascada, el registro de sus partes.
+func (p *BDRepositoryVideo) DeleteVideoUpload(ctx context.Context, request *model.GetUpload) error {
+	ctx, cancel := withTimeout(ctx, p.timeout, "video_id", request.VideoID, "upload_id", request.ID)
+	defer cancel()
+
+	result, err := p.db.ExecContext(ctx, "DELETE FROM video_uploads WHERE id = $1 AND video_id = $2", request.ID, request.VideoID)
+	if err != nil {
+		return dbError(err, "error executing delete")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dbError(err, "error reading affected rows")
+	}
+	if rowsAffected == 0 {
+		return entity.NewError(entity.KindNotFound, "upload with id %s not found", request.ID)
+	}
+
+	return nil
+}
+
+// ExpireVideoUploads borra los uploads creados antes de before, que ya no se completarán, y
+// retorna las llaves de sus partes. Las partes se borran en cascada, así que se leen antes; solo
+// se retornan las de los uploads que el DELETE efectivamente borró.
+func (p *BDRepositoryVideo) ExpireVideoUploads(ctx context.Context, before time.Time) (int64, []string, error) {
+	ctx, cancel := withTimeout(ctx, p.timeout)
+	defer cancel()
+
+	db := conn(ctx, p.db)
+	parts, err := uploadPartKeys(ctx, db, "u.id", "WHERE u.created_at < $1", before)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var keys []string
+	expired, err := deleteBefore(ctx, db, "video_uploads", "created_at", before, "id", func(rows *sql.Rows) error {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		keys = append(keys, parts[id]...)
+		return nil
+	})
+	return expired, keys, err
+}
+
+// purgeVideos borra los videos eliminados antes de before y retorna sus archivos y las partes de
+// sus uploads, que se leen antes porque se borran en cascada con el video.
+func purgeVideos(ctx context.Context, db querier, before time.Time) (int64, []string, error) {
+	parts, err := uploadPartKeys(ctx, db, "u.video_id", "JOIN videos v ON v.id = u.video_id WHERE v.deleted_at < $1", before)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var keys []string
+	purged, err := purgeDeleted(ctx, db, "videos", before, "id, content_key", func(rows *sql.Rows) error {
+		var id string
+		var key sql.NullString
+		if err := rows.Scan(&id, &key); err != nil {
+			return err
+		}
+		if key.Valid {
+			keys = append(keys, key.String)
+		}
+		keys = append(keys, parts[id]...)
+		return nil
+	})
+	return purged, keys, err
+}
+
+// uploadPartKeys lee las llaves de las partes de los uploads u que cumplen where, agrupadas por la
+// columna group, como u.id o u.video_id.
+func uploadPartKeys(ctx context.Context, db querier, group, where string, before time.Time) (map[string][]string, error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT "+group+", p.blob_key FROM video_upload_parts p JOIN video_uploads u ON u.id = p.upload_id "+where, before)
+	if err != nil {
+		return nil, dbError(err, "error listing upload parts")
+	}
+	defer rows.Close()
+
+	keys := map[string][]string{}
+	for rows.Next() {
+		var id, key string
+		if err := rows.Scan(&id, &key); err != nil {
+			return nil, dbError(err, "error scanning upload part row")
+		}
+		keys[id] = append(keys[id], key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dbError(err, "error listing upload parts")
+	}
+	return keys, nil
+}
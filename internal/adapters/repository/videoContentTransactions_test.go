@@ -0,0 +1,168 @@
+package repository
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/videos"
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBDRepositoryVideoContent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBdRepositoryVideo(db, 0)
+	ctx := context.Background()
+	updateColumns := []string{"id", "title", "description", "challenge_id", "created_at", "updated_at", "version", "content_key", "content_size", "content_type", "content_checksum", "content_duration"}
+
+	t.Run("UpdateVideoContent", func(t *testing.T) {
+		duration := 12.5
+		request := &model.UpdateVideoContent{ID: "123", Key: "videos/123/a", Size: 42, ContentType: "video/mp4", Checksum: "abc", Duration: &duration}
+
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE videos SET content_key = $1, content_size = $2, content_type = $3, content_checksum = $4, content_duration = $5, updated_at = $6, version = version + 1 WHERE content_key IS NULL AND id = $7 AND deleted_at IS NULL RETURNING")).
+			WithArgs("videos/123/a", int64(42), "video/mp4", "abc", sql.NullFloat64{Float64: 12.5, Valid: true}, sqlmock.AnyArg(), "123").
+			WillReturnRows(sqlmock.NewRows(updateColumns).
+				AddRow("123", "Test Video", "Description", nil, time.Now(), time.Now(), 2, "videos/123/a", 42, "video/mp4", "abc", 12.5))
+
+		video, err := repo.UpdateVideoContent(ctx, request)
+		require.NoError(t, err)
+		require.NotNil(t, video.Content)
+		assert.Equal(t, int64(42), video.Content.Size)
+		assert.Equal(t, "videos/123/a", video.Content.Key)
+		assert.Equal(t, 12.5, *video.Content.Duration)
+		assert.Equal(t, int64(2), video.Version)
+	})
+
+	t.Run("UpdateVideoContent_NotFound", func(t *testing.T) {
+		request := &model.UpdateVideoContent{ID: "999", Key: "videos/999/a", Size: 1, ContentType: "video/mp4", Checksum: "abc"}
+
+		mock.ExpectQuery("UPDATE videos SET (.+) RETURNING").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM videos WHERE id = $1 AND deleted_at IS NULL")).
+			WithArgs("999").
+			WillReturnError(sql.ErrNoRows)
+
+		video, err := repo.UpdateVideoContent(ctx, request)
+		assert.Nil(t, video)
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	})
+
+	t.Run("UpdateVideoContent_Replaced", func(t *testing.T) {
+		request := &model.UpdateVideoContent{ID: "123", Key: "videos/123/b", Size: 1, ContentType: "video/mp4", Checksum: "abc", Previous: "videos/123/a"}
+
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE content_key = $6 AND id = $8 AND deleted_at IS NULL RETURNING")).
+			WithArgs("videos/123/b", int64(1), "video/mp4", "abc", sql.NullFloat64{}, "videos/123/a", sqlmock.AnyArg(), "123").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM videos WHERE id = $1 AND deleted_at IS NULL")).
+			WithArgs("123").
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+
+		video, err := repo.UpdateVideoContent(ctx, request)
+		assert.Nil(t, video)
+		assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+	})
+
+	t.Run("CreateVideoUpload", func(t *testing.T) {
+		upload := &model.VideoUpload{ID: "up-1", VideoID: "123", Length: 100, ContentType: "video/mp4", CreatedBy: "user-1", CreatedAt: time.Now()}
+
+		mock.ExpectExec("INSERT INTO video_uploads").
+			WithArgs("up-1", "123", int64(100), int64(0), "video/mp4", sql.NullString{String: "user-1", Valid: true}, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		assert.NoError(t, repo.CreateVideoUpload(ctx, upload))
+	})
+
+	t.Run("CreateVideoUpload_MissingVideo", func(t *testing.T) {
+		upload := &model.VideoUpload{ID: "up-1", VideoID: "999", Length: 100, ContentType: "video/mp4"}
+
+		mock.ExpectExec("INSERT INTO video_uploads").
+			WillReturnError(fmt.Errorf("exec error"))
+
+		err := repo.CreateVideoUpload(ctx, upload)
+		assert.Contains(t, err.Error(), "error executing statement")
+	})
+
+	t.Run("SelectVideoUpload", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM video_uploads WHERE id = \\$1 AND video_id = \\$2").
+			WithArgs("up-1", "123").
+			WillReturnRows(sqlmock.NewRows([]string{"upload_length", "upload_offset", "content_type", "created_by", "created_at"}).
+				AddRow(100, 60, "video/mp4", "user-1", time.Now()))
+		mock.ExpectQuery("SELECT (.+) FROM video_upload_parts WHERE upload_id = \\$1 ORDER BY part_offset").
+			WithArgs("up-1").
+			WillReturnRows(sqlmock.NewRows([]string{"part_offset", "size", "blob_key"}).
+				AddRow(0, 40, "uploads/up-1/a").
+				AddRow(40, 20, "uploads/up-1/b"))
+
+		upload, err := repo.SelectVideoUpload(ctx, &model.GetUpload{VideoID: "123", ID: "up-1"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(60), upload.Offset)
+		assert.Equal(t, "user-1", upload.CreatedBy)
+		require.Len(t, upload.Parts, 2)
+		assert.Equal(t, model.UploadPart{UploadID: "up-1", Offset: 40, Size: 20, Key: "uploads/up-1/b"}, upload.Parts[1])
+	})
+
+	t.Run("SelectVideoUpload_NotFound", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM video_uploads").
+			WillReturnError(sql.ErrNoRows)
+
+		upload, err := repo.SelectVideoUpload(ctx, &model.GetUpload{VideoID: "123", ID: "missing"})
+		assert.Nil(t, upload)
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	})
+
+	t.Run("AppendVideoUploadPart", func(t *testing.T) {
+		part := &model.UploadPart{UploadID: "up-1", Offset: 40, Size: 20, Key: "uploads/up-1/b"}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE video_uploads SET upload_offset = $1 WHERE id = $2 AND upload_offset = $3 AND upload_length >= $1")).
+			WithArgs(int64(60), "up-1", int64(40)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO video_upload_parts").
+			WithArgs("up-1", int64(40), "uploads/up-1/b", int64(20)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		offset, err := repo.AppendVideoUploadPart(ctx, part)
+		require.NoError(t, err)
+		assert.Equal(t, int64(60), offset)
+	})
+
+	t.Run("AppendVideoUploadPart_Conflict", func(t *testing.T) {
+		part := &model.UploadPart{UploadID: "up-1", Offset: 40, Size: 20, Key: "uploads/up-1/c"}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE video_uploads SET upload_offset").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := repo.AppendVideoUploadPart(ctx, part)
+		assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+	})
+
+	t.Run("DeleteVideoUpload", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM video_uploads WHERE id = \\$1 AND video_id = \\$2").
+			WithArgs("up-1", "123").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.DeleteVideoUpload(ctx, &model.GetUpload{VideoID: "123", ID: "up-1"}))
+	})
+
+	t.Run("DeleteVideoUpload_NotFound", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM video_uploads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.DeleteVideoUpload(ctx, &model.GetUpload{VideoID: "123", ID: "missing"})
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,100 +1,137 @@
 package repository
 
 import (
+	entity "CrudPlatform/internal/core/domain/repository"
 	model "CrudPlatform/internal/core/domain/repository/model/videos"
 	schema "CrudPlatform/internal/core/domain/repository/schema/videos"
 	"database/sql"
-	"fmt"
 	"time"
-
-	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
-func (p *BDRepositoryVideo) CreateVideo(ctx *gin.Context, request *model.Videos) (string, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	id := uuid.NewString()
-	now := time.Now().UTC()
-
-	query := `
-		INSERT INTO videos (id, title, description, created_at, updated_at) 
-		VALUES ($1, $2, $3, $4, $5)
-	`
-	_, err := p.db.Exec(query, id, request.Title, request.Description, now, now)
-	if err != nil {
-		return "", fmt.Errorf("error executing statement: %w", err)
-	}
-
-	return id, nil
+var videosTable = tableSpec{
+	name:    "videos",
+	columns: "id, title, description, user_id, challenge_id, created_at, updated_at, version, deleted_at, " + videoContentColumns,
+	fields: map[string]string{
+		"id":         "id",
+		"title":      "title",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+	filters: map[string]string{
+		"user_id":      "user_id",
+		"challenge_id": "challenge_id",
+	},
+	search:        "search_vector",
+	searchColumns: []string{"title", "description"},
+	softDelete:    true,
 }
 
-func (p *BDRepositoryVideo) SelectVideo(ctx *gin.Context, request *model.GetVideo) (*schema.VideosGetResponse, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	query := "SELECT title, description, created_at, updated_at FROM videos WHERE id = $1"
-	row := p.db.QueryRow(query, request.ID)
-
-	var response schema.VideosGetResponse
-
-	err := row.Scan(&response.Title, &response.Description, &response.CreatedAt, &response.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("video with id %s not found", request.ID)
+var videosSpec = &crudSpec[model.Videos, model.UpdateVideo, model.ListVideos, schema.VideosGetResponse, schema.VideosUpdateResponse]{
+	table: "videos",
+	noun:  "video",
+	insert: func(request *model.Videos) ([]string, []any) {
+		return []string{"title", "description", "user_id", "challenge_id", "created_by"},
+			[]any{request.Title, request.Description, nullString(request.UserID), nullString(request.ChallengeID), request.CreatedBy}
+	},
+	assign: func(b *queryBuilder, request *model.UpdateVideo) (string, *int64) {
+		if request.Title != nil {
+			b.set("title", *request.Title)
 		}
-		return nil, fmt.Errorf("error scanning video row: %w", err)
-	}
-
-	return &response, nil
+		if request.Description != nil {
+			b.set("description", *request.Description)
+		}
+		if request.ChallengeID != nil {
+			b.set("challenge_id", nullString(*request.ChallengeID))
+		}
+		return request.ID, request.Version
+	},
+	columns: "title, description, user_id, challenge_id, created_by, created_at, updated_at, version, deleted_at, " + videoContentColumns,
+	scan: func(row *sql.Row, id string) (*schema.VideosGetResponse, error) {
+		response := schema.VideosGetResponse{ID: id}
+		var userID, challengeID, createdBy sql.NullString
+		var content contentColumns
+		err := row.Scan(append([]any{&response.Title, &response.Description, &userID, &challengeID, &createdBy, &response.CreatedAt, &response.UpdatedAt, &response.Version, &response.DeletedAt}, content.targets()...)...)
+		response.UserID = userID.String
+		response.ChallengeID = challengeID.String
+		response.CreatedBy = createdBy.String
+		response.Content = content.content()
+		return &response, err
+	},
+	returning: videoUpdateColumns,
+	scanWrite: scanVideoUpdate,
+	list: listSpec[schema.VideosGetResponse]{
+		tableSpec: videosTable,
+		scan: func(rows *sql.Rows) (row[schema.VideosGetResponse], error) {
+			var response schema.VideosGetResponse
+			var userID, challengeID sql.NullString
+			var createdAt, updatedAt time.Time
+			var deletedAt sql.NullTime
+			var content contentColumns
+			err := rows.Scan(append([]any{&response.ID, &response.Title, &response.Description, &userID, &challengeID, &createdAt, &updatedAt, &response.Version, &deletedAt}, content.targets()...)...)
+			response.UserID = userID.String
+			response.ChallengeID = challengeID.String
+			response.CreatedAt = formatTimestamp(createdAt)
+			response.UpdatedAt = formatTimestamp(updatedAt)
+			response.DeletedAt = formatDeletedAt(deletedAt)
+			response.Content = content.content()
+			return row[schema.VideosGetResponse]{
+				item: response,
+				id:   response.ID,
+				fields: map[string]any{
+					"title":      response.Title,
+					"created_at": createdAt,
+					"updated_at": updatedAt,
+				},
+			}, err
+		},
+	},
+	page: func(request *model.ListVideos) (entity.PageRequest, entity.ListQuery) {
+		return request.PageRequest, request.Query()
+	},
+	purge: purgeVideos,
 }
 
-func (p *BDRepositoryVideo) UpdateVideo(ctx *gin.Context, request *model.UpdateVideo) (*schema.VideosUpdateResponse, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	now := time.Now().UTC()
+// videoContentColumns son los metadatos del archivo del video, en el orden de contentColumns.targets.
+const videoContentColumns = "content_key, content_size, content_type, content_checksum, content_duration"
 
-	query := "UPDATE videos SET title = $1, description = $2, updated_at = $3 WHERE id = $4"
-	_, err := p.db.Exec(query, request.Title, request.Description, now, request.ID)
-	if err != nil {
-		return nil, fmt.Errorf("error executing update: %w", err)
-	}
-
-	updatedQuery := "SELECT title, description, updated_at FROM videos WHERE id = $1"
-	updatedRow := p.db.QueryRow(updatedQuery, request.ID)
+// videoUpdateColumns son las columnas que retornan las escrituras sobre un video.
+const videoUpdateColumns = "id, title, description, challenge_id, created_at, updated_at, version, " + videoContentColumns
 
-	var response schema.VideosUpdateResponse
-	err = updatedRow.Scan(&response.Title, &response.Description, &response.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("video with id %s not found after update", request.ID)
-		}
-		return nil, fmt.Errorf("error scanning updated video row: %w", err)
-	}
-
-	return &response, nil
+// contentColumns escanea los metadatos del archivo, que son NULL mientras el video no tiene uno.
+type contentColumns struct {
+	key, contentType, checksum sql.NullString
+	size                       sql.NullInt64
+	duration                   sql.NullFloat64
 }
 
-func (p *BDRepositoryVideo) DeleteVideo(ctx *gin.Context, request *model.DeleteVideo) error {
-	p.mu.Lock()
-	defer p.mu.Unlock()
+func (c *contentColumns) targets() []any {
+	return []any{&c.key, &c.size, &c.contentType, &c.checksum, &c.duration}
+}
 
-	query := "DELETE FROM videos WHERE id = $1"
-	result, err := p.db.Exec(query, request.ID)
-	if err != nil {
-		return err
+func (c *contentColumns) content() *schema.VideoContent {
+	if !c.key.Valid {
+		return nil
 	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
+	content := &schema.VideoContent{
+		Key:         c.key.String,
+		Size:        c.size.Int64,
+		ContentType: c.contentType.String,
+		Checksum:    c.checksum.String,
 	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("video with id %s not found", request.ID)
+	if c.duration.Valid {
+		content.Duration = &c.duration.Float64
 	}
+	return content
+}
 
-	return nil
+func scanVideoUpdate(row *sql.Row) (*schema.VideosUpdateResponse, error) {
+	var response schema.VideosUpdateResponse
+	var challengeID sql.NullString
+	var content contentColumns
+	if err := row.Scan(append([]any{&response.ID, &response.Title, &response.Description, &challengeID, &response.CreatedAt, &response.UpdatedAt, &response.Version}, content.targets()...)...); err != nil {
+		return nil, err
+	}
+	response.ChallengeID = challengeID.String
+	response.Content = content.content()
+	return &response, nil
 }
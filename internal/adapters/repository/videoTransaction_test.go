@@ -1,14 +1,16 @@
 package repository
 
 import (
+	entity "CrudPlatform/internal/core/domain/repository"
 	model "CrudPlatform/internal/core/domain/repository/model/videos"
+	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -18,20 +20,22 @@ func TestBDRepositoryVideo(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	repo := &BDRepositoryVideo{db: db}
-	ctx := &gin.Context{}
+	repo := NewBdRepositoryVideo(db, 0)
+	ctx := context.Background()
 
 	t.Run("CreateVideo", func(t *testing.T) {
 		video := &model.Videos{
 			Title:       "Test Video",
 			Description: "This is a test video",
+			UserID:      "user-1",
+			CreatedBy:   "user-1",
 		}
 
 		mock.ExpectExec("INSERT INTO videos").
-			WithArgs(sqlmock.AnyArg(), video.Title, video.Description, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), video.Title, video.Description, sql.NullString{String: "user-1", Valid: true}, sql.NullString{}, video.CreatedBy, sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		id, err := repo.CreateVideo(ctx, video)
+		id, err := repo.Create(ctx, video)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, id)
 	})
@@ -40,192 +44,277 @@ func TestBDRepositoryVideo(t *testing.T) {
 		video := &model.Videos{
 			Title:       "Test Video",
 			Description: "This is a test video",
+			CreatedBy:   "user-1",
 		}
 
 		mock.ExpectExec("INSERT INTO videos").
-			WithArgs(sqlmock.AnyArg(), video.Title, video.Description, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), video.Title, video.Description, sqlmock.AnyArg(), sqlmock.AnyArg(), video.CreatedBy, sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnError(fmt.Errorf("exec error"))
 
-		id, err := repo.CreateVideo(ctx, video)
+		id, err := repo.Create(ctx, video)
 		assert.Error(t, err)
 		assert.Empty(t, id)
 		assert.Contains(t, err.Error(), "error executing statement: exec error")
 	})
 
 	t.Run("SelectVideo", func(t *testing.T) {
-		request := &model.GetVideo{ID: "123"}
-		rows := sqlmock.NewRows([]string{"title", "description", "created_at", "updated_at"}).
-			AddRow("Test Video", "This is a test video", time.Now(), time.Now())
+		request := &entity.GetRecord{ID: "123"}
+		rows := sqlmock.NewRows([]string{"title", "description", "user_id", "challenge_id", "created_by", "created_at", "updated_at", "version", "deleted_at", "content_key", "content_size", "content_type", "content_checksum", "content_duration"}).
+			AddRow("Test Video", "This is a test video", "user-1", nil, "user-1", time.Now(), time.Now(), 1, nil, nil, nil, nil, nil, nil)
 
 		mock.ExpectQuery("SELECT (.+) FROM videos WHERE id = \\$1").
 			WithArgs(request.ID).
 			WillReturnRows(rows)
 
-		video, err := repo.SelectVideo(ctx, request)
+		video, err := repo.Select(ctx, request)
 		assert.NoError(t, err)
 		assert.NotNil(t, video)
 		assert.Equal(t, "Test Video", video.Title)
+		assert.Equal(t, "user-1", video.CreatedBy)
+		assert.Equal(t, "user-1", video.UserID)
+		assert.Empty(t, video.ChallengeID)
 	})
 
 	t.Run("SelectVideo_NotFound", func(t *testing.T) {
-		request := &model.GetVideo{ID: "999"}
+		request := &entity.GetRecord{ID: "999"}
 
 		mock.ExpectQuery("SELECT (.+) FROM videos WHERE id = \\$1").
 			WithArgs(request.ID).
 			WillReturnError(sql.ErrNoRows)
 
-		video, err := repo.SelectVideo(ctx, request)
+		video, err := repo.Select(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, video)
 		assert.Contains(t, err.Error(), "video with id 999 not found")
 	})
 
 	t.Run("SelectVideo_ScanError", func(t *testing.T) {
-		request := &model.GetVideo{ID: "123"}
+		request := &entity.GetRecord{ID: "123"}
 
-		rows := sqlmock.NewRows([]string{"title", "description", "created_at", "updated_at", "extra_column"}).
-			AddRow("Test Video", "Test Description", time.Now(), time.Now(), "extra data")
+		rows := sqlmock.NewRows([]string{"title", "description", "user_id", "challenge_id", "created_by", "created_at", "updated_at", "version", "deleted_at", "extra_column"}).
+			AddRow("Test Video", "Test Description", nil, nil, nil, time.Now(), time.Now(), 1, nil, "extra data")
 
 		mock.ExpectQuery("SELECT (.+) FROM videos WHERE id = \\$1").
 			WithArgs(request.ID).
 			WillReturnRows(rows)
 
-		video, err := repo.SelectVideo(ctx, request)
+		video, err := repo.Select(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, video)
 		assert.Contains(t, err.Error(), "error scanning video row")
 	})
 
 	t.Run("UpdateVideo", func(t *testing.T) {
-		request := &model.UpdateVideo{
-			ID:          "123",
+		request := model.ReplaceVideo{
 			Title:       "Updated Video",
 			Description: "This is an updated test video",
-		}
-
-		mock.ExpectExec("UPDATE videos SET").
-			WithArgs(request.Title, request.Description, sqlmock.AnyArg(), request.ID).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		}.Update("123")
+		createdAt := time.Now().Add(-time.Hour)
 
-		rows := sqlmock.NewRows([]string{"title", "description", "updated_at"}).
-			AddRow(request.Title, request.Description, time.Now())
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "challenge_id", "created_at", "updated_at", "version", "content_key", "content_size", "content_type", "content_checksum", "content_duration"}).
+			AddRow(request.ID, *request.Title, *request.Description, "challenge-1", createdAt, time.Now(), 1, nil, nil, nil, nil, nil)
 
-		mock.ExpectQuery("SELECT (.+) FROM videos WHERE id = \\$1").
-			WithArgs(request.ID).
+		mock.ExpectQuery("UPDATE videos SET (.+) RETURNING").
+			WithArgs(*request.Title, *request.Description, sqlmock.AnyArg(), sqlmock.AnyArg(), request.ID).
 			WillReturnRows(rows)
 
-		video, err := repo.UpdateVideo(ctx, request)
+		video, err := repo.Update(ctx, request)
 		assert.NoError(t, err)
 		assert.NotNil(t, video)
-		assert.Equal(t, request.Title, video.Title)
+		assert.Equal(t, request.ID, video.ID)
+		assert.Equal(t, *request.Title, video.Title)
+		assert.Equal(t, "challenge-1", video.ChallengeID)
+		assert.NotEmpty(t, video.CreatedAt)
 	})
 
 	t.Run("UpdateVideo_ExecError", func(t *testing.T) {
-		request := &model.UpdateVideo{
-			ID:          "123",
+		request := model.ReplaceVideo{
 			Title:       "Updated Video",
 			Description: "This is an updated test video",
-		}
+		}.Update("123")
 
-		mock.ExpectExec("UPDATE videos SET").
-			WithArgs(request.Title, request.Description, sqlmock.AnyArg(), request.ID).
+		mock.ExpectQuery("UPDATE videos SET (.+) RETURNING").
+			WithArgs(*request.Title, *request.Description, sqlmock.AnyArg(), sqlmock.AnyArg(), request.ID).
 			WillReturnError(fmt.Errorf("exec error"))
 
-		video, err := repo.UpdateVideo(ctx, request)
+		video, err := repo.Update(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, video)
 		assert.Contains(t, err.Error(), "error executing update")
 	})
 
 	t.Run("UpdateVideo_NotFound", func(t *testing.T) {
-		request := &model.UpdateVideo{
-			ID:          "123",
+		request := model.ReplaceVideo{
 			Title:       "Updated Video",
 			Description: "This is an updated test video",
-		}
+		}.Update("123")
 
-		mock.ExpectExec("UPDATE videos SET").
-			WithArgs(request.Title, request.Description, sqlmock.AnyArg(), request.ID).
-			WillReturnResult(sqlmock.NewResult(0, 1))
-
-		mock.ExpectQuery("SELECT (.+) FROM videos WHERE id = \\$1").
-			WithArgs(request.ID).
+		mock.ExpectQuery("UPDATE videos SET (.+) RETURNING").
+			WithArgs(*request.Title, *request.Description, sqlmock.AnyArg(), sqlmock.AnyArg(), request.ID).
 			WillReturnError(sql.ErrNoRows)
 
-		video, err := repo.UpdateVideo(ctx, request)
+		video, err := repo.Update(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, video)
-		assert.Contains(t, err.Error(), "video with id 123 not found after update")
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+		assert.Contains(t, err.Error(), "video with id 123 not found")
 	})
 
 	t.Run("UpdateVideo_ScanError", func(t *testing.T) {
-		request := &model.UpdateVideo{
-			ID:          "123",
+		request := model.ReplaceVideo{
 			Title:       "Updated Video",
 			Description: "This is an updated test video",
-		}
+		}.Update("123")
+		createdAt := time.Now().Add(-time.Hour)
 
-		mock.ExpectExec("UPDATE videos SET").
-			WithArgs(request.Title, request.Description, sqlmock.AnyArg(), request.ID).
-			WillReturnResult(sqlmock.NewResult(0, 1))
-
-		rows := sqlmock.NewRows([]string{"title", "description", "updated_at", "extra_column"}).
-			AddRow(request.Title, request.Description, time.Now(), "extra data")
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "challenge_id", "created_at", "updated_at", "version", "extra_column"}).
+			AddRow(request.ID, *request.Title, *request.Description, nil, createdAt, time.Now(), 1, "extra data")
 
-		mock.ExpectQuery("SELECT (.+) FROM videos WHERE id = \\$1").
-			WithArgs(request.ID).
+		mock.ExpectQuery("UPDATE videos SET (.+) RETURNING").
+			WithArgs(*request.Title, *request.Description, sqlmock.AnyArg(), sqlmock.AnyArg(), request.ID).
 			WillReturnRows(rows)
 
-		video, err := repo.UpdateVideo(ctx, request)
+		video, err := repo.Update(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, video)
-		assert.Contains(t, err.Error(), "error scanning updated video row")
+		assert.Contains(t, err.Error(), "error executing update")
+	})
+
+	t.Run("UpdateVideo_ClearChallenge", func(t *testing.T) {
+		challengeID := ""
+		request := &model.UpdateVideo{ID: "123", ChallengeID: &challengeID}
+
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "challenge_id", "created_at", "updated_at", "version", "content_key", "content_size", "content_type", "content_checksum", "content_duration"}).
+			AddRow(request.ID, "Test Video", "This is a test video", nil, time.Now().Add(-time.Hour), time.Now(), 1, nil, nil, nil, nil, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta("UPDATE videos SET challenge_id = $1, updated_at = $2, version = version + 1 WHERE id = $3 AND deleted_at IS NULL RETURNING")).
+			WithArgs(sql.NullString{}, sqlmock.AnyArg(), request.ID).
+			WillReturnRows(rows)
+
+		video, err := repo.Update(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, "Test Video", video.Title)
+		assert.Empty(t, video.ChallengeID)
 	})
 
 	t.Run("DeleteVideo", func(t *testing.T) {
-		request := &model.DeleteVideo{ID: "123"}
+		request := &entity.DeleteRecord{ID: "123"}
 
-		mock.ExpectExec("DELETE FROM videos WHERE id = \\$1").
-			WithArgs(request.ID).
+		mock.ExpectExec("UPDATE videos SET deleted_at = \\$1, version = version \\+ 1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		err := repo.DeleteVideo(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.NoError(t, err)
 	})
 
 	t.Run("DeleteVideo_NotFound", func(t *testing.T) {
-		request := &model.DeleteVideo{ID: "999"}
+		request := &entity.DeleteRecord{ID: "999"}
 
-		mock.ExpectExec("DELETE FROM videos WHERE id = \\$1").
-			WithArgs(request.ID).
+		mock.ExpectExec("UPDATE videos SET deleted_at = \\$1, version = version \\+ 1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
-		err := repo.DeleteVideo(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "video with id 999 not found")
 	})
 
-	t.Run("DeleteVideo_ExecError", func(t *testing.T) {
-		request := &model.DeleteVideo{ID: "123"}
+	t.Run("DeleteVideo_StaleVersion", func(t *testing.T) {
+		version := int64(4)
+		request := &entity.DeleteRecord{ID: "123", Version: &version}
 
-		mock.ExpectExec("DELETE FROM videos WHERE id = \\$1").
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE videos SET deleted_at = $1, version = version + 1 WHERE id = $2 AND version = $3 AND deleted_at IS NULL")).
+			WithArgs(sqlmock.AnyArg(), request.ID, version).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT EXISTS").
 			WithArgs(request.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		err := repo.Delete(ctx, request)
+		assert.Equal(t, entity.KindPreconditionFailed, entity.KindOf(err))
+	})
+
+	t.Run("DeleteVideo_ExecError", func(t *testing.T) {
+		request := &entity.DeleteRecord{ID: "123"}
+
+		mock.ExpectExec("UPDATE videos SET deleted_at = \\$1, version = version \\+ 1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnError(fmt.Errorf("exec error"))
 
-		err := repo.DeleteVideo(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "exec error")
 	})
 
 	t.Run("DeleteVideo_RowsAffectedError", func(t *testing.T) {
-		request := &model.DeleteVideo{ID: "123"}
+		request := &entity.DeleteRecord{ID: "123"}
 
-		mock.ExpectExec("DELETE FROM videos WHERE id = \\$1").
-			WithArgs(request.ID).
+		mock.ExpectExec("UPDATE videos SET deleted_at = \\$1, version = version \\+ 1 WHERE id = \\$2").
+			WithArgs(sqlmock.AnyArg(), request.ID).
 			WillReturnResult(sqlmock.NewErrorResult(fmt.Errorf("rows affected error")))
 
-		err := repo.DeleteVideo(ctx, request)
+		err := repo.Delete(ctx, request)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "rows affected error")
 	})
 }
+
+func TestBDRepositoryListVideos(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBdRepositoryVideo(db, 0)
+	ctx := context.Background()
+	table := "videos"
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM " + table).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM "+table+" WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC").
+		WithArgs(entity.MaxPageSize+1, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "user_id", "challenge_id", "created_at", "updated_at", "version", "deleted_at", "content_key", "content_size", "content_type", "content_checksum", "content_duration"}).AddRow("1", "Test Video", "Description", "user-1", nil, time.Now(), time.Now(), 1, nil, nil, nil, nil, nil, nil))
+
+	page, err := repo.List(ctx, &model.ListVideos{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "1", page.Items[0].ID)
+	assert.Equal(t, "user-1", page.Items[0].UserID)
+	assert.Empty(t, page.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBDRepositoryListVideos_ByRelation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBdRepositoryVideo(db, 0)
+	ctx := context.Background()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM videos WHERE deleted_at IS NULL AND user_id = \\$1 AND challenge_id = \\$2").
+		WithArgs("user-1", "challenge-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT (.+) FROM videos WHERE deleted_at IS NULL AND user_id = \\$1 AND challenge_id = \\$2 ORDER BY created_at DESC, id DESC").
+		WithArgs("user-1", "challenge-1", entity.MaxPageSize+1, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "user_id", "challenge_id", "created_at", "updated_at", "version", "deleted_at", "content_key", "content_size", "content_type", "content_checksum", "content_duration"}))
+
+	page, err := repo.List(ctx, &model.ListVideos{UserID: "user-1", ChallengeID: "challenge-1"})
+	require.NoError(t, err)
+	assert.Empty(t, page.Items)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBDRepositoryListVideos_RelationNotSortable(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBdRepositoryVideo(db, 0)
+
+	request := &model.ListVideos{}
+	request.Sort = "user_id"
+	_, err = repo.List(context.Background(), request)
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore guarda cada llave como un archivo bajo root. Sirve para una sola instancia del
+// servicio o para varias que comparten un volumen.
+type LocalStore struct {
+	root string
+}
+
+func NewLocalStore(root string) *LocalStore {
+	return &LocalStore{root: root}
+}
+
+// Put escribe primero en un archivo temporal y luego lo renombra, de modo que una lectura
+// concurrente nunca ve un archivo a medio escribir.
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return entity.WrapError(entity.KindInternal, err, "error creating directory for %s", key)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return entity.WrapError(entity.KindInternal, err, "error creating file for %s", key)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, contextReader{ctx: ctx, r: r}); err != nil {
+		tmp.Close()
+		return readError(err, "error writing %s", key)
+	}
+	if err := tmp.Close(); err != nil {
+		return entity.WrapError(entity.KindInternal, err, "error writing %s", key)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return entity.WrapError(entity.KindInternal, err, "error writing %s", key)
+	}
+	return nil
+}
+
+func (s *LocalStore) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, entity.NewError(entity.KindNotFound, "blob %s not found", key)
+	}
+	if err != nil {
+		return nil, entity.WrapError(entity.KindInternal, err, "error opening %s", key)
+	}
+	return file, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return entity.WrapError(entity.KindInternal, err, "error deleting %s", key)
+	}
+	return nil
+}
+
+// path resuelve la llave dentro de root; las llaves que intentan salir de root se rechazan.
+func (s *LocalStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", entity.NewError(entity.KindValidation, "invalid blob key %q", key)
+	}
+	return filepath.Join(s.root, clean), nil
+}
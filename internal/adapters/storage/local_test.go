@@ -0,0 +1,68 @@
+package storage
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	ctx := context.Background()
+
+	t.Run("PutOpenSeek", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "videos/1/content", strings.NewReader("0123456789"), -1))
+
+		blob, err := store.Open(ctx, "videos/1/content")
+		require.NoError(t, err)
+		defer blob.Close()
+
+		_, err = blob.Seek(6, io.SeekStart)
+		require.NoError(t, err)
+		rest, err := io.ReadAll(blob)
+		require.NoError(t, err)
+		assert.Equal(t, "6789", string(rest))
+	})
+
+	t.Run("PutReplaces", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "videos/2/content", strings.NewReader("old"), 3))
+		require.NoError(t, store.Put(ctx, "videos/2/content", strings.NewReader("new"), 3))
+
+		blob, err := store.Open(ctx, "videos/2/content")
+		require.NoError(t, err)
+		defer blob.Close()
+		content, _ := io.ReadAll(blob)
+		assert.Equal(t, "new", string(content))
+	})
+
+	t.Run("DeleteAndNotFound", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "videos/3/content", strings.NewReader("x"), 1))
+		require.NoError(t, store.Delete(ctx, "videos/3/content"))
+		require.NoError(t, store.Delete(ctx, "videos/3/content"))
+
+		_, err := store.Open(ctx, "videos/3/content")
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	})
+
+	t.Run("KeyOutsideRoot", func(t *testing.T) {
+		for _, key := range []string{"../secret", "/etc/passwd", ""} {
+			err := store.Put(ctx, key, strings.NewReader("x"), 1)
+			assert.Equal(t, entity.KindValidation, entity.KindOf(err), key)
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := store.Put(canceled, "videos/4/content", strings.NewReader("x"), 1)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = store.Open(ctx, "videos/4/content")
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	})
+}
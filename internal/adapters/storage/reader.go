@@ -0,0 +1,34 @@
+package storage
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"context"
+	"errors"
+	"io"
+)
+
+// contextReader deja de leer cuando ctx se cancela, para no seguir copiando un upload abandonado.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// readError clasifica un error al copiar contenido: si el origen lo cortó, como un cliente que se
+// desconecta o un contenido que supera el límite, se conserva el error del origen.
+func readError(err error, format string, args ...any) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	var kinded interface{ Kind() entity.ErrorKind }
+	if errors.As(err, &kinded) {
+		return err
+	}
+	return entity.WrapError(entity.KindInternal, err, format, args...)
+}
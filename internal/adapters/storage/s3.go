@@ -0,0 +1,322 @@
+package storage
+
+import (
+	"CrudPlatform/cmd/config"
+	entity "CrudPlatform/internal/core/domain/repository"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// emptyPayloadHash es el SHA-256 de un cuerpo vacío, que firman GET, HEAD y DELETE.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// unsignedPayload evita leer dos veces un PUT para firmarlo; TLS protege el contenido.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// S3Store guarda cada llave como un objeto de un bucket S3 o de un servicio compatible, como
+// MinIO. Las solicitudes se firman con AWS Signature Version 4.
+type S3Store struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+	now       func() time.Time
+}
+
+func NewS3Store(cfg config.S3Config) *S3Store {
+	endpoint, _ := url.Parse(cfg.Endpoint)
+	return &S3Store{
+		endpoint:  endpoint,
+		region:    cfg.Region,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+		pathStyle: cfg.PathStyle,
+		client:    http.DefaultClient,
+		now:       time.Now,
+	}
+}
+
+// Put sube el objeto en un solo PUT. S3 exige conocer el largo, así que un contenido de tamaño
+// desconocido se copia primero a un archivo temporal.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if size < 0 {
+		spool, err := os.CreateTemp("", "blob-*")
+		if err != nil {
+			return entity.WrapError(entity.KindInternal, err, "error buffering %s", key)
+		}
+		defer os.Remove(spool.Name())
+		defer spool.Close()
+
+		if size, err = io.Copy(spool, contextReader{ctx: ctx, r: r}); err != nil {
+			return readError(err, "error buffering %s", key)
+		}
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return entity.WrapError(entity.KindInternal, err, "error buffering %s", key)
+		}
+		r = spool
+	}
+
+	req, err := s.request(ctx, http.MethodPut, key, io.NopCloser(r))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+
+	resp, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return readError(err, "error uploading %s", key)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s.statusError(resp, "error uploading %s", key)
+	}
+	return nil
+}
+
+// Open consulta el tamaño del objeto y lo lee con GETs por rango, que se reabren en cada Seek.
+func (s *S3Store) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	req, err := s.request(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, entity.WrapError(entity.KindUnavailable, err, "error opening %s", key)
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return &s3Object{ctx: ctx, store: s, key: key, size: resp.ContentLength}, nil
+	case http.StatusNotFound:
+		return nil, entity.NewError(entity.KindNotFound, "blob %s not found", key)
+	default:
+		return nil, s.statusError(resp, "error opening %s", key)
+	}
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return entity.WrapError(entity.KindUnavailable, err, "error deleting %s", key)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s.statusError(resp, "error deleting %s", key)
+	}
+	return nil
+}
+
+// request arma la URL del objeto según el estilo de direccionamiento configurado.
+func (s *S3Store) request(ctx context.Context, method, key string, body io.ReadCloser) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, entity.NewError(entity.KindValidation, "invalid blob key %q", key)
+	}
+
+	target := *s.endpoint
+	path := strings.TrimSuffix(target.Path, "/")
+	if s.pathStyle {
+		path += "/" + s.bucket
+	} else {
+		target.Host = s.bucket + "." + target.Host
+	}
+	target.Path = path + "/" + key
+	target.RawPath = awsEscapePath(target.Path)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), nil)
+	if err != nil {
+		return nil, entity.WrapError(entity.KindInternal, err, "error building request for %s", key)
+	}
+	if body != nil {
+		req.Body = body
+	}
+	return req, nil
+}
+
+func (s *S3Store) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash, s.now().UTC())
+	return s.client.Do(req)
+}
+
+// sign agrega la firma Signature Version 4 del request. Se firman el host, el rango y los headers x-amz-*.
+func (s *S3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "range" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	digest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(digest[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+// statusError clasifica una respuesta inesperada; los 5xx del servicio se pueden reintentar.
+func (s *S3Store) statusError(resp *http.Response, format string, args ...any) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err := fmt.Errorf("s3 responded %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	if resp.StatusCode >= 500 {
+		return entity.WrapError(entity.KindUnavailable, err, format, args...)
+	}
+	return entity.WrapError(entity.KindInternal, err, format, args...)
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// awsEscapePath codifica la ruta como exige SigV4: todo salvo los caracteres no reservados y '/'.
+func awsEscapePath(path string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			escaped.WriteByte(c)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", c)
+		}
+	}
+	return escaped.String()
+}
+
+// s3Object lee un objeto desde la posición actual con un GET por rango que se abre al primer Read.
+type s3Object struct {
+	ctx    context.Context
+	store  *S3Store
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (o *s3Object) Read(p []byte) (int, error) {
+	if o.offset >= o.size {
+		return 0, io.EOF
+	}
+	if o.body == nil {
+		if err := o.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := o.body.Read(p)
+	o.offset += int64(n)
+	if errors.Is(err, io.EOF) && o.offset < o.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (o *s3Object) open() error {
+	req, err := o.store.request(o.ctx, http.MethodGet, o.key, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", "bytes="+strconv.FormatInt(o.offset, 10)+"-")
+
+	resp, err := o.store.do(req, emptyPayloadHash)
+	if err != nil {
+		return entity.WrapError(entity.KindUnavailable, err, "error reading %s", o.key)
+	}
+	if resp.StatusCode != http.StatusPartialContent && !(resp.StatusCode == http.StatusOK && o.offset == 0) {
+		defer resp.Body.Close()
+		return o.store.statusError(resp, "error reading %s", o.key)
+	}
+	o.body = resp.Body
+	return nil
+}
+
+func (o *s3Object) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += o.offset
+	case io.SeekEnd:
+		offset += o.size
+	default:
+		return o.offset, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return o.offset, errors.New("negative position")
+	}
+
+	if offset != o.offset && o.body != nil {
+		o.body.Close()
+		o.body = nil
+	}
+	o.offset = offset
+	return offset, nil
+}
+
+func (o *s3Object) Close() error {
+	if o.body == nil {
+		return nil
+	}
+	err := o.body.Close()
+	o.body = nil
+	return err
+}
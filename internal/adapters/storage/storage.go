@@ -0,0 +1,14 @@
+package storage
+
+import (
+	"CrudPlatform/cmd/config"
+	"CrudPlatform/internal/core/ports"
+)
+
+// New crea el BlobStore del driver configurado. La configuración ya fue validada por config.Load.
+func New(cfg config.StorageConfig) ports.BlobStore {
+	if cfg.Driver == "s3" {
+		return NewS3Store(cfg.S3)
+	}
+	return NewLocalStore(cfg.LocalPath)
+}
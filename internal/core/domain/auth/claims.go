@@ -0,0 +1,33 @@
+package auth
+
+import "context"
+
+// Claims son los datos validados del token del usuario autenticado.
+type Claims struct {
+	Subject string
+	Role    Role
+	Scopes  []string
+}
+
+// HasScope indica si el token concede el scope indicado.
+func (c *Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type claimsKey struct{}
+
+// WithClaims retorna una copia del contexto que transporta los claims.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext retorna los claims del usuario autenticado, si existen.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok && claims != nil
+}
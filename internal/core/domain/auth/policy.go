@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"fmt"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+)
+
+// Role es el rol del usuario autenticado, tomado del claim role del token.
+type Role string
+
+const (
+	RoleAdmin   Role = "admin"
+	RoleCreator Role = "creator"
+	RoleViewer  Role = "viewer"
+)
+
+// ParseRole retorna el rol indicado; los valores desconocidos o vacíos se tratan como viewer.
+func ParseRole(value string) Role {
+	switch Role(value) {
+	case RoleAdmin, RoleCreator:
+		return Role(value)
+	default:
+		return RoleViewer
+	}
+}
+
+// Valid indica si el rol es uno de los roles conocidos.
+func (r Role) Valid() bool {
+	return r == RoleAdmin || r == RoleCreator || r == RoleViewer
+}
+
+// Permission identifica una acción sobre un recurso, por ejemplo videos:update.
+type Permission string
+
+const (
+	PermissionUsersCreate  Permission = "users:create"
+	PermissionUsersRead    Permission = "users:read"
+	PermissionUsersUpdate  Permission = "users:update"
+	PermissionUsersDelete  Permission = "users:delete"
+	PermissionUsersRestore Permission = "users:restore"
+
+	PermissionChallengesCreate  Permission = "challenges:create"
+	PermissionChallengesRead    Permission = "challenges:read"
+	PermissionChallengesUpdate  Permission = "challenges:update"
+	PermissionChallengesDelete  Permission = "challenges:delete"
+	PermissionChallengesRestore Permission = "challenges:restore"
+
+	PermissionVideosCreate  Permission = "videos:create"
+	PermissionVideosRead    Permission = "videos:read"
+	PermissionVideosUpdate  Permission = "videos:update"
+	PermissionVideosDelete  Permission = "videos:delete"
+	PermissionVideosRestore Permission = "videos:restore"
+
+	// PermissionDeletedRead permite leer y listar registros eliminados con include_deleted.
+	PermissionDeletedRead Permission = "deleted:read"
+	// PermissionAuditRead permite consultar la auditoría de escrituras.
+	PermissionAuditRead Permission = "audit:read"
+)
+
+// scope indica si un permiso aplica a cualquier registro o solo a los propios.
+type scope int
+
+const (
+	scopeOwn scope = iota + 1
+	scopeAny
+)
+
+// ForbiddenError indica que el usuario no tiene el permiso solicitado.
+type ForbiddenError struct {
+	Permission Permission
+	Reason     string
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("permission %s denied: %s", e.Permission, e.Reason)
+}
+
+func (e *ForbiddenError) Kind() entity.ErrorKind {
+	return entity.KindForbidden
+}
+
+// Policy decide qué puede hacer cada rol. Los permisos con alcance propio solo se conceden
+// cuando el usuario autenticado es el dueño del registro.
+type Policy struct {
+	grants map[Role]map[Permission]scope
+}
+
+func NewPolicy() *Policy {
+	reads := map[Permission]scope{
+		PermissionUsersRead:      scopeAny,
+		PermissionChallengesRead: scopeAny,
+		PermissionVideosRead:     scopeAny,
+		PermissionUsersUpdate:    scopeOwn,
+		PermissionUsersDelete:    scopeOwn,
+	}
+
+	creator := map[Permission]scope{
+		PermissionChallengesCreate: scopeAny,
+		PermissionChallengesUpdate: scopeOwn,
+		PermissionChallengesDelete: scopeOwn,
+		PermissionVideosCreate:     scopeAny,
+		PermissionVideosUpdate:     scopeOwn,
+		PermissionVideosDelete:     scopeOwn,
+		// Un creator puede deshacer la eliminación de sus propios registros.
+		PermissionChallengesRestore: scopeOwn,
+		PermissionVideosRestore:     scopeOwn,
+	}
+	for permission, s := range reads {
+		creator[permission] = s
+	}
+
+	admin := map[Permission]scope{}
+	for _, permission := range []Permission{
+		PermissionUsersCreate, PermissionUsersRead, PermissionUsersUpdate, PermissionUsersDelete, PermissionUsersRestore,
+		PermissionChallengesCreate, PermissionChallengesRead, PermissionChallengesUpdate, PermissionChallengesDelete, PermissionChallengesRestore,
+		PermissionVideosCreate, PermissionVideosRead, PermissionVideosUpdate, PermissionVideosDelete, PermissionVideosRestore,
+		PermissionDeletedRead, PermissionAuditRead,
+	} {
+		admin[permission] = scopeAny
+	}
+
+	return &Policy{grants: map[Role]map[Permission]scope{
+		RoleAdmin:   admin,
+		RoleCreator: creator,
+		RoleViewer:  reads,
+	}}
+}
+
+// Authorize verifica que los claims concedan el permiso. ownerID es el dueño del registro
+// afectado y se ignora en permisos que no dependen de un registro existente.
+func (p *Policy) Authorize(claims *Claims, permission Permission, ownerID string) error {
+	if claims == nil {
+		return &ForbiddenError{Permission: permission, Reason: "no authenticated user"}
+	}
+
+	switch p.grants[claims.Role][permission] {
+	case scopeAny:
+		return nil
+	case scopeOwn:
+		if ownerID != "" && ownerID == claims.Subject {
+			return nil
+		}
+		return &ForbiddenError{Permission: permission, Reason: "only the owner of the record or an admin may do this"}
+	default:
+		return &ForbiddenError{Permission: permission, Reason: fmt.Sprintf("role %s does not grant it", claims.Role)}
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy(t *testing.T) {
+	policy := NewPolicy()
+	admin := &Claims{Subject: "admin-1", Role: RoleAdmin}
+	creator := &Claims{Subject: "creator-1", Role: RoleCreator}
+	viewer := &Claims{Subject: "viewer-1", Role: RoleViewer}
+
+	tests := []struct {
+		name       string
+		claims     *Claims
+		permission Permission
+		ownerID    string
+		allowed    bool
+	}{
+		{"AdminUpdatesAnyVideo", admin, PermissionVideosUpdate, "creator-1", true},
+		{"AdminCreatesUsers", admin, PermissionUsersCreate, "", true},
+		{"CreatorCreatesChallenge", creator, PermissionChallengesCreate, "", true},
+		{"CreatorUpdatesOwnChallenge", creator, PermissionChallengesUpdate, "creator-1", true},
+		{"CreatorUpdatesOthersChallenge", creator, PermissionChallengesUpdate, "creator-2", false},
+		{"CreatorDeletesOthersVideo", creator, PermissionVideosDelete, "creator-2", false},
+		{"CreatorWithoutOwner", creator, PermissionVideosDelete, "", false},
+		{"CreatorCannotCreateUsers", creator, PermissionUsersCreate, "", false},
+		{"ViewerReadsVideos", viewer, PermissionVideosRead, "", true},
+		{"ViewerCannotCreateVideos", viewer, PermissionVideosCreate, "", false},
+		{"ViewerUpdatesOwnProfile", viewer, PermissionUsersUpdate, "viewer-1", true},
+		{"ViewerUpdatesOthersProfile", viewer, PermissionUsersUpdate, "creator-1", false},
+		{"CreatorRestoresOwnVideo", creator, PermissionVideosRestore, "creator-1", true},
+		{"CreatorRestoresOthersChallenge", creator, PermissionChallengesRestore, "creator-2", false},
+		{"ViewerCannotRestoreOwnProfile", viewer, PermissionUsersRestore, "viewer-1", false},
+		{"AdminReadsDeleted", admin, PermissionDeletedRead, "", true},
+		{"CreatorCannotReadDeleted", creator, PermissionDeletedRead, "", false},
+		{"AdminReadsAudit", admin, PermissionAuditRead, "", true},
+		{"CreatorCannotReadAudit", creator, PermissionAuditRead, "", false},
+		{"Anonymous", nil, PermissionVideosRead, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Authorize(tt.claims, tt.permission, tt.ownerID)
+			if tt.allowed {
+				assert.NoError(t, err)
+				return
+			}
+
+			var forbidden *ForbiddenError
+			assert.ErrorAs(t, err, &forbidden)
+			assert.Equal(t, tt.permission, forbidden.Permission)
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	assert.Equal(t, RoleAdmin, ParseRole("admin"))
+	assert.Equal(t, RoleCreator, ParseRole("creator"))
+	assert.Equal(t, RoleViewer, ParseRole(""))
+	assert.Equal(t, RoleViewer, ParseRole("root"))
+}
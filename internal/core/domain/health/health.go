@@ -0,0 +1,39 @@
+// Package health describe el resultado de las verificaciones de salud que consultan los probes
+// de Kubernetes.
+package health
+
+import "context"
+
+// Estados de una verificación y del reporte completo.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// ErrorUnavailable es el error de toda verificación fallida en el reporte; el detalle va al log.
+const ErrorUnavailable = "unavailable"
+
+// Check es una verificación con nombre, como un ping a la base de datos. Run retorna nil si la
+// dependencia está sana y debe respetar la cancelación del contexto.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result es el resultado de una verificación.
+type Result struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Report reúne las verificaciones de un probe; Status es ok solo si todas lo son.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// Healthy indica si el reporte es ok.
+func (r *Report) Healthy() bool {
+	return r.Status == StatusOK
+}
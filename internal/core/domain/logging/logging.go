@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+type loggerKey struct{}
+
+// Redacted reemplaza el valor de los atributos sensibles.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys son los atributos que nunca se escriben, sin importar en qué grupo aparezcan.
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"password":      true,
+	"password_hash": true,
+	"secret":        true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+}
+
+// New crea el logger de la aplicación, en JSON o en texto ("text"), que escribe desde level y
+// redacta los atributos sensibles.
+func New(w io.Writer, format string, level slog.Level) *slog.Logger {
+	options := &slog.HandlerOptions{Level: level, ReplaceAttr: redact}
+	if format == "text" {
+		return slog.New(slog.NewTextHandler(w, options))
+	}
+	return slog.New(slog.NewJSONHandler(w, options))
+}
+
+// With retorna una copia del contexto que transporta logger.
+func With(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext retorna el logger del contexto, que en un request ya lleva su id; sin uno, el logger por defecto.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// WithAttrs agrega atributos al logger del contexto, como el id del registro que se está procesando,
+// para que todo lo que se registre con ese contexto los incluya.
+func WithAttrs(ctx context.Context, args ...any) context.Context {
+	if len(args) == 0 {
+		return ctx
+	}
+	return With(ctx, FromContext(ctx).With(args...))
+}
+
+// MaskEmail deja solo la primera letra y el dominio de un email, como j***@example.com.
+func MaskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return Redacted
+	}
+	return local[:1] + "***@" + domain
+}
+
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+	switch {
+	case sensitiveKeys[key]:
+		attr.Value = slog.StringValue(Redacted)
+	case key == "email":
+		attr.Value = slog.StringValue(MaskEmail(attr.Value.String()))
+	}
+	return attr
+}
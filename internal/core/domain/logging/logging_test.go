@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_Redacts(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "json", slog.LevelInfo)
+
+	logger.Info("login", "email", "juan@example.com", "Authorization", "Bearer abc",
+		slog.Group("request", "password", "hunter22", "name", "Juan"))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "j***@example.com", entry["email"])
+	assert.Equal(t, Redacted, entry["Authorization"])
+	assert.Equal(t, map[string]any{"password": Redacted, "name": "Juan"}, entry["request"])
+}
+
+func TestNew_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "text", slog.LevelWarn)
+
+	logger.Info("hidden")
+	assert.Empty(t, buf.String())
+	logger.Warn("shown")
+	assert.Contains(t, buf.String(), "msg=shown")
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, slog.Default(), FromContext(context.Background()))
+
+	var buf bytes.Buffer
+	ctx := With(context.Background(), New(&buf, "json", slog.LevelInfo))
+	ctx = WithAttrs(ctx, "video_id", "123")
+	FromContext(ctx).Info("query")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "123", entry["video_id"])
+}
+
+func TestMaskEmail(t *testing.T) {
+	assert.Equal(t, "a***@b.co", MaskEmail("ana@b.co"))
+	assert.Equal(t, Redacted, MaskEmail("not an email"))
+	assert.Equal(t, Redacted, MaskEmail("@example.com"))
+}
@@ -0,0 +1,247 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// Formatos de imagen aceptados. Se reconocen por sus primeros bytes y no por el tipo que declara el cliente.
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+)
+
+var (
+	// ErrUnsupportedImage indica que el contenido no empieza como un JPEG, PNG o GIF.
+	ErrUnsupportedImage = errors.New("image is not a JPEG, PNG or GIF")
+	// ErrImageTooLarge indica que la imagen tiene más píxeles de los que se aceptan decodificar.
+	ErrImageTooLarge = errors.New("image dimensions are too large")
+	// ErrInvalidImage indica que el contenido tiene la firma de un formato aceptado pero no se puede decodificar.
+	ErrInvalidImage = errors.New("invalid image")
+)
+
+// maxImagePixels acota lo que se decodifica, para que una imagen pequeña en bytes pero enorme en
+// píxeles no agote la memoria.
+const maxImagePixels = 40_000_000
+
+// Image es una imagen decodificada con la orientación EXIF que hay que aplicarle para mostrarla derecha.
+type Image struct {
+	image.Image
+	Format      string
+	Orientation int
+}
+
+// ImageFormat reconoce el formato de una imagen por su firma.
+func ImageFormat(data []byte) (string, bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return FormatJPEG, true
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return FormatPNG, true
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return FormatGIF, true
+	}
+	return "", false
+}
+
+// DecodeImage valida la firma y las dimensiones de la imagen antes de decodificarla. La imagen
+// decodificada no conserva ningún metadato del archivo, como EXIF, salvo la orientación.
+func DecodeImage(data []byte) (*Image, error) {
+	format, ok := ImageFormat(data)
+	if !ok {
+		return nil, ErrUnsupportedImage
+	}
+
+	var decodeConfig func(io.Reader) (image.Config, error)
+	var decode func(io.Reader) (image.Image, error)
+	switch format {
+	case FormatJPEG:
+		decodeConfig, decode = jpeg.DecodeConfig, jpeg.Decode
+	case FormatPNG:
+		decodeConfig, decode = png.DecodeConfig, png.Decode
+	case FormatGIF:
+		decodeConfig, decode = gif.DecodeConfig, gif.Decode
+	}
+
+	config, err := decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, fmt.Errorf("%w: empty image", ErrInvalidImage)
+	}
+	if int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	decoded, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	img := &Image{Image: decoded, Format: format, Orientation: 1}
+	if format == FormatJPEG {
+		img.Orientation = jpegOrientation(data)
+	}
+	return img, nil
+}
+
+// Thumbnail recorta el cuadrado central de la imagen y lo lleva a size×size píxeles promediando
+// los píxeles de origen que cubre cada uno. Al final aplica la orientación, que sobre un cuadrado
+// centrado da lo mismo que aplicarla antes y cuesta mucho menos.
+func (i *Image) Thumbnail(size int) *image.RGBA {
+	bounds := i.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	thumb := image.NewRGBA(image.Rect(0, 0, size, size))
+	for dy := 0; dy < size; dy++ {
+		sy0, sy1 := span(y0, side, size, dy)
+		for dx := 0; dx < size; dx++ {
+			sx0, sx1 := span(x0, side, size, dx)
+
+			var r, g, b, a, count uint64
+			for y := sy0; y < sy1; y++ {
+				for x := sx0; x < sx1; x++ {
+					pr, pg, pb, pa := i.At(x, y).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+
+			offset := thumb.PixOffset(dx, dy)
+			thumb.Pix[offset+0] = uint8(r / count >> 8)
+			thumb.Pix[offset+1] = uint8(g / count >> 8)
+			thumb.Pix[offset+2] = uint8(b / count >> 8)
+			thumb.Pix[offset+3] = uint8(a / count >> 8)
+		}
+	}
+	return orient(thumb, i.Orientation)
+}
+
+// span retorna los píxeles de origen que cubre el píxel d de un destino de size píxeles. Al
+// ampliar, cada píxel de destino cubre al menos uno de origen.
+func span(start, side, size, d int) (int, int) {
+	from := start + d*side/size
+	to := start + (d+1)*side/size
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
+
+// orient aplica una orientación EXIF (1 a 8) a una imagen cuadrada.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	n := src.Bounds().Dx()
+	last := n - 1
+	dst := image.NewRGBA(src.Bounds())
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // espejo horizontal
+				sx, sy = last-x, y
+			case 3: // rotada 180°
+				sx, sy = last-x, last-y
+			case 4: // espejo vertical
+				sx, sy = x, last-y
+			case 5: // transpuesta
+				sx, sy = y, x
+			case 6: // rotada 90° en sentido horario
+				sx, sy = y, last-x
+			case 7: // transversa
+				sx, sy = last-y, last-x
+			case 8: // rotada 90° en sentido antihorario
+				sx, sy = last-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation lee el tag Orientation (0x0112) del IFD0 del segmento EXIF de un JPEG. Sin EXIF,
+// o con un EXIF que no se entiende, la imagen se toma como derecha (1). El archivo viene del
+// cliente: un largo de segmento inválido corta la búsqueda en lugar de leer fuera de data.
+func jpegOrientation(data []byte) int {
+	for offset := 2; offset+2 <= len(data); {
+		if data[offset] != 0xFF {
+			return 1
+		}
+		marker := data[offset+1]
+		switch {
+		case marker == 0xFF:
+			// Byte de relleno antes de un marcador.
+			offset++
+			continue
+		case marker == 0x01 || marker >= 0xD0 && marker <= 0xD7:
+			// TEM y RSTn no tienen largo ni contenido.
+			offset += 2
+			continue
+		case marker == 0xDA || marker == 0xD9:
+			// Empiezan los datos de la imagen: los metadatos van antes.
+			return 1
+		}
+		if offset+4 > len(data) {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if length < 2 || offset+2+length > len(data) {
+			return 1
+		}
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		offset += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
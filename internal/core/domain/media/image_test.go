@@ -0,0 +1,172 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// halves pinta la primera mitad de la imagen de first y la segunda de second, en columnas o en filas.
+func halves(width, height int, vertical bool, first, second color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if vertical && x < width/2 || !vertical && y < height/2 {
+				img.SetRGBA(x, y, first)
+			} else {
+				img.SetRGBA(x, y, second)
+			}
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// withOrientation inserta después del SOI un segmento EXIF con el tag Orientation.
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := append([]byte{0xFF, 0xE1}, binary.BigEndian.AppendUint16(nil, uint16(len(segment)+2))...)
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+func assertDominant(t *testing.T, img image.Image, x, y int, want color.RGBA) {
+	t.Helper()
+	r, _, b, _ := img.At(x, y).RGBA()
+	if want == red {
+		assert.Greater(t, r, b, "pixel (%d,%d) should be red", x, y)
+	} else {
+		assert.Greater(t, b, r, "pixel (%d,%d) should be blue", x, y)
+	}
+}
+
+func TestImageFormat(t *testing.T) {
+	format, ok := ImageFormat(encodePNG(t, halves(2, 2, true, red, blue)))
+	assert.True(t, ok)
+	assert.Equal(t, FormatPNG, format)
+
+	format, _ = ImageFormat([]byte("GIF89a..."))
+	assert.Equal(t, FormatGIF, format)
+
+	_, ok = ImageFormat([]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>"))
+	assert.False(t, ok)
+}
+
+func TestDecodeImage(t *testing.T) {
+	t.Run("NotAnImage", func(t *testing.T) {
+		_, err := DecodeImage([]byte("%PDF-1.7"))
+		assert.ErrorIs(t, err, ErrUnsupportedImage)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		_, err := DecodeImage([]byte("\x89PNG\r\n\x1a\n\x00\x00"))
+		assert.ErrorIs(t, err, ErrInvalidImage)
+	})
+
+	t.Run("TooManyPixels", func(t *testing.T) {
+		ihdr := binary.BigEndian.AppendUint32(nil, 20000)
+		ihdr = binary.BigEndian.AppendUint32(ihdr, 20000)
+		ihdr = append(ihdr, 8, 2, 0, 0, 0)
+		chunk := append([]byte("IHDR"), ihdr...)
+		data := append([]byte("\x89PNG\r\n\x1a\n"), binary.BigEndian.AppendUint32(nil, uint32(len(ihdr)))...)
+		data = append(data, chunk...)
+		data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(chunk))
+
+		_, err := DecodeImage(data)
+		assert.ErrorIs(t, err, ErrImageTooLarge)
+	})
+}
+
+func TestJpegOrientation(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, halves(16, 16, false, red, blue), nil))
+	plain := buf.Bytes()
+
+	t.Run("StandaloneMarkers", func(t *testing.T) {
+		// image/jpeg acepta un RST0 y bytes de relleno antes del SOF; el EXIF que sigue se lee igual.
+		data := append([]byte{0xFF, 0xD8, 0xFF, 0xD0, 0xFF}, withOrientation(plain, 6)[2:]...)
+
+		img, err := DecodeImage(data)
+		require.NoError(t, err)
+		assert.Equal(t, 6, img.Orientation)
+
+		tem := append([]byte{0xFF, 0xD8, 0xFF, 0x01}, withOrientation(plain, 3)[2:]...)
+		assert.Equal(t, 3, jpegOrientation(tem))
+	})
+
+	t.Run("InvalidSegmentLength", func(t *testing.T) {
+		for name, header := range map[string][]byte{
+			"Zero":      {0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x00},
+			"One":       {0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01, 0xFF, 0xDA},
+			"PastEnd":   {0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x20, 'E', 'x', 'i', 'f', 0x00, 0x00},
+			"NoLength":  {0xFF, 0xD8, 0xFF, 0xE1, 0x00},
+			"OnlyFills": {0xFF, 0xD8, 0xFF, 0xFF, 0xFF},
+		} {
+			assert.Equal(t, 1, jpegOrientation(header), name)
+		}
+	})
+}
+
+func TestThumbnail(t *testing.T) {
+	t.Run("CropsCenterSquare", func(t *testing.T) {
+		img, err := DecodeImage(encodePNG(t, halves(200, 100, true, red, blue)))
+		require.NoError(t, err)
+
+		thumb := img.Thumbnail(64)
+		assert.Equal(t, image.Rect(0, 0, 64, 64), thumb.Bounds())
+		assertDominant(t, thumb, 0, 0, red)
+		assertDominant(t, thumb, 63, 63, blue)
+	})
+
+	t.Run("Enlarges", func(t *testing.T) {
+		img, err := DecodeImage(encodePNG(t, halves(10, 10, false, red, blue)))
+		require.NoError(t, err)
+
+		thumb := img.Thumbnail(256)
+		assertDominant(t, thumb, 128, 0, red)
+		assertDominant(t, thumb, 128, 255, blue)
+	})
+
+	t.Run("AppliesExifOrientation", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, jpeg.Encode(&buf, halves(64, 64, false, red, blue), nil))
+
+		img, err := DecodeImage(withOrientation(buf.Bytes(), 6))
+		require.NoError(t, err)
+		assert.Equal(t, 6, img.Orientation)
+
+		// Rotada 90° en sentido horario, la mitad de arriba queda a la derecha.
+		thumb := img.Thumbnail(64)
+		assertDominant(t, thumb, 0, 32, blue)
+		assertDominant(t, thumb, 63, 32, red)
+	})
+}
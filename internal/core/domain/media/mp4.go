@@ -0,0 +1,102 @@
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrUnknownDuration indica que el contenido no es un MP4/MOV con la duración en su encabezado.
+var ErrUnknownDuration = errors.New("unknown media duration")
+
+// maxBoxes acota cuántas cajas se recorren, para que un archivo malformado no se lea entero.
+const maxBoxes = 1024
+
+// MP4Duration lee la duración de un archivo MP4 o MOV desde la caja mvhd dentro de moov. Solo
+// lee los encabezados de las cajas, así que moov puede estar al principio o al final del archivo.
+func MP4Duration(r io.ReadSeeker) (time.Duration, error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	moov, moovEnd, err := findBox(r, 0, size, "moov")
+	if err != nil {
+		return 0, err
+	}
+	mvhd, _, err := findBox(r, moov, moovEnd, "mvhd")
+	if err != nil {
+		return 0, err
+	}
+	return readMvhd(r, mvhd)
+}
+
+// findBox busca la caja kind entre start y end y retorna dónde empieza y termina su contenido.
+func findBox(r io.ReadSeeker, start, end int64, kind string) (int64, int64, error) {
+	header := make([]byte, 16)
+	for offset, boxes := start, 0; offset+8 <= end && boxes < maxBoxes; boxes++ {
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return 0, 0, err
+		}
+		if _, err := io.ReadFull(r, header[:8]); err != nil {
+			return 0, 0, ErrUnknownDuration
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(header[:4]))
+		headerSize := int64(8)
+		switch boxSize {
+		case 0:
+			boxSize = end - offset
+		case 1:
+			if _, err := io.ReadFull(r, header[8:16]); err != nil {
+				return 0, 0, ErrUnknownDuration
+			}
+			boxSize = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+		if boxSize < headerSize || offset+boxSize > end {
+			return 0, 0, ErrUnknownDuration
+		}
+
+		if string(header[4:8]) == kind {
+			return offset + headerSize, offset + boxSize, nil
+		}
+		offset += boxSize
+	}
+	return 0, 0, ErrUnknownDuration
+}
+
+// readMvhd interpreta la escala de tiempo y la duración de mvhd, que en la versión 1 usa campos de 64 bits.
+func readMvhd(r io.ReadSeeker, offset int64) (time.Duration, error) {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	fields := make([]byte, 32)
+	if _, err := io.ReadFull(r, fields[:4]); err != nil {
+		return 0, ErrUnknownDuration
+	}
+
+	var timescale, duration uint64
+	switch fields[0] {
+	case 0:
+		if _, err := io.ReadFull(r, fields[4:20]); err != nil {
+			return 0, ErrUnknownDuration
+		}
+		timescale = uint64(binary.BigEndian.Uint32(fields[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(fields[16:20]))
+	case 1:
+		if _, err := io.ReadFull(r, fields[4:32]); err != nil {
+			return 0, ErrUnknownDuration
+		}
+		timescale = uint64(binary.BigEndian.Uint32(fields[20:24]))
+		duration = binary.BigEndian.Uint64(fields[24:32])
+	default:
+		return 0, ErrUnknownDuration
+	}
+
+	if timescale == 0 {
+		return 0, ErrUnknownDuration
+	}
+	return time.Duration(float64(duration) / float64(timescale) * float64(time.Second)), nil
+}
@@ -0,0 +1,61 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func box(kind string, content ...[]byte) []byte {
+	body := bytes.Join(content, nil)
+	out := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(out, kind...), body...)
+}
+
+func mvhdV0(timescale, duration uint32) []byte {
+	fields := make([]byte, 20)
+	binary.BigEndian.PutUint32(fields[12:], timescale)
+	binary.BigEndian.PutUint32(fields[16:], duration)
+	return box("mvhd", fields, make([]byte, 80))
+}
+
+func TestMP4Duration(t *testing.T) {
+	t.Run("MoovAtEnd", func(t *testing.T) {
+		file := bytes.Join([][]byte{
+			box("ftyp", []byte("isom\x00\x00\x02\x00")),
+			box("mdat", make([]byte, 4096)),
+			box("moov", mvhdV0(1000, 12500), box("trak")),
+		}, nil)
+
+		duration, err := MP4Duration(bytes.NewReader(file))
+		require.NoError(t, err)
+		assert.Equal(t, 12500*time.Millisecond, duration)
+	})
+
+	t.Run("Version1", func(t *testing.T) {
+		fields := make([]byte, 32)
+		fields[0] = 1
+		binary.BigEndian.PutUint32(fields[20:], 90000)
+		binary.BigEndian.PutUint64(fields[24:], 90000*3)
+		file := box("moov", box("mvhd", fields))
+
+		duration, err := MP4Duration(bytes.NewReader(file))
+		require.NoError(t, err)
+		assert.Equal(t, 3*time.Second, duration)
+	})
+
+	t.Run("NotMP4", func(t *testing.T) {
+		_, err := MP4Duration(bytes.NewReader([]byte("\x1aE\xdf\xa3 webm content")))
+		assert.ErrorIs(t, err, ErrUnknownDuration)
+	})
+
+	t.Run("TruncatedBox", func(t *testing.T) {
+		file := box("moov", mvhdV0(1000, 1000))
+		_, err := MP4Duration(bytes.NewReader(file[:len(file)-40]))
+		assert.ErrorIs(t, err, ErrUnknownDuration)
+	})
+}
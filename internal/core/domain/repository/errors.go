@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorKind clasifica los errores de dominio para que los adaptadores decidan cómo responderlos.
+type ErrorKind string
+
+const (
+	KindNotFound     ErrorKind = "not_found"
+	KindConflict     ErrorKind = "conflict"
+	KindValidation   ErrorKind = "validation"
+	KindUnauthorized ErrorKind = "unauthorized"
+	KindForbidden    ErrorKind = "forbidden"
+	KindUnavailable  ErrorKind = "unavailable"
+	KindInternal     ErrorKind = "internal"
+
+	// KindPreconditionFailed indica que el registro cambió desde la versión que el cliente esperaba.
+	KindPreconditionFailed ErrorKind = "precondition_failed"
+	// KindPreconditionRequired indica que la escritura exige indicar la versión esperada.
+	KindPreconditionRequired ErrorKind = "precondition_required"
+	// KindUnsupportedMediaType indica que el contenido enviado no es de un tipo aceptado.
+	KindUnsupportedMediaType ErrorKind = "unsupported_media_type"
+	// KindTooLarge indica que el contenido enviado supera el tamaño permitido.
+	KindTooLarge ErrorKind = "too_large"
+)
+
+// Error es un error de dominio con su clasificación y, opcionalmente, la causa original.
+type Error struct {
+	kind    ErrorKind
+	message string
+	err     error
+}
+
+// NewError crea un error de dominio sin causa.
+func NewError(kind ErrorKind, format string, args ...any) *Error {
+	return &Error{kind: kind, message: fmt.Sprintf(format, args...)}
+}
+
+// WrapError crea un error de dominio que conserva la causa para errors.Is/errors.As.
+func WrapError(kind ErrorKind, err error, format string, args ...any) *Error {
+	return &Error{kind: kind, message: fmt.Sprintf(format, args...), err: err}
+}
+
+func (e *Error) Error() string {
+	if e.err != nil {
+		return e.message + ": " + e.err.Error()
+	}
+	return e.message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Kind() ErrorKind {
+	return e.kind
+}
+
+// KindOf retorna la clasificación del error; los errores sin clasificar son internos.
+func KindOf(err error) ErrorKind {
+	var kinded interface{ Kind() ErrorKind }
+	if errors.As(err, &kinded) {
+		return kinded.Kind()
+	}
+	return KindInternal
+}
+
+// FieldError es una regla incumplida por un campo del request, identificado por su ruta JSON.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError agrupa todas las reglas incumplidas por un request para responderlas juntas.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Field + " " + field.Message
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Kind() ErrorKind {
+	return KindValidation
+}
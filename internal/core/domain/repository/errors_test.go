@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	t.Run("Message", func(t *testing.T) {
+		err := NewError(KindNotFound, "user with id %s not found", "123")
+		assert.EqualError(t, err, "user with id 123 not found")
+		assert.Equal(t, KindNotFound, KindOf(err))
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		err := WrapError(KindUnavailable, sql.ErrConnDone, "error executing statement")
+		assert.EqualError(t, err, "error executing statement: "+sql.ErrConnDone.Error())
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+	})
+
+	t.Run("KindThroughWrapping", func(t *testing.T) {
+		err := fmt.Errorf("context: %w", NewError(KindConflict, "duplicated"))
+		assert.Equal(t, KindConflict, KindOf(err))
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		err := &ValidationError{Fields: []FieldError{
+			{Field: "name", Message: "is required"},
+			{Field: "difficulty", Message: "must be at most 5"},
+		}}
+		assert.EqualError(t, err, "validation failed: name is required; difficulty must be at most 5")
+		assert.Equal(t, KindValidation, KindOf(err))
+	})
+
+	t.Run("Unclassified", func(t *testing.T) {
+		assert.Equal(t, KindInternal, KindOf(errors.New("boom")))
+	})
+}
@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+)
+
+// Action es el tipo de escritura que registra un evento de auditoría.
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionRestore Action = "restore"
+)
+
+// Tipos de registro auditados, que se usan como entity en GET /audit.
+const (
+	EntityUser      = "user"
+	EntityChallenge = "challenge"
+	EntityVideo     = "video"
+)
+
+// Event es una escritura auditada. Before y After son el JSON del registro antes y después del
+// cambio; en una actualización solo llevan los campos que cambiaron.
+type Event struct {
+	ID         string
+	Actor      string
+	Action     Action
+	EntityType string
+	EntityID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+	RequestID  string
+	CreatedAt  time.Time
+}
+
+type ListEvents struct {
+	entity.PageRequest
+	Entity string `form:"entity" binding:"omitempty,oneof=user challenge video"`
+	ID     string `form:"id"`
+	Actor  string `form:"actor"`
+}
+
+// Query filtra los eventos por registro y actor, del más reciente al más antiguo.
+func (l ListEvents) Query() entity.ListQuery {
+	query := entity.ListQuery{Sort: entity.DefaultSort}
+	if l.Entity != "" {
+		query.Filters = append(query.Filters, entity.Filter{Field: "entity_type", Op: entity.OpEq, Value: l.Entity})
+	}
+	if l.ID != "" {
+		query.Filters = append(query.Filters, entity.Filter{Field: "entity_id", Op: entity.OpEq, Value: l.ID})
+	}
+	if l.Actor != "" {
+		query.Filters = append(query.Filters, entity.Filter{Field: "actor", Op: entity.OpEq, Value: l.Actor})
+	}
+	return query
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+)
+
+var (
+	ErrInvalidCredentials  = entity.NewError(entity.KindUnauthorized, "invalid email or password")
+	ErrInvalidRefreshToken = entity.NewError(entity.KindUnauthorized, "invalid refresh token")
+)
+
+type Login struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type Refresh struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type Logout struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RefreshToken es un refresh token persistido. Solo se guarda el hash del valor entregado
+// al cliente; FamilyID agrupa las rotaciones de una misma sesión para revocarlas juntas.
+type RefreshToken struct {
+	ID         string
+	UserID     string
+	FamilyID   string
+	TokenHash  string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+	ReplacedBy string
+}
@@ -1,27 +1,57 @@
 package challenges
 
-import "time"
+import (
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+)
 
 type Challenge struct {
 	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Difficulty  int       `json:"difficulty"`
+	Title       string    `json:"title" binding:"required,max=200"`
+	Description string    `json:"description" binding:"max=5000"`
+	Difficulty  int       `json:"difficulty" binding:"required,min=1,max=5"`
+	CreatedBy   string    `json:"-"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
-type GetChallenge struct {
-	ID string `json:"id"`
+// UpdateChallenge lleva solo los campos que cambian; un campo nil conserva su valor actual.
+type UpdateChallenge struct {
+	ID          string  `json:"id"`
+	Title       *string `json:"title" binding:"omitempty,min=1,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=5000" patch:"nullable"`
+	Difficulty  *int    `json:"difficulty" binding:"omitempty,min=1,max=5"`
+	Version     *int64  `json:"-"`
 }
 
-type UpdateChallenge struct {
-	ID          string `json:"id"`
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
-	Difficulty  int    `json:"difficulty,omitempty"`
+// ReplaceChallenge es el body de un PUT, que reemplaza todos los campos editables.
+type ReplaceChallenge struct {
+	Title       string `json:"title" binding:"required,max=200"`
+	Description string `json:"description" binding:"max=5000"`
+	Difficulty  int    `json:"difficulty" binding:"required,min=1,max=5"`
+}
+
+func (r ReplaceChallenge) Update(id string) *UpdateChallenge {
+	return &UpdateChallenge{ID: id, Title: &r.Title, Description: &r.Description, Difficulty: &r.Difficulty}
+}
+
+type ListChallenges struct {
+	entity.PageRequest
+	entity.ListFilter
+	DifficultyMin *int   `form:"difficulty_min" binding:"omitempty,min=1,max=5"`
+	DifficultyMax *int   `form:"difficulty_max" binding:"omitempty,min=1,max=5"`
+	Q             string `form:"q"`
 }
 
-type DeleteChallenge struct {
-	ID string `json:"id"`
+func (l ListChallenges) Query() entity.ListQuery {
+	query := l.ListFilter.Query()
+	if l.DifficultyMin != nil {
+		query.Filters = append(query.Filters, entity.Filter{Field: "difficulty", Op: entity.OpGte, Value: *l.DifficultyMin})
+	}
+	if l.DifficultyMax != nil {
+		query.Filters = append(query.Filters, entity.Filter{Field: "difficulty", Op: entity.OpLte, Value: *l.DifficultyMax})
+	}
+	query.Search = l.Q
+	return query
 }
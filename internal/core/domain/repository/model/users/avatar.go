@@ -0,0 +1,57 @@
+package users
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// AvatarSizes son los lados en píxeles de las miniaturas que se generan de cada avatar.
+var AvatarSizes = []int{64, 256}
+
+// AvatarKey ubica la miniatura de un tamaño, como avatars/<usuario>/<uuid>/64.jpg.
+func AvatarKey(userID, file string, size int) string {
+	name, ext, _ := strings.Cut(file, ".")
+	return "avatars/" + userID + "/" + name + "/" + strconv.Itoa(size) + "." + ext
+}
+
+// AvatarKeys retorna las llaves de todas las miniaturas del avatar al que apunta imagePath, o nil
+// si imagePath no apunta a un avatar propio del usuario.
+func AvatarKeys(userID, imagePath string) []string {
+	file, ok := AvatarFile(imagePath, userID)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, len(AvatarSizes))
+	for i, size := range AvatarSizes {
+		keys[i] = AvatarKey(userID, file, size)
+	}
+	return keys
+}
+
+// AvatarFile reconoce un image_path que apunta a un avatar propio del usuario y retorna su archivo.
+// Un image_path que el cliente puso a mano, hacia otro servidor, no se toca.
+func AvatarFile(imagePath, userID string) (string, bool) {
+	parsed, err := url.Parse(imagePath)
+	if err != nil {
+		return "", false
+	}
+	dir, file := path.Split(parsed.Path)
+	if !strings.HasSuffix(dir, "/users/"+userID+"/avatar/") || !ValidAvatarFile(file) {
+		return "", false
+	}
+	return file, true
+}
+
+// ValidAvatarFile reconoce el nombre de archivo de un avatar: un uuid con extensión jpg o png.
+func ValidAvatarFile(file string) bool {
+	name, ext, _ := strings.Cut(file, ".")
+	if ext != "jpg" && ext != "png" {
+		return false
+	}
+	id, err := uuid.Parse(name)
+	return err == nil && id.String() == name
+}
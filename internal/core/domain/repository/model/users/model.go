@@ -1,27 +1,114 @@
 package users
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+
+	"golang.org/x/crypto/bcrypt"
+)
 
 type User struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	ImagePath string    `json:"image_path,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           int       `json:"id"`
+	Name         string    `json:"name" binding:"required,max=100"`
+	Email        string    `json:"email" binding:"required,max=254,rfcemail"`
+	ImagePath    string    `json:"image_path,omitempty" binding:"omitempty,max=2048,imageurl"`
+	Role         string    `json:"role,omitempty" binding:"omitempty,oneof=admin creator viewer"`
+	Password     string    `json:"password,omitempty" binding:"omitempty,min=8,maxbytes=72"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
-type GetUser struct {
-	Id string `json:"id"`
+// NormalizeEmail retorna el email como se guarda y se compara: en minúsculas, porque el login no
+// distingue mayúsculas y A@x.com y a@x.com son el mismo usuario.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(email)
+}
+
+// HashPassword reemplaza la contraseña en texto plano por su hash bcrypt.
+func (u *User) HashPassword() error {
+	if u.Password == "" {
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return &entity.ValidationError{Fields: []entity.FieldError{{Field: "password", Message: "must be at most 72 bytes long"}}}
+	}
+	if err != nil {
+		return fmt.Errorf("error hashing password: %w", err)
+	}
+
+	u.PasswordHash = string(hash)
+	u.Password = ""
+	return nil
 }
 
+// UpdateUser lleva solo los campos que cambian; un campo nil conserva su valor actual.
+// El tag patch:"nullable" marca los campos que un merge patch puede vaciar con null.
 type UpdateUser struct {
-	Id        string `json:"id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	ImagePath string `json:"image_path,omitempty"`
+	Id        string  `json:"id"`
+	Name      *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Email     *string `json:"email" binding:"omitempty,max=254,rfcemail"`
+	ImagePath *string `json:"image_path" binding:"omitempty,max=2048,imageurl" patch:"nullable"`
+	// Version es la versión que el cliente espera modificar (If-Match); nil no condiciona la escritura.
+	Version *int64 `json:"-"`
+}
+
+// ReplaceUser es el body de un PUT, que reemplaza todos los campos editables.
+type ReplaceUser struct {
+	Name      string `json:"name" binding:"required,max=100"`
+	Email     string `json:"email" binding:"required,max=254,rfcemail"`
+	ImagePath string `json:"image_path,omitempty" binding:"omitempty,max=2048,imageurl"`
+}
+
+// Update convierte el reemplazo en una actualización de todos los campos; image_path ausente se vacía.
+func (r ReplaceUser) Update(id string) *UpdateUser {
+	return &UpdateUser{Id: id, Name: &r.Name, Email: &r.Email, ImagePath: &r.ImagePath}
+}
+
+// GetUserCredentials busca las credenciales de un usuario por email para el login.
+type GetUserCredentials struct {
+	Email string `json:"email"`
+}
+
+type ListUsers struct {
+	entity.PageRequest
+	entity.ListFilter
+	EmailDomain string `form:"email_domain"`
+}
+
+func (l ListUsers) Query() entity.ListQuery {
+	query := l.ListFilter.Query()
+	if l.EmailDomain != "" {
+		query.Filters = append(query.Filters, entity.Filter{Field: "email", Op: entity.OpEndsWith, Value: "@" + strings.TrimPrefix(l.EmailDomain, "@")})
+	}
+	return query
+}
+
+// UploadAvatar es la imagen que reemplaza el avatar de un usuario. BaseURL es la URL pública del
+// servidor, con la que se arma el image_path que sirve el avatar.
+type UploadAvatar struct {
+	ID      string
+	Body    io.Reader
+	BaseURL string
+	Version *int64
+}
+
+// GetAvatar pide una de las miniaturas de un avatar; File es el último segmento de su image_path.
+type GetAvatar struct {
+	UserID string `form:"-"`
+	File   string `form:"-"`
+	Size   int    `form:"size" binding:"omitempty,oneof=64 256"`
 }
 
-type DeleteUser struct {
-	Id string `json:"id"`
+// Avatar es una miniatura abierta para servirla.
+type Avatar struct {
+	Body        io.ReadSeekCloser
+	ContentType string
 }
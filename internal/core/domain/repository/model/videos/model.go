@@ -1,25 +1,140 @@
 package videos
 
-import "time"
+import (
+	"io"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+)
 
 type Videos struct {
 	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
+	Title       string    `json:"title" binding:"required,max=200"`
+	Description string    `json:"description" binding:"max=5000"`
+	UserID      string    `json:"user_id,omitempty" binding:"omitempty,uuid"`
+	ChallengeID string    `json:"challenge_id,omitempty" binding:"omitempty,uuid"`
+	CreatedBy   string    `json:"-"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
-type GetVideo struct {
-	ID string `json:"id"`
+// UpdateVideo lleva solo los campos que cambian; un campo nil conserva su valor actual y
+// un challenge_id vacío desvincula el video de su challenge.
+type UpdateVideo struct {
+	ID          string  `json:"id"`
+	Title       *string `json:"title" binding:"omitempty,min=1,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=5000" patch:"nullable"`
+	ChallengeID *string `json:"challenge_id" binding:"omitempty,uuid" patch:"nullable"`
+	Version     *int64  `json:"-"`
 }
 
-type UpdateVideo struct {
-	ID          string `json:"id"`
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
+// ReplaceVideo es el body de un PUT, que reemplaza todos los campos editables.
+type ReplaceVideo struct {
+	Title       string `json:"title" binding:"required,max=200"`
+	Description string `json:"description" binding:"max=5000"`
+	ChallengeID string `json:"challenge_id,omitempty" binding:"omitempty,uuid"`
+}
+
+func (r ReplaceVideo) Update(id string) *UpdateVideo {
+	return &UpdateVideo{ID: id, Title: &r.Title, Description: &r.Description, ChallengeID: &r.ChallengeID}
+}
+
+type ListVideos struct {
+	entity.PageRequest
+	entity.ListFilter
+	UserID      string `form:"user_id" binding:"omitempty,uuid"`
+	ChallengeID string `form:"challenge_id" binding:"omitempty,uuid"`
+	Q           string `form:"q"`
+}
+
+func (l ListVideos) Query() entity.ListQuery {
+	query := l.ListFilter.Query()
+	if l.UserID != "" {
+		query.Filters = append(query.Filters, entity.Filter{Field: "user_id", Op: entity.OpEq, Value: l.UserID})
+	}
+	if l.ChallengeID != "" {
+		query.Filters = append(query.Filters, entity.Filter{Field: "challenge_id", Op: entity.OpEq, Value: l.ChallengeID})
+	}
+	query.Search = l.Q
+	return query
+}
+
+// UploadContent es un archivo de video recibido en un solo request, como un multipart/form-data.
+type UploadContent struct {
+	ID          string
+	ContentType string
+	// Size es el largo del archivo, o -1 si no se conoce antes de leerlo.
+	Size    int64
+	Body    io.Reader
+	Version *int64
+}
+
+// UpdateVideoContent guarda en el video los metadatos de un archivo ya guardado en el BlobStore.
+type UpdateVideoContent struct {
+	ID          string
+	Key         string
+	Size        int64
+	ContentType string
+	Checksum    string
+	Duration    *float64
+	Version     *int64
+	// Previous es la llave del archivo que se reemplaza, vacía si el video no tenía. Si el video ya
+	// tiene otro archivo, porque otro upload lo reemplazó antes, no se guarda y es KindConflict.
+	Previous string
+}
+
+// GetContent pide el archivo de un video para descargarlo.
+type GetContent struct {
+	ID string
+}
+
+// Content es el archivo de un video abierto para leerlo desde cualquier posición.
+type Content struct {
+	Body        io.ReadSeekCloser
+	Size        int64
+	ContentType string
+	Checksum    string
+	UpdatedAt   time.Time
+}
+
+// CreateUpload inicia un upload por partes de un archivo de Length bytes.
+type CreateUpload struct {
+	VideoID     string
+	Length      int64
+	ContentType string
+}
+
+// VideoUpload es un upload por partes en curso; Offset es la cantidad de bytes ya recibidos.
+type VideoUpload struct {
+	ID          string
+	VideoID     string
+	Length      int64
+	Offset      int64
+	ContentType string
+	CreatedBy   string
+	CreatedAt   time.Time
+	Parts       []UploadPart
+}
+
+// UploadPart es una parte recibida de un upload, guardada en el BlobStore con Key.
+type UploadPart struct {
+	UploadID string
+	Offset   int64
+	Size     int64
+	Key      string
+}
+
+type GetUpload struct {
+	VideoID string
+	ID      string
 }
 
-type DeleteVideo struct {
-	ID string `json:"id"`
+// AppendUpload es una parte enviada con PATCH; Offset debe ser la cantidad de bytes ya recibidos.
+type AppendUpload struct {
+	VideoID string
+	ID      string
+	Offset  int64
+	// Size es el largo de la parte, o -1 si no se conoce antes de leerla.
+	Size int64
+	Body io.Reader
 }
@@ -0,0 +1,71 @@
+package repository
+
+// MaxPageSize es la cantidad máxima de registros retornados por página.
+const MaxPageSize = 10
+
+// PageRequest contiene los parámetros de paginación de un listado.
+//
+// Se usa paginación por offset (page/page_size) salvo que se envíe un cursor,
+// en cuyo caso se usa paginación por keyset sobre (created_at, id).
+type PageRequest struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+	Cursor   string `form:"cursor"`
+}
+
+// Normalize aplica los valores por defecto y el tamaño máximo de página.
+func (p *PageRequest) Normalize() {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.PageSize < 1 || p.PageSize > MaxPageSize {
+		p.PageSize = MaxPageSize
+	}
+}
+
+// Offset retorna la cantidad de registros a saltar en paginación por offset.
+func (p PageRequest) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// Page es una página de resultados retornada por los repositorios.
+type Page[T any] struct {
+	Items      []T
+	Total      int
+	NextCursor string
+	PrevCursor string
+}
+
+// Pagination describe la página retornada dentro de ResponseWithList.
+type Pagination struct {
+	Total      int    `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+}
+
+// Data convierte los elementos de la página al formato de ResponseWithList.
+func (p *Page[T]) Data() []interface{} {
+	data := make([]interface{}, 0, len(p.Items))
+	for _, item := range p.Items {
+		data = append(data, item)
+	}
+	return data
+}
+
+// NewPagination construye la descripción de la página para la respuesta.
+func NewPagination[T any](request PageRequest, page *Page[T]) *Pagination {
+	pagination := &Pagination{
+		Total:      page.Total,
+		PageSize:   request.PageSize,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+	if request.Cursor == "" {
+		pagination.Page = request.Page
+	}
+	return pagination
+}
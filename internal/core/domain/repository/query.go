@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"strings"
+	"time"
+)
+
+// FilterOp es el operador de comparación de un filtro de listado.
+type FilterOp string
+
+const (
+	OpEq       FilterOp = "eq"
+	OpGte      FilterOp = "gte"
+	OpLte      FilterOp = "lte"
+	OpEndsWith FilterOp = "ends_with"
+)
+
+// Filter restringe un listado comparando un campo con un valor.
+type Filter struct {
+	Field string
+	Op    FilterOp
+	Value any
+}
+
+// SortField es un criterio de ordenamiento sobre un campo expuesto por la API.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListQuery es la especificación ya interpretada de filtros, orden y búsqueda de un listado.
+type ListQuery struct {
+	Filters []Filter
+	Sort    []SortField
+	Search  string
+	// IncludeDeleted incluye los registros eliminados, que por defecto se excluyen.
+	IncludeDeleted bool
+}
+
+// DefaultSort ordena los listados del registro más reciente al más antiguo.
+var DefaultSort = []SortField{{Field: "created_at", Desc: true}}
+
+// ListFilter contiene los filtros comunes a todos los listados: ventanas de fechas y orden.
+type ListFilter struct {
+	CreatedFrom time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedFrom time.Time `form:"updated_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedTo   time.Time `form:"updated_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Sort        string    `form:"sort"`
+	// IncludeDeleted lista también los registros eliminados; solo un admin puede pedirlo.
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
+// Query convierte los parámetros comunes en una ListQuery.
+func (f ListFilter) Query() ListQuery {
+	query := ListQuery{Sort: ParseSort(f.Sort), IncludeDeleted: f.IncludeDeleted}
+
+	windows := []struct {
+		field string
+		op    FilterOp
+		value time.Time
+	}{
+		{"created_at", OpGte, f.CreatedFrom},
+		{"created_at", OpLte, f.CreatedTo},
+		{"updated_at", OpGte, f.UpdatedFrom},
+		{"updated_at", OpLte, f.UpdatedTo},
+	}
+	for _, window := range windows {
+		if !window.value.IsZero() {
+			query.Filters = append(query.Filters, Filter{Field: window.field, Op: window.op, Value: window.value.UTC()})
+		}
+	}
+
+	return query
+}
+
+// ParseSort interpreta una expresión como "-created_at,title"; el prefijo "-" indica orden descendente.
+func ParseSort(value string) []SortField {
+	var sort []SortField
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if strings.HasPrefix(part, "-") {
+			sort = append(sort, SortField{Field: strings.TrimPrefix(part, "-"), Desc: true})
+		} else {
+			sort = append(sort, SortField{Field: strings.TrimPrefix(part, "+")})
+		}
+	}
+
+	if len(sort) == 0 {
+		return DefaultSort
+	}
+	return sort
+}
+
+// SortString retorna la forma canónica de un ordenamiento, inversa a ParseSort.
+func SortString(sort []SortField) string {
+	parts := make([]string, 0, len(sort))
+	for _, field := range sort {
+		if field.Desc {
+			parts = append(parts, "-"+field.Field)
+		} else {
+			parts = append(parts, field.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
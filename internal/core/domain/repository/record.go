@@ -0,0 +1,43 @@
+package repository
+
+import "strings"
+
+// GetRecord pide un registro por id, el mismo para todas las entidades del CRUD genérico.
+type GetRecord struct {
+	ID             string `json:"id"`
+	IncludeDeleted bool   `form:"include_deleted"`
+	// Include son las relaciones a expandir, por ejemplo "user,challenge"; las entidades sin
+	// relaciones lo ignoran.
+	Include string `form:"include"`
+	// ForUpdate bloquea el registro hasta que termine la transacción en curso, para que el estado
+	// leído antes de una escritura siga siendo el actual al escribir. No llega desde el cliente.
+	ForUpdate bool `json:"-" form:"-"`
+}
+
+// Includes retorna las relaciones pedidas en Include.
+func (g GetRecord) Includes() []string {
+	var includes []string
+	for _, part := range strings.Split(g.Include, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			includes = append(includes, part)
+		}
+	}
+	return includes
+}
+
+// DeleteRecord marca un registro como eliminado.
+type DeleteRecord struct {
+	ID string `json:"id"`
+	// Version es la versión que el cliente espera eliminar (If-Match); nil no condiciona el borrado.
+	Version *int64 `json:"-"`
+}
+
+// RestoreRecord quita la marca de eliminación de un registro que todavía no se purgó.
+type RestoreRecord struct {
+	ID string `json:"id"`
+}
+
+// Versioned es un registro con la versión que los handlers publican como ETag.
+type Versioned interface {
+	GetVersion() int64
+}
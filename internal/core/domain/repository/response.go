@@ -3,11 +3,14 @@ package repository
 type Response struct {
 	Data   any    `json:"data,omitempty" mask:"struct"`
 	Result Result `json:"result"`
+	// Version es la versión del registro en Data, que los handlers publican como ETag; 0 si no aplica.
+	Version int64 `json:"-"`
 }
 
 type ResponseWithList struct {
-	Data   []interface{} `json:"data,omitempty" mask:"struct"`
-	Result Result        `json:"result"`
+	Data       []interface{} `json:"data,omitempty" mask:"struct"`
+	Pagination *Pagination   `json:"pagination,omitempty"`
+	Result     Result        `json:"result"`
 }
 
 type Result struct {
@@ -19,4 +22,5 @@ type Detail struct {
 	InternalCode string `json:"internalCode"`
 	Message      string `json:"message"`
 	Detail       string `json:"detail"`
+	Field        string `json:"field,omitempty"`
 }
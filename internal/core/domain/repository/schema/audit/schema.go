@@ -0,0 +1,15 @@
+package audit
+
+import "encoding/json"
+
+type EventResponse struct {
+	ID        string          `json:"id"`
+	Actor     string          `json:"actor,omitempty"`
+	Action    string          `json:"action"`
+	Entity    string          `json:"entity"`
+	EntityID  string          `json:"entity_id"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+	CreatedAt string          `json:"created_at"`
+}
@@ -1,16 +1,27 @@
 package challenges
 
 type ChallengeGetResponse struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Difficulty  int    `json:"difficulty"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID          string  `json:"id,omitempty"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Difficulty  int     `json:"difficulty"`
+	CreatedBy   string  `json:"created_by,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+	Version     int64   `json:"version"`
+	DeletedAt   *string `json:"deleted_at,omitempty"`
 }
 
+func (r ChallengeGetResponse) GetVersion() int64 { return r.Version }
+
 type ChallengeUpdateResponse struct {
+	ID          string `json:"id"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Difficulty  int    `json:"difficulty"`
+	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
+	Version     int64  `json:"version"`
 }
+
+func (r ChallengeUpdateResponse) GetVersion() int64 { return r.Version }
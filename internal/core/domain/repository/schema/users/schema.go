@@ -1,16 +1,35 @@
 package users
 
 type UsersGetResponse struct {
+	ID        string `json:"id,omitempty"`
 	Name      string `json:"name"`
 	Email     string `json:"email"`
 	ImagePath string `json:"image_path,omitempty"`
+	Role      string `json:"role,omitempty"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+	Version   int64  `json:"version"`
+	// DeletedAt solo aparece en los registros eliminados, visibles con include_deleted.
+	DeletedAt *string `json:"deleted_at,omitempty"`
 }
 
+func (r UsersGetResponse) GetVersion() int64 { return r.Version }
+
 type UsersUpdateResponse struct {
+	ID        string `json:"id"`
 	Name      string `json:"name"`
 	Email     string `json:"email"`
 	ImagePath string `json:"image_path,omitempty"`
+	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+	Version   int64  `json:"version"`
+}
+
+func (r UsersUpdateResponse) GetVersion() int64 { return r.Version }
+
+// UserCredentials son los datos que el login necesita para verificar la contraseña.
+type UserCredentials struct {
+	ID           string
+	Role         string
+	PasswordHash string
 }
@@ -1,14 +1,63 @@
 package videos
 
+import (
+	schemaChallenges "CrudPlatform/internal/core/domain/repository/schema/challenges"
+	schemaUsers "CrudPlatform/internal/core/domain/repository/schema/users"
+)
+
 type VideosGetResponse struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID          string  `json:"id,omitempty"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	UserID      string  `json:"user_id,omitempty"`
+	ChallengeID string  `json:"challenge_id,omitempty"`
+	CreatedBy   string  `json:"created_by,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+	Version     int64   `json:"version"`
+	DeletedAt   *string `json:"deleted_at,omitempty"`
+	// Content solo aparece cuando el video tiene un archivo subido.
+	Content *VideoContent `json:"content,omitempty"`
+
+	// User y Challenge solo se completan cuando se piden con include.
+	User      *schemaUsers.UsersGetResponse          `json:"user,omitempty"`
+	Challenge *schemaChallenges.ChallengeGetResponse `json:"challenge,omitempty"`
 }
 
+func (r VideosGetResponse) GetVersion() int64 { return r.Version }
+
 type VideosUpdateResponse struct {
+	ID          string `json:"id"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	ChallengeID string `json:"challenge_id,omitempty"`
+	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
+	Version     int64  `json:"version"`
+
+	Content *VideoContent `json:"content,omitempty"`
+}
+
+func (r VideosUpdateResponse) GetVersion() int64 { return r.Version }
+
+// VideoContent describe el archivo de un video, que se descarga de GET /video/:id/content.
+type VideoContent struct {
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
+	// Checksum es el SHA-256 del archivo en hexadecimal, que la descarga publica como ETag.
+	Checksum string `json:"checksum"`
+	// Duration son los segundos del video, si se pudieron leer del archivo.
+	Duration *float64 `json:"duration,omitempty"`
+	// Key es la llave del archivo en el BlobStore.
+	Key string `json:"-"`
+}
+
+// UploadResponse es el estado de un upload por partes.
+type UploadResponse struct {
+	ID      string `json:"id"`
+	VideoID string `json:"video_id"`
+	Length  int64  `json:"length"`
+	Offset  int64  `json:"offset"`
+	// Video es el video con su nuevo archivo, cuando la última parte completó el upload.
+	Video *VideosUpdateResponse `json:"video,omitempty"`
 }
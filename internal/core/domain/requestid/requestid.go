@@ -0,0 +1,16 @@
+package requestid
+
+import "context"
+
+type requestIDKey struct{}
+
+// With retorna una copia del contexto que transporta el id del request.
+func With(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext retorna el id del request, o vacío si el contexto no tiene uno.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
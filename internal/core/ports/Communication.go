@@ -1,56 +1,162 @@
 package ports
 
 import (
+	"context"
+	"io"
+	"time"
+
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/domain/health"
 	entity "CrudPlatform/internal/core/domain/repository"
+	modelAudit "CrudPlatform/internal/core/domain/repository/model/audit"
+	modelAuth "CrudPlatform/internal/core/domain/repository/model/auth"
 	modelChallenge "CrudPlatform/internal/core/domain/repository/model/challenges"
 	model "CrudPlatform/internal/core/domain/repository/model/users"
 	modelVideo "CrudPlatform/internal/core/domain/repository/model/videos"
 
+	schemaAudit "CrudPlatform/internal/core/domain/repository/schema/audit"
 	schemaChallenges "CrudPlatform/internal/core/domain/repository/schema/challenges"
 	schema "CrudPlatform/internal/core/domain/repository/schema/users"
 	schemaVideos "CrudPlatform/internal/core/domain/repository/schema/videos"
-
-	"github.com/gin-gonic/gin"
 )
 
+// Service es el caso de uso CRUD de una entidad: C es la solicitud de creación, U la de
+// actualización y L la de listado. Leer, eliminar y restaurar usan las solicitudes por id comunes.
+type Service[C, U, L any] interface {
+	Create(ctx context.Context, request *C) (*entity.Response, error)
+	Select(ctx context.Context, request *entity.GetRecord) (*entity.Response, error)
+	Update(ctx context.Context, request *U) (*entity.Response, error)
+	Delete(ctx context.Context, request *entity.DeleteRecord) (*entity.Response, error)
+	Restore(ctx context.Context, request *entity.RestoreRecord) (*entity.Response, error)
+	List(ctx context.Context, request *L) (*entity.ResponseWithList, error)
+}
+
 type CommunicationUserServices interface {
-	CreateUser(ctx *gin.Context, request *model.User) (*entity.Response, error)
-	SelectUser(ctx *gin.Context, request *model.GetUser) (*entity.Response, error)
-	UpdateUser(ctx *gin.Context, request *model.UpdateUser) (*entity.Response, error)
-	DeleteUser(ctx *gin.Context, request *model.DeleteUser) (*entity.Response, error)
+	Service[model.User, model.UpdateUser, model.ListUsers]
+}
+
+type CommunicationAuthServices interface {
+	Login(ctx context.Context, request *modelAuth.Login) (*entity.Response, error)
+	Refresh(ctx context.Context, request *modelAuth.Refresh) (*entity.Response, error)
+	Logout(ctx context.Context, request *modelAuth.Logout) (*entity.Response, error)
 }
 
 type CommunicationChallengeServices interface {
-	CreateChallenge(ctx *gin.Context, request *modelChallenge.Challenge) (*entity.Response, error)
-	SelectChallenge(ctx *gin.Context, request *modelChallenge.GetChallenge) (*entity.Response, error)
-	UpdateChallenge(ctx *gin.Context, request *modelChallenge.UpdateChallenge) (*entity.Response, error)
-	DeleteChallenge(ctx *gin.Context, request *modelChallenge.DeleteChallenge) (*entity.Response, error)
+	Service[modelChallenge.Challenge, modelChallenge.UpdateChallenge, modelChallenge.ListChallenges]
 }
 
 type CommunicationVideoServices interface {
-	CreateVideo(ctx *gin.Context, request *modelVideo.Videos) (*entity.Response, error)
-	SelectVideo(ctx *gin.Context, request *modelVideo.GetVideo) (*entity.Response, error)
-	UpdateVideo(ctx *gin.Context, request *modelVideo.UpdateVideo) (*entity.Response, error)
-	DeleteVideo(ctx *gin.Context, request *modelVideo.DeleteVideo) (*entity.Response, error)
+	Service[modelVideo.Videos, modelVideo.UpdateVideo, modelVideo.ListVideos]
+}
+
+type CommunicationVideoContentServices interface {
+	UploadContent(ctx context.Context, request *modelVideo.UploadContent) (*entity.Response, error)
+	OpenContent(ctx context.Context, request *modelVideo.GetContent) (*modelVideo.Content, error)
+	CreateUpload(ctx context.Context, request *modelVideo.CreateUpload) (*entity.Response, error)
+	SelectUpload(ctx context.Context, request *modelVideo.GetUpload) (*entity.Response, error)
+	AppendUpload(ctx context.Context, request *modelVideo.AppendUpload) (*entity.Response, error)
+	DeleteUpload(ctx context.Context, request *modelVideo.GetUpload) (*entity.Response, error)
+}
+
+type CommunicationAvatarServices interface {
+	UploadAvatar(ctx context.Context, request *model.UploadAvatar) (*entity.Response, error)
+	OpenAvatar(ctx context.Context, request *model.GetAvatar) (*model.Avatar, error)
+}
+
+// CommunicationHealthServices responde los probes de liveness y readiness.
+type CommunicationHealthServices interface {
+	Live(ctx context.Context) *health.Report
+	Ready(ctx context.Context) *health.Report
+	// Drain hace fallar Ready desde ahora; el servidor lo llama al empezar a apagarse.
+	Drain()
+}
+
+type CommunicationAuditServices interface {
+	ListAuditEvents(ctx context.Context, request *modelAudit.ListEvents) (*entity.ResponseWithList, error)
+}
+
+// Repository guarda los registros de una entidad con borrado lógico. T es el registro leído y W
+// el que retornan las escrituras, que no incluye los campos calculados al leer.
+type Repository[C, U, L, T, W any] interface {
+	Create(ctx context.Context, request *C) (string, error)
+	Select(ctx context.Context, request *entity.GetRecord) (*T, error)
+	Update(ctx context.Context, request *U) (*W, error)
+	Delete(ctx context.Context, request *entity.DeleteRecord) error
+	Restore(ctx context.Context, request *entity.RestoreRecord) (*W, error)
+	List(ctx context.Context, request *L) (*entity.Page[T], error)
+	DBPurger
 }
 
 type DBRepositoryUsers interface {
-	CreateUser(ctx *gin.Context, request *model.User) (string, error)
-	SelectUser(ctx *gin.Context, request *model.GetUser) (*schema.UsersGetResponse, error)
-	UpdateUser(ctx *gin.Context, request *model.UpdateUser) (*schema.UsersUpdateResponse, error)
-	DeleteUser(ctx *gin.Context, request *model.DeleteUser) error
+	Repository[model.User, model.UpdateUser, model.ListUsers, schema.UsersGetResponse, schema.UsersUpdateResponse]
+	SelectUserCredentials(ctx context.Context, request *model.GetUserCredentials) (*schema.UserCredentials, error)
+}
+
+type DBRepositoryTokens interface {
+	CreateRefreshToken(ctx context.Context, token *modelAuth.RefreshToken) error
+	SelectRefreshToken(ctx context.Context, tokenHash string) (*modelAuth.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, current *modelAuth.RefreshToken, next *modelAuth.RefreshToken) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
 }
 
 type DBRepositoryChallenge interface {
-	CreateChallenge(ctx *gin.Context, request *modelChallenge.Challenge) (string, error)
-	SelectChallenge(ctx *gin.Context, request *modelChallenge.GetChallenge) (*schemaChallenges.ChallengeGetResponse, error)
-	UpdateChallenge(ctx *gin.Context, request *modelChallenge.UpdateChallenge) (*schemaChallenges.ChallengeUpdateResponse, error)
-	DeleteChallenge(ctx *gin.Context, request *modelChallenge.DeleteChallenge) error
+	Repository[modelChallenge.Challenge, modelChallenge.UpdateChallenge, modelChallenge.ListChallenges, schemaChallenges.ChallengeGetResponse, schemaChallenges.ChallengeUpdateResponse]
 }
 
 type DBRepositoryVideo interface {
-	CreateVideo(ctx *gin.Context, request *modelVideo.Videos) (string, error)
-	SelectVideo(ctx *gin.Context, request *modelVideo.GetVideo) (*schemaVideos.VideosGetResponse, error)
-	UpdateVideo(ctx *gin.Context, request *modelVideo.UpdateVideo) (*schemaVideos.VideosUpdateResponse, error)
-	DeleteVideo(ctx *gin.Context, request *modelVideo.DeleteVideo) error
+	Repository[modelVideo.Videos, modelVideo.UpdateVideo, modelVideo.ListVideos, schemaVideos.VideosGetResponse, schemaVideos.VideosUpdateResponse]
+	UpdateVideoContent(ctx context.Context, request *modelVideo.UpdateVideoContent) (*schemaVideos.VideosUpdateResponse, error)
+}
+
+// DBRepositoryVideoUploads guarda el estado de los uploads por partes de los archivos de video.
+type DBRepositoryVideoUploads interface {
+	CreateVideoUpload(ctx context.Context, upload *modelVideo.VideoUpload) error
+	SelectVideoUpload(ctx context.Context, request *modelVideo.GetUpload) (*modelVideo.VideoUpload, error)
+	// AppendVideoUploadPart registra la parte y retorna el nuevo offset del upload.
+	AppendVideoUploadPart(ctx context.Context, part *modelVideo.UploadPart) (int64, error)
+	DeleteVideoUpload(ctx context.Context, request *modelVideo.GetUpload) error
+	// ExpireVideoUploads borra los uploads creados antes de before que no se completaron y retorna
+	// cuántos borró y las llaves de sus partes, que quien los expira debe borrar del BlobStore.
+	ExpireVideoUploads(ctx context.Context, before time.Time) (int64, []string, error)
+}
+
+// DBRepositoryAudit guarda los eventos de auditoría; los eventos no se modifican ni se borran.
+type DBRepositoryAudit interface {
+	CreateAuditEvent(ctx context.Context, event *modelAudit.Event) error
+	ListAuditEvents(ctx context.Context, request *modelAudit.ListEvents) (*entity.Page[schemaAudit.EventResponse], error)
+}
+
+// Transactor ejecuta fn en una transacción: las operaciones de los repositorios que reciben el
+// contexto de fn se confirman juntas, o ninguna si fn retorna un error.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// DBPurger borra definitivamente los registros eliminados antes de una fecha.
+type DBPurger interface {
+	// PurgeDeleted retorna cuántos registros borró y las llaves de los archivos del BlobStore que
+	// usaban, que quien purga debe borrar.
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, []string, error)
+}
+
+// BlobStore guarda contenido binario, como los archivos de los videos, identificado por una llave.
+type BlobStore interface {
+	// Put guarda el contenido de r en key, reemplazando el anterior; size es -1 si no se conoce.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Open abre key para leerlo desde cualquier posición. Una llave inexistente es KindNotFound.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// Delete borra key; borrar una llave inexistente no es un error.
+	Delete(ctx context.Context, key string) error
+}
+
+type TokenValidator interface {
+	Validate(ctx context.Context, token string) (*auth.Claims, error)
+}
+
+type TokenIssuer interface {
+	Issue(ctx context.Context, subject string, role auth.Role) (string, time.Duration, error)
+}
+
+type AuthorizationPolicy interface {
+	Authorize(claims *auth.Claims, permission auth.Permission, ownerID string) error
 }
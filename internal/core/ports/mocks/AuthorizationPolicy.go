@@ -0,0 +1,46 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	auth "CrudPlatform/internal/core/domain/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthorizationPolicy is an autogenerated mock type for the AuthorizationPolicy type
+type AuthorizationPolicy struct {
+	mock.Mock
+}
+
+// Authorize provides a mock function with given fields: claims, permission, ownerID
+func (_m *AuthorizationPolicy) Authorize(claims *auth.Claims, permission auth.Permission, ownerID string) error {
+	ret := _m.Called(claims, permission, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authorize")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*auth.Claims, auth.Permission, string) error); ok {
+		r0 = rf(claims, permission, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAuthorizationPolicy creates a new instance of AuthorizationPolicy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthorizationPolicy(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthorizationPolicy {
+	mock := &AuthorizationPolicy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
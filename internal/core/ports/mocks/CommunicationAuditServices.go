@@ -0,0 +1,61 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	audit "CrudPlatform/internal/core/domain/repository/model/audit"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "CrudPlatform/internal/core/domain/repository"
+)
+
+// CommunicationAuditServices is an autogenerated mock type for the CommunicationAuditServices type
+type CommunicationAuditServices struct {
+	mock.Mock
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, request
+func (_m *CommunicationAuditServices) ListAuditEvents(ctx context.Context, request *audit.ListEvents) (*repository.ResponseWithList, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEvents")
+	}
+
+	var r0 *repository.ResponseWithList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *audit.ListEvents) (*repository.ResponseWithList, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *audit.ListEvents) *repository.ResponseWithList); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.ResponseWithList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *audit.ListEvents) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCommunicationAuditServices creates a new instance of CommunicationAuditServices. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommunicationAuditServices(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommunicationAuditServices {
+	mock := &CommunicationAuditServices{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
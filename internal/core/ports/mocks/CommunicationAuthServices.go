@@ -0,0 +1,121 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	auth "CrudPlatform/internal/core/domain/repository/model/auth"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "CrudPlatform/internal/core/domain/repository"
+)
+
+// CommunicationAuthServices is an autogenerated mock type for the CommunicationAuthServices type
+type CommunicationAuthServices struct {
+	mock.Mock
+}
+
+// Login provides a mock function with given fields: ctx, request
+func (_m *CommunicationAuthServices) Login(ctx context.Context, request *auth.Login) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *auth.Login) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *auth.Login) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *auth.Login) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, request
+func (_m *CommunicationAuthServices) Logout(ctx context.Context, request *auth.Logout) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *auth.Logout) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *auth.Logout) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *auth.Logout) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refresh provides a mock function with given fields: ctx, request
+func (_m *CommunicationAuthServices) Refresh(ctx context.Context, request *auth.Refresh) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *auth.Refresh) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *auth.Refresh) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *auth.Refresh) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCommunicationAuthServices creates a new instance of CommunicationAuthServices. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommunicationAuthServices(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommunicationAuthServices {
+	mock := &CommunicationAuthServices{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "CrudPlatform/internal/core/domain/repository"
+
+	users "CrudPlatform/internal/core/domain/repository/model/users"
+)
+
+// CommunicationAvatarServices is an autogenerated mock type for the CommunicationAvatarServices type
+type CommunicationAvatarServices struct {
+	mock.Mock
+}
+
+// OpenAvatar provides a mock function with given fields: ctx, request
+func (_m *CommunicationAvatarServices) OpenAvatar(ctx context.Context, request *users.GetAvatar) (*users.Avatar, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenAvatar")
+	}
+
+	var r0 *users.Avatar
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *users.GetAvatar) (*users.Avatar, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *users.GetAvatar) *users.Avatar); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*users.Avatar)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *users.GetAvatar) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadAvatar provides a mock function with given fields: ctx, request
+func (_m *CommunicationAvatarServices) UploadAvatar(ctx context.Context, request *users.UploadAvatar) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadAvatar")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *users.UploadAvatar) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *users.UploadAvatar) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *users.UploadAvatar) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCommunicationAvatarServices creates a new instance of CommunicationAvatarServices. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommunicationAvatarServices(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommunicationAvatarServices {
+	mock := &CommunicationAvatarServices{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
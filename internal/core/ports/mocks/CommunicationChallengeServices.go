@@ -4,8 +4,7 @@ package mocks
 
 import (
 	challenges "CrudPlatform/internal/core/domain/repository/model/challenges"
-
-	gin "github.com/gin-gonic/gin"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -17,20 +16,20 @@ type CommunicationChallengeServices struct {
 	mock.Mock
 }
 
-// CreateChallenge provides a mock function with given fields: ctx, request
-func (_m *CommunicationChallengeServices) CreateChallenge(ctx *gin.Context, request *challenges.Challenge) (*repository.Response, error) {
+// Create provides a mock function with given fields: ctx, request
+func (_m *CommunicationChallengeServices) Create(ctx context.Context, request *challenges.Challenge) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateChallenge")
+		panic("no return value specified for Create")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.Challenge) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.Challenge) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.Challenge) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.Challenge) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -38,7 +37,67 @@ func (_m *CommunicationChallengeServices) CreateChallenge(ctx *gin.Context, requ
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *challenges.Challenge) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *challenges.Challenge) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, request
+func (_m *CommunicationChallengeServices) Delete(ctx context.Context, request *repository.DeleteRecord) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.DeleteRecord) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.DeleteRecord) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.DeleteRecord) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, request
+func (_m *CommunicationChallengeServices) List(ctx context.Context, request *challenges.ListChallenges) (*repository.ResponseWithList, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *repository.ResponseWithList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.ListChallenges) (*repository.ResponseWithList, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.ListChallenges) *repository.ResponseWithList); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.ResponseWithList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *challenges.ListChallenges) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -47,20 +106,20 @@ func (_m *CommunicationChallengeServices) CreateChallenge(ctx *gin.Context, requ
 	return r0, r1
 }
 
-// DeleteChallenge provides a mock function with given fields: ctx, request
-func (_m *CommunicationChallengeServices) DeleteChallenge(ctx *gin.Context, request *challenges.DeleteChallenge) (*repository.Response, error) {
+// Restore provides a mock function with given fields: ctx, request
+func (_m *CommunicationChallengeServices) Restore(ctx context.Context, request *repository.RestoreRecord) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteChallenge")
+		panic("no return value specified for Restore")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.DeleteChallenge) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.DeleteChallenge) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -68,7 +127,7 @@ func (_m *CommunicationChallengeServices) DeleteChallenge(ctx *gin.Context, requ
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *challenges.DeleteChallenge) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.RestoreRecord) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -77,20 +136,20 @@ func (_m *CommunicationChallengeServices) DeleteChallenge(ctx *gin.Context, requ
 	return r0, r1
 }
 
-// SelectChallenge provides a mock function with given fields: ctx, request
-func (_m *CommunicationChallengeServices) SelectChallenge(ctx *gin.Context, request *challenges.GetChallenge) (*repository.Response, error) {
+// Select provides a mock function with given fields: ctx, request
+func (_m *CommunicationChallengeServices) Select(ctx context.Context, request *repository.GetRecord) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SelectChallenge")
+		panic("no return value specified for Select")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.GetChallenge) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.GetChallenge) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -98,7 +157,7 @@ func (_m *CommunicationChallengeServices) SelectChallenge(ctx *gin.Context, requ
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *challenges.GetChallenge) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.GetRecord) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -107,20 +166,20 @@ func (_m *CommunicationChallengeServices) SelectChallenge(ctx *gin.Context, requ
 	return r0, r1
 }
 
-// UpdateChallenge provides a mock function with given fields: ctx, request
-func (_m *CommunicationChallengeServices) UpdateChallenge(ctx *gin.Context, request *challenges.UpdateChallenge) (*repository.Response, error) {
+// Update provides a mock function with given fields: ctx, request
+func (_m *CommunicationChallengeServices) Update(ctx context.Context, request *challenges.UpdateChallenge) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateChallenge")
+		panic("no return value specified for Update")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.UpdateChallenge) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.UpdateChallenge) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.UpdateChallenge) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.UpdateChallenge) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -128,7 +187,7 @@ func (_m *CommunicationChallengeServices) UpdateChallenge(ctx *gin.Context, requ
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *challenges.UpdateChallenge) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *challenges.UpdateChallenge) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
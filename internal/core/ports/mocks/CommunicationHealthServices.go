@@ -0,0 +1,74 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	health "CrudPlatform/internal/core/domain/health"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CommunicationHealthServices is an autogenerated mock type for the CommunicationHealthServices type
+type CommunicationHealthServices struct {
+	mock.Mock
+}
+
+// Drain provides a mock function with given fields:
+func (_m *CommunicationHealthServices) Drain() {
+	_m.Called()
+}
+
+// Live provides a mock function with given fields: ctx
+func (_m *CommunicationHealthServices) Live(ctx context.Context) *health.Report {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Live")
+	}
+
+	var r0 *health.Report
+	if rf, ok := ret.Get(0).(func(context.Context) *health.Report); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*health.Report)
+		}
+	}
+
+	return r0
+}
+
+// Ready provides a mock function with given fields: ctx
+func (_m *CommunicationHealthServices) Ready(ctx context.Context) *health.Report {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ready")
+	}
+
+	var r0 *health.Report
+	if rf, ok := ret.Get(0).(func(context.Context) *health.Report); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*health.Report)
+		}
+	}
+
+	return r0
+}
+
+// NewCommunicationHealthServices creates a new instance of CommunicationHealthServices. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommunicationHealthServices(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommunicationHealthServices {
+	mock := &CommunicationHealthServices{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
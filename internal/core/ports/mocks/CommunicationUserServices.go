@@ -3,7 +3,8 @@
 package mocks
 
 import (
-	gin "github.com/gin-gonic/gin"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
 	repository "CrudPlatform/internal/core/domain/repository"
@@ -16,20 +17,20 @@ type CommunicationUserServices struct {
 	mock.Mock
 }
 
-// CreateUser provides a mock function with given fields: ctx, request
-func (_m *CommunicationUserServices) CreateUser(ctx *gin.Context, request *users.User) (*repository.Response, error) {
+// Create provides a mock function with given fields: ctx, request
+func (_m *CommunicationUserServices) Create(ctx context.Context, request *users.User) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for Create")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.User) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *users.User) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.User) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *users.User) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -37,7 +38,67 @@ func (_m *CommunicationUserServices) CreateUser(ctx *gin.Context, request *users
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *users.User) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *users.User) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, request
+func (_m *CommunicationUserServices) Delete(ctx context.Context, request *repository.DeleteRecord) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.DeleteRecord) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.DeleteRecord) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.DeleteRecord) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, request
+func (_m *CommunicationUserServices) List(ctx context.Context, request *users.ListUsers) (*repository.ResponseWithList, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *repository.ResponseWithList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *users.ListUsers) (*repository.ResponseWithList, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *users.ListUsers) *repository.ResponseWithList); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.ResponseWithList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *users.ListUsers) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -46,20 +107,20 @@ func (_m *CommunicationUserServices) CreateUser(ctx *gin.Context, request *users
 	return r0, r1
 }
 
-// DeleteUser provides a mock function with given fields: ctx, request
-func (_m *CommunicationUserServices) DeleteUser(ctx *gin.Context, request *users.DeleteUser) (*repository.Response, error) {
+// Restore provides a mock function with given fields: ctx, request
+func (_m *CommunicationUserServices) Restore(ctx context.Context, request *repository.RestoreRecord) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUser")
+		panic("no return value specified for Restore")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.DeleteUser) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.DeleteUser) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -67,7 +128,7 @@ func (_m *CommunicationUserServices) DeleteUser(ctx *gin.Context, request *users
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *users.DeleteUser) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.RestoreRecord) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -76,20 +137,20 @@ func (_m *CommunicationUserServices) DeleteUser(ctx *gin.Context, request *users
 	return r0, r1
 }
 
-// SelectUser provides a mock function with given fields: ctx, request
-func (_m *CommunicationUserServices) SelectUser(ctx *gin.Context, request *users.GetUser) (*repository.Response, error) {
+// Select provides a mock function with given fields: ctx, request
+func (_m *CommunicationUserServices) Select(ctx context.Context, request *repository.GetRecord) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SelectUser")
+		panic("no return value specified for Select")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.GetUser) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.GetUser) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -97,7 +158,7 @@ func (_m *CommunicationUserServices) SelectUser(ctx *gin.Context, request *users
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *users.GetUser) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.GetRecord) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -106,20 +167,20 @@ func (_m *CommunicationUserServices) SelectUser(ctx *gin.Context, request *users
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: ctx, request
-func (_m *CommunicationUserServices) UpdateUser(ctx *gin.Context, request *users.UpdateUser) (*repository.Response, error) {
+// Update provides a mock function with given fields: ctx, request
+func (_m *CommunicationUserServices) Update(ctx context.Context, request *users.UpdateUser) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUser")
+		panic("no return value specified for Update")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.UpdateUser) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *users.UpdateUser) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.UpdateUser) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *users.UpdateUser) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -127,7 +188,7 @@ func (_m *CommunicationUserServices) UpdateUser(ctx *gin.Context, request *users
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *users.UpdateUser) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *users.UpdateUser) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
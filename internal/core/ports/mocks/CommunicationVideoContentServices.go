@@ -0,0 +1,212 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "CrudPlatform/internal/core/domain/repository"
+
+	videos "CrudPlatform/internal/core/domain/repository/model/videos"
+)
+
+// CommunicationVideoContentServices is an autogenerated mock type for the CommunicationVideoContentServices type
+type CommunicationVideoContentServices struct {
+	mock.Mock
+}
+
+// AppendUpload provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoContentServices) AppendUpload(ctx context.Context, request *videos.AppendUpload) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendUpload")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.AppendUpload) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.AppendUpload) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.AppendUpload) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUpload provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoContentServices) CreateUpload(ctx context.Context, request *videos.CreateUpload) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUpload")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.CreateUpload) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.CreateUpload) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.CreateUpload) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUpload provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoContentServices) DeleteUpload(ctx context.Context, request *videos.GetUpload) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUpload")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.GetUpload) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.GetUpload) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.GetUpload) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenContent provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoContentServices) OpenContent(ctx context.Context, request *videos.GetContent) (*videos.Content, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenContent")
+	}
+
+	var r0 *videos.Content
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.GetContent) (*videos.Content, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.GetContent) *videos.Content); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*videos.Content)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.GetContent) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectUpload provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoContentServices) SelectUpload(ctx context.Context, request *videos.GetUpload) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectUpload")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.GetUpload) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.GetUpload) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.GetUpload) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadContent provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoContentServices) UploadContent(ctx context.Context, request *videos.UploadContent) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadContent")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.UploadContent) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.UploadContent) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.UploadContent) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCommunicationVideoContentServices creates a new instance of CommunicationVideoContentServices. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommunicationVideoContentServices(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommunicationVideoContentServices {
+	mock := &CommunicationVideoContentServices{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
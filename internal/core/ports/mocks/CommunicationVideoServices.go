@@ -3,7 +3,8 @@
 package mocks
 
 import (
-	gin "github.com/gin-gonic/gin"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
 	repository "CrudPlatform/internal/core/domain/repository"
@@ -16,20 +17,20 @@ type CommunicationVideoServices struct {
 	mock.Mock
 }
 
-// CreateVideo provides a mock function with given fields: ctx, request
-func (_m *CommunicationVideoServices) CreateVideo(ctx *gin.Context, request *videos.Videos) (*repository.Response, error) {
+// Create provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoServices) Create(ctx context.Context, request *videos.Videos) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateVideo")
+		panic("no return value specified for Create")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.Videos) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.Videos) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.Videos) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.Videos) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -37,7 +38,67 @@ func (_m *CommunicationVideoServices) CreateVideo(ctx *gin.Context, request *vid
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *videos.Videos) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.Videos) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoServices) Delete(ctx context.Context, request *repository.DeleteRecord) (*repository.Response, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *repository.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.DeleteRecord) (*repository.Response, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.DeleteRecord) *repository.Response); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.DeleteRecord) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoServices) List(ctx context.Context, request *videos.ListVideos) (*repository.ResponseWithList, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *repository.ResponseWithList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.ListVideos) (*repository.ResponseWithList, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.ListVideos) *repository.ResponseWithList); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.ResponseWithList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.ListVideos) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -46,20 +107,20 @@ func (_m *CommunicationVideoServices) CreateVideo(ctx *gin.Context, request *vid
 	return r0, r1
 }
 
-// DeleteVideo provides a mock function with given fields: ctx, request
-func (_m *CommunicationVideoServices) DeleteVideo(ctx *gin.Context, request *videos.DeleteVideo) (*repository.Response, error) {
+// Restore provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoServices) Restore(ctx context.Context, request *repository.RestoreRecord) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteVideo")
+		panic("no return value specified for Restore")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.DeleteVideo) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.DeleteVideo) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -67,7 +128,7 @@ func (_m *CommunicationVideoServices) DeleteVideo(ctx *gin.Context, request *vid
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *videos.DeleteVideo) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.RestoreRecord) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -76,20 +137,20 @@ func (_m *CommunicationVideoServices) DeleteVideo(ctx *gin.Context, request *vid
 	return r0, r1
 }
 
-// SelectVideo provides a mock function with given fields: ctx, request
-func (_m *CommunicationVideoServices) SelectVideo(ctx *gin.Context, request *videos.GetVideo) (*repository.Response, error) {
+// Select provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoServices) Select(ctx context.Context, request *repository.GetRecord) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SelectVideo")
+		panic("no return value specified for Select")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.GetVideo) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.GetVideo) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -97,7 +158,7 @@ func (_m *CommunicationVideoServices) SelectVideo(ctx *gin.Context, request *vid
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *videos.GetVideo) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.GetRecord) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -106,20 +167,20 @@ func (_m *CommunicationVideoServices) SelectVideo(ctx *gin.Context, request *vid
 	return r0, r1
 }
 
-// UpdateVideo provides a mock function with given fields: ctx, request
-func (_m *CommunicationVideoServices) UpdateVideo(ctx *gin.Context, request *videos.UpdateVideo) (*repository.Response, error) {
+// Update provides a mock function with given fields: ctx, request
+func (_m *CommunicationVideoServices) Update(ctx context.Context, request *videos.UpdateVideo) (*repository.Response, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateVideo")
+		panic("no return value specified for Update")
 	}
 
 	var r0 *repository.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.UpdateVideo) (*repository.Response, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.UpdateVideo) (*repository.Response, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.UpdateVideo) *repository.Response); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.UpdateVideo) *repository.Response); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -127,7 +188,7 @@ func (_m *CommunicationVideoServices) UpdateVideo(ctx *gin.Context, request *vid
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *videos.UpdateVideo) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.UpdateVideo) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
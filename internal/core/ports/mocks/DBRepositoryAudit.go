@@ -0,0 +1,81 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	audit "CrudPlatform/internal/core/domain/repository/model/audit"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repository "CrudPlatform/internal/core/domain/repository"
+
+	schemaaudit "CrudPlatform/internal/core/domain/repository/schema/audit"
+)
+
+// DBRepositoryAudit is an autogenerated mock type for the DBRepositoryAudit type
+type DBRepositoryAudit struct {
+	mock.Mock
+}
+
+// CreateAuditEvent provides a mock function with given fields: ctx, event
+func (_m *DBRepositoryAudit) CreateAuditEvent(ctx context.Context, event *audit.Event) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAuditEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *audit.Event) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryAudit) ListAuditEvents(ctx context.Context, request *audit.ListEvents) (*repository.Page[schemaaudit.EventResponse], error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEvents")
+	}
+
+	var r0 *repository.Page[schemaaudit.EventResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *audit.ListEvents) (*repository.Page[schemaaudit.EventResponse], error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *audit.ListEvents) *repository.Page[schemaaudit.EventResponse]); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[schemaaudit.EventResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *audit.ListEvents) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDBRepositoryAudit creates a new instance of DBRepositoryAudit. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDBRepositoryAudit(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DBRepositoryAudit {
+	mock := &DBRepositoryAudit{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,12 +4,15 @@ package mocks
 
 import (
 	challenges "CrudPlatform/internal/core/domain/repository/model/challenges"
-
-	gin "github.com/gin-gonic/gin"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 
+	repository "CrudPlatform/internal/core/domain/repository"
+
 	schemachallenges "CrudPlatform/internal/core/domain/repository/schema/challenges"
+
+	time "time"
 )
 
 // DBRepositoryChallenge is an autogenerated mock type for the DBRepositoryChallenge type
@@ -17,26 +20,26 @@ type DBRepositoryChallenge struct {
 	mock.Mock
 }
 
-// CreateChallenge provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryChallenge) CreateChallenge(ctx *gin.Context, request *challenges.Challenge) (string, error) {
+// Create provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryChallenge) Create(ctx context.Context, request *challenges.Challenge) (string, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateChallenge")
+		panic("no return value specified for Create")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.Challenge) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.Challenge) (string, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.Challenge) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.Challenge) string); ok {
 		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *challenges.Challenge) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *challenges.Challenge) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -45,16 +48,16 @@ func (_m *DBRepositoryChallenge) CreateChallenge(ctx *gin.Context, request *chal
 	return r0, r1
 }
 
-// DeleteChallenge provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryChallenge) DeleteChallenge(ctx *gin.Context, request *challenges.DeleteChallenge) error {
+// Delete provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryChallenge) Delete(ctx context.Context, request *repository.DeleteRecord) error {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteChallenge")
+		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.DeleteChallenge) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.DeleteRecord) error); ok {
 		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
@@ -63,20 +66,117 @@ func (_m *DBRepositoryChallenge) DeleteChallenge(ctx *gin.Context, request *chal
 	return r0
 }
 
-// SelectChallenge provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryChallenge) SelectChallenge(ctx *gin.Context, request *challenges.GetChallenge) (*schemachallenges.ChallengeGetResponse, error) {
+// List provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryChallenge) List(ctx context.Context, request *challenges.ListChallenges) (*repository.Page[schemachallenges.ChallengeGetResponse], error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *repository.Page[schemachallenges.ChallengeGetResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.ListChallenges) (*repository.Page[schemachallenges.ChallengeGetResponse], error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.ListChallenges) *repository.Page[schemachallenges.ChallengeGetResponse]); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[schemachallenges.ChallengeGetResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *challenges.ListChallenges) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeleted provides a mock function with given fields: ctx, before
+func (_m *DBRepositoryChallenge) PurgeDeleted(ctx context.Context, before time.Time) (int64, []string, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeleted")
+	}
+
+	var r0 int64
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, []string, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) []string); ok {
+		r1 = rf(ctx, before)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, before)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Restore provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryChallenge) Restore(ctx context.Context, request *repository.RestoreRecord) (*schemachallenges.ChallengeUpdateResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *schemachallenges.ChallengeUpdateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) (*schemachallenges.ChallengeUpdateResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) *schemachallenges.ChallengeUpdateResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*schemachallenges.ChallengeUpdateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.RestoreRecord) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Select provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryChallenge) Select(ctx context.Context, request *repository.GetRecord) (*schemachallenges.ChallengeGetResponse, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SelectChallenge")
+		panic("no return value specified for Select")
 	}
 
 	var r0 *schemachallenges.ChallengeGetResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.GetChallenge) (*schemachallenges.ChallengeGetResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) (*schemachallenges.ChallengeGetResponse, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.GetChallenge) *schemachallenges.ChallengeGetResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) *schemachallenges.ChallengeGetResponse); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -84,7 +184,7 @@ func (_m *DBRepositoryChallenge) SelectChallenge(ctx *gin.Context, request *chal
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *challenges.GetChallenge) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.GetRecord) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -93,20 +193,20 @@ func (_m *DBRepositoryChallenge) SelectChallenge(ctx *gin.Context, request *chal
 	return r0, r1
 }
 
-// UpdateChallenge provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryChallenge) UpdateChallenge(ctx *gin.Context, request *challenges.UpdateChallenge) (*schemachallenges.ChallengeUpdateResponse, error) {
+// Update provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryChallenge) Update(ctx context.Context, request *challenges.UpdateChallenge) (*schemachallenges.ChallengeUpdateResponse, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateChallenge")
+		panic("no return value specified for Update")
 	}
 
 	var r0 *schemachallenges.ChallengeUpdateResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.UpdateChallenge) (*schemachallenges.ChallengeUpdateResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.UpdateChallenge) (*schemachallenges.ChallengeUpdateResponse, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *challenges.UpdateChallenge) *schemachallenges.ChallengeUpdateResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *challenges.UpdateChallenge) *schemachallenges.ChallengeUpdateResponse); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -114,7 +214,7 @@ func (_m *DBRepositoryChallenge) UpdateChallenge(ctx *gin.Context, request *chal
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *challenges.UpdateChallenge) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *challenges.UpdateChallenge) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -3,11 +3,16 @@
 package mocks
 
 import (
-	gin "github.com/gin-gonic/gin"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
+	repository "CrudPlatform/internal/core/domain/repository"
+
 	schemausers "CrudPlatform/internal/core/domain/repository/schema/users"
 
+	time "time"
+
 	users "CrudPlatform/internal/core/domain/repository/model/users"
 )
 
@@ -16,26 +21,26 @@ type DBRepositoryUsers struct {
 	mock.Mock
 }
 
-// CreateUser provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryUsers) CreateUser(ctx *gin.Context, request *users.User) (string, error) {
+// Create provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryUsers) Create(ctx context.Context, request *users.User) (string, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for Create")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.User) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *users.User) (string, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.User) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *users.User) string); ok {
 		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *users.User) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *users.User) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -44,16 +49,16 @@ func (_m *DBRepositoryUsers) CreateUser(ctx *gin.Context, request *users.User) (
 	return r0, r1
 }
 
-// DeleteUser provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryUsers) DeleteUser(ctx *gin.Context, request *users.DeleteUser) error {
+// Delete provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryUsers) Delete(ctx context.Context, request *repository.DeleteRecord) error {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUser")
+		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.DeleteUser) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.DeleteRecord) error); ok {
 		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
@@ -62,20 +67,117 @@ func (_m *DBRepositoryUsers) DeleteUser(ctx *gin.Context, request *users.DeleteU
 	return r0
 }
 
-// SelectUser provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryUsers) SelectUser(ctx *gin.Context, request *users.GetUser) (*schemausers.UsersGetResponse, error) {
+// List provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryUsers) List(ctx context.Context, request *users.ListUsers) (*repository.Page[schemausers.UsersGetResponse], error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *repository.Page[schemausers.UsersGetResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *users.ListUsers) (*repository.Page[schemausers.UsersGetResponse], error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *users.ListUsers) *repository.Page[schemausers.UsersGetResponse]); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[schemausers.UsersGetResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *users.ListUsers) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeleted provides a mock function with given fields: ctx, before
+func (_m *DBRepositoryUsers) PurgeDeleted(ctx context.Context, before time.Time) (int64, []string, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeleted")
+	}
+
+	var r0 int64
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, []string, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) []string); ok {
+		r1 = rf(ctx, before)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, before)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Restore provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryUsers) Restore(ctx context.Context, request *repository.RestoreRecord) (*schemausers.UsersUpdateResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *schemausers.UsersUpdateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) (*schemausers.UsersUpdateResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) *schemausers.UsersUpdateResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*schemausers.UsersUpdateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.RestoreRecord) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Select provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryUsers) Select(ctx context.Context, request *repository.GetRecord) (*schemausers.UsersGetResponse, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SelectUser")
+		panic("no return value specified for Select")
 	}
 
 	var r0 *schemausers.UsersGetResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.GetUser) (*schemausers.UsersGetResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) (*schemausers.UsersGetResponse, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.GetUser) *schemausers.UsersGetResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) *schemausers.UsersGetResponse); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -83,7 +185,37 @@ func (_m *DBRepositoryUsers) SelectUser(ctx *gin.Context, request *users.GetUser
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *users.GetUser) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.GetRecord) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SelectUserCredentials provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryUsers) SelectUserCredentials(ctx context.Context, request *users.GetUserCredentials) (*schemausers.UserCredentials, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectUserCredentials")
+	}
+
+	var r0 *schemausers.UserCredentials
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *users.GetUserCredentials) (*schemausers.UserCredentials, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *users.GetUserCredentials) *schemausers.UserCredentials); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*schemausers.UserCredentials)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *users.GetUserCredentials) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -92,20 +224,20 @@ func (_m *DBRepositoryUsers) SelectUser(ctx *gin.Context, request *users.GetUser
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryUsers) UpdateUser(ctx *gin.Context, request *users.UpdateUser) (*schemausers.UsersUpdateResponse, error) {
+// Update provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryUsers) Update(ctx context.Context, request *users.UpdateUser) (*schemausers.UsersUpdateResponse, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUser")
+		panic("no return value specified for Update")
 	}
 
 	var r0 *schemausers.UsersUpdateResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.UpdateUser) (*schemausers.UsersUpdateResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *users.UpdateUser) (*schemausers.UsersUpdateResponse, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *users.UpdateUser) *schemausers.UsersUpdateResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *users.UpdateUser) *schemausers.UsersUpdateResponse); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -113,7 +245,7 @@ func (_m *DBRepositoryUsers) UpdateUser(ctx *gin.Context, request *users.UpdateU
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *users.UpdateUser) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *users.UpdateUser) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -3,11 +3,16 @@
 package mocks
 
 import (
-	gin "github.com/gin-gonic/gin"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
+	repository "CrudPlatform/internal/core/domain/repository"
+
 	schemavideos "CrudPlatform/internal/core/domain/repository/schema/videos"
 
+	time "time"
+
 	videos "CrudPlatform/internal/core/domain/repository/model/videos"
 )
 
@@ -16,26 +21,26 @@ type DBRepositoryVideo struct {
 	mock.Mock
 }
 
-// CreateVideo provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryVideo) CreateVideo(ctx *gin.Context, request *videos.Videos) (string, error) {
+// Create provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryVideo) Create(ctx context.Context, request *videos.Videos) (string, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateVideo")
+		panic("no return value specified for Create")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.Videos) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.Videos) (string, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.Videos) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.Videos) string); ok {
 		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *videos.Videos) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.Videos) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -44,16 +49,16 @@ func (_m *DBRepositoryVideo) CreateVideo(ctx *gin.Context, request *videos.Video
 	return r0, r1
 }
 
-// DeleteVideo provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryVideo) DeleteVideo(ctx *gin.Context, request *videos.DeleteVideo) error {
+// Delete provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryVideo) Delete(ctx context.Context, request *repository.DeleteRecord) error {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteVideo")
+		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.DeleteVideo) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.DeleteRecord) error); ok {
 		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
@@ -62,20 +67,117 @@ func (_m *DBRepositoryVideo) DeleteVideo(ctx *gin.Context, request *videos.Delet
 	return r0
 }
 
-// SelectVideo provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryVideo) SelectVideo(ctx *gin.Context, request *videos.GetVideo) (*schemavideos.VideosGetResponse, error) {
+// List provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryVideo) List(ctx context.Context, request *videos.ListVideos) (*repository.Page[schemavideos.VideosGetResponse], error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *repository.Page[schemavideos.VideosGetResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.ListVideos) (*repository.Page[schemavideos.VideosGetResponse], error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.ListVideos) *repository.Page[schemavideos.VideosGetResponse]); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[schemavideos.VideosGetResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.ListVideos) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeleted provides a mock function with given fields: ctx, before
+func (_m *DBRepositoryVideo) PurgeDeleted(ctx context.Context, before time.Time) (int64, []string, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeleted")
+	}
+
+	var r0 int64
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, []string, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) []string); ok {
+		r1 = rf(ctx, before)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, before)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Restore provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryVideo) Restore(ctx context.Context, request *repository.RestoreRecord) (*schemavideos.VideosUpdateResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *schemavideos.VideosUpdateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) (*schemavideos.VideosUpdateResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.RestoreRecord) *schemavideos.VideosUpdateResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*schemavideos.VideosUpdateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.RestoreRecord) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Select provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryVideo) Select(ctx context.Context, request *repository.GetRecord) (*schemavideos.VideosGetResponse, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SelectVideo")
+		panic("no return value specified for Select")
 	}
 
 	var r0 *schemavideos.VideosGetResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.GetVideo) (*schemavideos.VideosGetResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) (*schemavideos.VideosGetResponse, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.GetVideo) *schemavideos.VideosGetResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *repository.GetRecord) *schemavideos.VideosGetResponse); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -83,7 +185,37 @@ func (_m *DBRepositoryVideo) SelectVideo(ctx *gin.Context, request *videos.GetVi
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *videos.GetVideo) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *repository.GetRecord) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryVideo) Update(ctx context.Context, request *videos.UpdateVideo) (*schemavideos.VideosUpdateResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *schemavideos.VideosUpdateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.UpdateVideo) (*schemavideos.VideosUpdateResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.UpdateVideo) *schemavideos.VideosUpdateResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*schemavideos.VideosUpdateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.UpdateVideo) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
@@ -92,20 +224,20 @@ func (_m *DBRepositoryVideo) SelectVideo(ctx *gin.Context, request *videos.GetVi
 	return r0, r1
 }
 
-// UpdateVideo provides a mock function with given fields: ctx, request
-func (_m *DBRepositoryVideo) UpdateVideo(ctx *gin.Context, request *videos.UpdateVideo) (*schemavideos.VideosUpdateResponse, error) {
+// UpdateVideoContent provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryVideo) UpdateVideoContent(ctx context.Context, request *videos.UpdateVideoContent) (*schemavideos.VideosUpdateResponse, error) {
 	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateVideo")
+		panic("no return value specified for UpdateVideoContent")
 	}
 
 	var r0 *schemavideos.VideosUpdateResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.UpdateVideo) (*schemavideos.VideosUpdateResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.UpdateVideoContent) (*schemavideos.VideosUpdateResponse, error)); ok {
 		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(*gin.Context, *videos.UpdateVideo) *schemavideos.VideosUpdateResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.UpdateVideoContent) *schemavideos.VideosUpdateResponse); ok {
 		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
@@ -113,7 +245,7 @@ func (_m *DBRepositoryVideo) UpdateVideo(ctx *gin.Context, request *videos.Updat
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gin.Context, *videos.UpdateVideo) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.UpdateVideoContent) error); ok {
 		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
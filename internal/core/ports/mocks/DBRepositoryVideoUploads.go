@@ -0,0 +1,163 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	videos "CrudPlatform/internal/core/domain/repository/model/videos"
+)
+
+// DBRepositoryVideoUploads is an autogenerated mock type for the DBRepositoryVideoUploads type
+type DBRepositoryVideoUploads struct {
+	mock.Mock
+}
+
+// AppendVideoUploadPart provides a mock function with given fields: ctx, part
+func (_m *DBRepositoryVideoUploads) AppendVideoUploadPart(ctx context.Context, part *videos.UploadPart) (int64, error) {
+	ret := _m.Called(ctx, part)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendVideoUploadPart")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.UploadPart) (int64, error)); ok {
+		return rf(ctx, part)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.UploadPart) int64); ok {
+		r0 = rf(ctx, part)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.UploadPart) error); ok {
+		r1 = rf(ctx, part)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateVideoUpload provides a mock function with given fields: ctx, upload
+func (_m *DBRepositoryVideoUploads) CreateVideoUpload(ctx context.Context, upload *videos.VideoUpload) error {
+	ret := _m.Called(ctx, upload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVideoUpload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.VideoUpload) error); ok {
+		r0 = rf(ctx, upload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteVideoUpload provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryVideoUploads) DeleteVideoUpload(ctx context.Context, request *videos.GetUpload) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVideoUpload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.GetUpload) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpireVideoUploads provides a mock function with given fields: ctx, before
+func (_m *DBRepositoryVideoUploads) ExpireVideoUploads(ctx context.Context, before time.Time) (int64, []string, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireVideoUploads")
+	}
+
+	var r0 int64
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, []string, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) []string); ok {
+		r1 = rf(ctx, before)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, before)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SelectVideoUpload provides a mock function with given fields: ctx, request
+func (_m *DBRepositoryVideoUploads) SelectVideoUpload(ctx context.Context, request *videos.GetUpload) (*videos.VideoUpload, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectVideoUpload")
+	}
+
+	var r0 *videos.VideoUpload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.GetUpload) (*videos.VideoUpload, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *videos.GetUpload) *videos.VideoUpload); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*videos.VideoUpload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *videos.GetUpload) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDBRepositoryVideoUploads creates a new instance of DBRepositoryVideoUploads. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDBRepositoryVideoUploads(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DBRepositoryVideoUploads {
+	mock := &DBRepositoryVideoUploads{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,66 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	auth "CrudPlatform/internal/core/domain/auth"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TokenIssuer is an autogenerated mock type for the TokenIssuer type
+type TokenIssuer struct {
+	mock.Mock
+}
+
+// Issue provides a mock function with given fields: ctx, subject, role
+func (_m *TokenIssuer) Issue(ctx context.Context, subject string, role auth.Role) (string, time.Duration, error) {
+	ret := _m.Called(ctx, subject, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Issue")
+	}
+
+	var r0 string
+	var r1 time.Duration
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.Role) (string, time.Duration, error)); ok {
+		return rf(ctx, subject, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.Role) string); ok {
+		r0 = rf(ctx, subject, role)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.Role) time.Duration); ok {
+		r1 = rf(ctx, subject, role)
+	} else {
+		r1 = ret.Get(1).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, auth.Role) error); ok {
+		r2 = rf(ctx, subject, role)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewTokenIssuer creates a new instance of TokenIssuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTokenIssuer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TokenIssuer {
+	mock := &TokenIssuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
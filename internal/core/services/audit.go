@@ -0,0 +1,138 @@
+package service
+
+import (
+	"CrudPlatform/internal/core/domain/requestid"
+	"CrudPlatform/internal/core/ports"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	model "CrudPlatform/internal/core/domain/repository/model/audit"
+)
+
+// auditIgnored son campos que cambian en toda escritura y no aportan al diff de una actualización.
+var auditIgnored = map[string]bool{"updated_at": true, "version": true}
+
+// auditor registra las escrituras sobre un tipo de registro. Sin repositorio no registra nada,
+// de modo que un servicio sin auditoría se comporta igual que antes.
+type auditor struct {
+	repo       ports.DBRepositoryAudit
+	entityType string
+}
+
+func (a auditor) enabled() bool {
+	return a.repo != nil
+}
+
+// atomic ejecuta la escritura fn, que registra su evento con record, en una transacción si el
+// repositorio de auditoría es un ports.Transactor; así, si el evento no se guarda, la escritura
+// también se descarta. Sin auditoría, o sin transacciones, solo ejecuta fn.
+func (a auditor) atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := a.repo.(ports.Transactor); ok {
+		return tx.WithinTx(ctx, fn)
+	}
+	return fn(ctx)
+}
+
+// record guarda el evento con el usuario y el id del request del contexto. Se llama dentro de
+// atomic, y su error debe fallar el request para no dejar una escritura sin auditar.
+func (a auditor) record(ctx context.Context, action model.Action, id string, before, after any) error {
+	if !a.enabled() {
+		return nil
+	}
+	if action == model.ActionUpdate {
+		before, after = auditDiff(before, after)
+	}
+
+	event := &model.Event{
+		Actor:      currentUserID(ctx),
+		Action:     action,
+		EntityType: a.entityType,
+		EntityID:   id,
+		Before:     auditJSON(before),
+		After:      auditJSON(after),
+		RequestID:  requestid.FromContext(ctx),
+		CreatedAt:  time.Now().UTC(),
+	}
+	return a.repo.CreateAuditEvent(ctx, event)
+}
+
+// recordCreate registra una creación con el registro tal como quedó guardado, que load vuelve a leer.
+func (a auditor) recordCreate(ctx context.Context, id string, load func() (any, error)) error {
+	if !a.enabled() {
+		return nil
+	}
+	created, err := load()
+	if err != nil {
+		return err
+	}
+	return a.record(ctx, model.ActionCreate, id, nil, created)
+}
+
+// auditDiff reduce before y after a los campos de after que cambiaron. Los campos se toman del tipo
+// de after y no de su JSON, para que un campo omitempty que pasó a vacío también aparezca.
+func auditDiff(before, after any) (map[string]any, map[string]any) {
+	previous, current := auditFields(before), auditFields(after)
+
+	changedBefore, changedAfter := map[string]any{}, map[string]any{}
+	for _, name := range jsonNames(reflect.TypeOf(after)) {
+		if auditIgnored[name] || reflect.DeepEqual(previous[name], current[name]) {
+			continue
+		}
+		changedBefore[name] = previous[name]
+		changedAfter[name] = current[name]
+	}
+	return changedBefore, changedAfter
+}
+
+func auditFields(value any) map[string]any {
+	fields := map[string]any{}
+	if raw, err := json.Marshal(value); err == nil {
+		_ = json.Unmarshal(raw, &fields)
+	}
+	return fields
+}
+
+// jsonNames retorna los nombres JSON de los campos de un struct o de un puntero a struct.
+func jsonNames(t reflect.Type) []string {
+	if t == nil {
+		return nil
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// auditJSON serializa un estado del registro; un estado ausente queda vacío y se guarda como NULL.
+func auditJSON(value any) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil || string(raw) == "null" {
+		return nil
+	}
+	return raw
+}
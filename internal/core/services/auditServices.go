@@ -0,0 +1,54 @@
+package service
+
+import (
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/ports"
+	"context"
+	"net/http"
+	"strconv"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/audit"
+)
+
+type RepositoryAudit struct {
+	repo   ports.DBRepositoryAudit
+	policy ports.AuthorizationPolicy
+}
+
+func NewServiceAudit(repo ports.DBRepositoryAudit, policy ports.AuthorizationPolicy) *RepositoryAudit {
+	return &RepositoryAudit{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+func (r *RepositoryAudit) ListAuditEvents(ctx context.Context, request *model.ListEvents) (*entity.ResponseWithList, error) {
+
+	if err := authorize(ctx, r.policy, auth.PermissionAuditRead, ""); err != nil {
+		return nil, err
+	}
+
+	request.Normalize()
+
+	resp, err := r.repo.ListAuditEvents(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ResponseWithList{
+		Data:       resp.Data(),
+		Pagination: entity.NewPagination(request.PageRequest, resp),
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusOK),
+					Message:      http.StatusText(http.StatusOK),
+					Detail:       "Registros Listados",
+				},
+			},
+			Source: "List Audit Events",
+		},
+	}, nil
+
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"CrudPlatform/internal/core/domain/auth"
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/audit"
+	schema "CrudPlatform/internal/core/domain/repository/schema/audit"
+	mockRepository "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	t.Run("Admin", func(t *testing.T) {
+		mockRepo := mockRepository.NewDBRepositoryAudit(t)
+		svc := NewServiceAudit(mockRepo, auth.NewPolicy())
+
+		mockRepo.On("ListAuditEvents", mock.Anything, mock.MatchedBy(func(request *model.ListEvents) bool {
+			return request.Entity == "video" && request.ID == "123" && request.PageSize == entity.MaxPageSize
+		})).Return(&entity.Page[schema.EventResponse]{Items: []schema.EventResponse{{ID: "event-1"}}, Total: 1}, nil)
+
+		response, err := svc.ListAuditEvents(testContext("admin-1", auth.RoleAdmin), &model.ListEvents{Entity: "video", ID: "123"})
+		require.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, 1, response.Pagination.Total)
+		assert.Equal(t, "List Audit Events", response.Result.Source)
+	})
+
+	t.Run("CreatorForbidden", func(t *testing.T) {
+		svc := NewServiceAudit(mockRepository.NewDBRepositoryAudit(t), auth.NewPolicy())
+
+		_, err := svc.ListAuditEvents(testContext("user-1", auth.RoleCreator), &model.ListEvents{})
+		assertForbidden(t, err, auth.PermissionAuditRead)
+	})
+}
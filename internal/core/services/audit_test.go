@@ -0,0 +1,138 @@
+package service
+
+import (
+	entity "CrudPlatform/internal/core/domain/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"CrudPlatform/internal/core/domain/auth"
+	modelAudit "CrudPlatform/internal/core/domain/repository/model/audit"
+	model "CrudPlatform/internal/core/domain/repository/model/challenges"
+	schema "CrudPlatform/internal/core/domain/repository/schema/challenges"
+	schemaUsers "CrudPlatform/internal/core/domain/repository/schema/users"
+	"CrudPlatform/internal/core/domain/requestid"
+	mockRepository "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditDiff(t *testing.T) {
+	before := &schemaUsers.UsersGetResponse{ID: "1", Name: "Ana", Email: "ana@example.com", ImagePath: "https://x/a.png", UpdatedAt: "t1", Version: 1}
+	after := &schemaUsers.UsersUpdateResponse{ID: "1", Name: "Ana María", Email: "ana@example.com", UpdatedAt: "t2", Version: 2}
+
+	changedBefore, changedAfter := auditDiff(before, after)
+	// image_path es omitempty: que quede vacío también es un cambio.
+	assert.Equal(t, map[string]any{"name": "Ana", "image_path": "https://x/a.png"}, changedBefore)
+	assert.Equal(t, map[string]any{"name": "Ana María", "image_path": nil}, changedAfter)
+}
+
+func newAuditedChallengeService(t *testing.T) (*RepositoryChallenge, *mockRepository.DBRepositoryChallenge, *mockRepository.DBRepositoryAudit) {
+	repo := mockRepository.NewDBRepositoryChallenge(t)
+	audit := mockRepository.NewDBRepositoryAudit(t)
+	return NewServiceChallenge(repo, auth.NewPolicy(), audit), repo, audit
+}
+
+// transactionalAudit es un repositorio de auditoría que además abre transacciones, como el de SQL.
+type transactionalAudit struct {
+	*mockRepository.DBRepositoryAudit
+	*mockRepository.Transactor
+}
+
+func TestChallengeAudit(t *testing.T) {
+	ctx := requestid.With(testContext("user-1", auth.RoleCreator), "req-1")
+
+	t.Run("CreateRecordsStoredRecord", func(t *testing.T) {
+		svc, repo, audit := newAuditedChallengeService(t)
+		repo.On("Create", mock.Anything, mock.Anything).Return("123", nil)
+		repo.On("Select", mock.Anything, &entity.GetRecord{ID: "123"}).
+			Return(&schema.ChallengeGetResponse{ID: "123", Title: "Go", CreatedBy: "user-1"}, nil)
+		audit.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(event *modelAudit.Event) bool {
+			return event.Action == modelAudit.ActionCreate && event.EntityType == "challenge" && event.EntityID == "123" &&
+				event.Actor == "user-1" && event.RequestID == "req-1" && event.Before == nil
+		})).Return(nil)
+
+		_, err := svc.Create(ctx, &model.Challenge{Title: "Go"})
+		require.NoError(t, err)
+
+		event := audit.Calls[0].Arguments.Get(1).(*modelAudit.Event)
+		var after map[string]any
+		require.NoError(t, json.Unmarshal(event.After, &after))
+		assert.Equal(t, "Go", after["title"])
+	})
+
+	t.Run("UpdateRecordsDiff", func(t *testing.T) {
+		svc, repo, audit := newAuditedChallengeService(t)
+		repo.On("Select", mock.Anything, mock.Anything).
+			Return(&schema.ChallengeGetResponse{ID: "123", Title: "Go", Difficulty: 2, CreatedBy: "user-1", Version: 1}, nil)
+		repo.On("Update", mock.Anything, mock.Anything).
+			Return(&schema.ChallengeUpdateResponse{ID: "123", Title: "Go", Difficulty: 4, Version: 2}, nil)
+		audit.On("CreateAuditEvent", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := svc.Update(ctx, &model.UpdateChallenge{ID: "123"})
+		require.NoError(t, err)
+
+		event := audit.Calls[0].Arguments.Get(1).(*modelAudit.Event)
+		assert.Equal(t, modelAudit.ActionUpdate, event.Action)
+		assert.JSONEq(t, `{"difficulty":2}`, string(event.Before))
+		assert.JSONEq(t, `{"difficulty":4}`, string(event.After))
+	})
+
+	t.Run("DeleteRecordsPreviousState", func(t *testing.T) {
+		svc, repo, audit := newAuditedChallengeService(t)
+		repo.On("Select", mock.Anything, mock.Anything).
+			Return(&schema.ChallengeGetResponse{ID: "123", Title: "Go", CreatedBy: "user-1"}, nil)
+		repo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		audit.On("CreateAuditEvent", mock.Anything, mock.MatchedBy(func(event *modelAudit.Event) bool {
+			return event.Action == modelAudit.ActionDelete && event.Before != nil && event.After == nil
+		})).Return(nil)
+
+		_, err := svc.Delete(ctx, &entity.DeleteRecord{ID: "123"})
+		require.NoError(t, err)
+	})
+
+	t.Run("AuditErrorFailsWrite", func(t *testing.T) {
+		svc, repo, audit := newAuditedChallengeService(t)
+		repo.On("Select", mock.Anything, mock.Anything).
+			Return(&schema.ChallengeGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
+		repo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		audit.On("CreateAuditEvent", mock.Anything, mock.Anything).Return(errors.New("insert error"))
+
+		_, err := svc.Delete(ctx, &entity.DeleteRecord{ID: "123"})
+		assert.EqualError(t, err, "insert error")
+	})
+
+	t.Run("WriteAndEventShareTransaction", func(t *testing.T) {
+		repo := mockRepository.NewDBRepositoryChallenge(t)
+		audit := transactionalAudit{DBRepositoryAudit: mockRepository.NewDBRepositoryAudit(t), Transactor: mockRepository.NewTransactor(t)}
+		svc := NewServiceChallenge(repo, auth.NewPolicy(), audit)
+
+		type txKey struct{}
+		inTx := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txKey{}) != nil })
+		audit.Transactor.On("WithinTx", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(context.WithValue(ctx, txKey{}, true))
+			})
+		// El estado previo, con el dueño que se verifica, se lee bloqueado dentro de la transacción.
+		repo.On("Select", inTx, &entity.GetRecord{ID: "123", ForUpdate: true}).
+			Return(&schema.ChallengeGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
+		repo.On("Delete", inTx, mock.Anything).Return(nil)
+		audit.DBRepositoryAudit.On("CreateAuditEvent", inTx, mock.Anything).Return(nil)
+
+		_, err := svc.Delete(ctx, &entity.DeleteRecord{ID: "123"})
+		require.NoError(t, err)
+	})
+
+	t.Run("FailedWriteNotRecorded", func(t *testing.T) {
+		svc, repo, _ := newAuditedChallengeService(t)
+		repo.On("Select", mock.Anything, mock.Anything).
+			Return(&schema.ChallengeGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
+		repo.On("Delete", mock.Anything, mock.Anything).Return(errors.New("delete error"))
+
+		_, err := svc.Delete(ctx, &entity.DeleteRecord{ID: "123"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/ports"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelAuth "CrudPlatform/internal/core/domain/repository/model/auth"
+	model "CrudPlatform/internal/core/domain/repository/model/users"
+	schemaAuth "CrudPlatform/internal/core/domain/repository/schema/auth"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyPasswordHash se compara cuando el email no existe para que el login tarde lo mismo
+// con o sin usuario y no revele qué emails están registrados.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("crudplatform"), bcrypt.DefaultCost)
+
+type RepositoryAuth struct {
+	users      ports.DBRepositoryUsers
+	tokens     ports.DBRepositoryTokens
+	issuer     ports.TokenIssuer
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+func NewServiceAuth(users ports.DBRepositoryUsers, tokens ports.DBRepositoryTokens, issuer ports.TokenIssuer, refreshTTL time.Duration) *RepositoryAuth {
+	return &RepositoryAuth{
+		users:      users,
+		tokens:     tokens,
+		issuer:     issuer,
+		refreshTTL: refreshTTL,
+		now:        time.Now,
+	}
+}
+
+func (r *RepositoryAuth) Login(ctx context.Context, request *modelAuth.Login) (*entity.Response, error) {
+
+	credentials, err := r.users.SelectUserCredentials(ctx, &model.GetUserCredentials{Email: request.Email})
+	if err != nil {
+		if errors.Is(err, modelAuth.ErrInvalidCredentials) {
+			bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(request.Password))
+			return nil, modelAuth.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(credentials.PasswordHash), []byte(request.Password)); err != nil {
+		return nil, modelAuth.ErrInvalidCredentials
+	}
+
+	refreshToken, value, err := r.newRefreshToken(credentials.ID, uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+	if err := r.tokens.CreateRefreshToken(ctx, refreshToken); err != nil {
+		return nil, err
+	}
+
+	return r.tokenResponse(ctx, credentials.ID, auth.ParseRole(credentials.Role), value, "Sesión Iniciada", "Login")
+}
+
+// Refresh rota el refresh token: el token presentado queda revocado y se entrega uno nuevo.
+// Presentar un token ya revocado indica que fue robado, por lo que se revoca toda la sesión.
+func (r *RepositoryAuth) Refresh(ctx context.Context, request *modelAuth.Refresh) (*entity.Response, error) {
+
+	current, err := r.tokens.SelectRefreshToken(ctx, hashRefreshToken(request.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+
+	if current.RevokedAt != nil {
+		if err := r.tokens.RevokeRefreshTokenFamily(ctx, current.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, modelAuth.ErrInvalidRefreshToken
+	}
+	if !r.now().Before(current.ExpiresAt) {
+		return nil, modelAuth.ErrInvalidRefreshToken
+	}
+
+	// El rol se vuelve a leer para que los cambios de rol apliquen al renovar el token.
+	user, err := r.users.Select(ctx, &entity.GetRecord{ID: current.UserID})
+	if err != nil {
+		if entity.KindOf(err) == entity.KindNotFound {
+			return nil, modelAuth.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	next, value, err := r.newRefreshToken(current.UserID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.tokens.RotateRefreshToken(ctx, current, next); err != nil {
+		if errors.Is(err, modelAuth.ErrInvalidRefreshToken) {
+			if err := r.tokens.RevokeRefreshTokenFamily(ctx, current.FamilyID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	return r.tokenResponse(ctx, current.UserID, auth.ParseRole(user.Role), value, "Token Renovado", "Refresh")
+}
+
+// Logout revoca todos los refresh tokens de la sesión a la que pertenece el token.
+func (r *RepositoryAuth) Logout(ctx context.Context, request *modelAuth.Logout) (*entity.Response, error) {
+
+	current, err := r.tokens.SelectRefreshToken(ctx, hashRefreshToken(request.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.tokens.RevokeRefreshTokenFamily(ctx, current.FamilyID); err != nil {
+		return nil, err
+	}
+
+	return &entity.Response{
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusOK),
+					Message:      http.StatusText(http.StatusOK),
+					Detail:       "Sesión Cerrada",
+				},
+			},
+			Source: "Logout",
+		},
+	}, nil
+
+}
+
+func (r *RepositoryAuth) tokenResponse(ctx context.Context, userID string, role auth.Role, refreshToken, detail, source string) (*entity.Response, error) {
+	accessToken, expiresIn, err := r.issuer.Issue(ctx, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Response{
+		Data: schemaAuth.TokenResponse{
+			AccessToken:  accessToken,
+			TokenType:    "Bearer",
+			ExpiresIn:    int(expiresIn.Seconds()),
+			RefreshToken: refreshToken,
+		},
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusOK),
+					Message:      http.StatusText(http.StatusOK),
+					Detail:       detail,
+				},
+			},
+			Source: source,
+		},
+	}, nil
+}
+
+// newRefreshToken genera un valor aleatorio para el cliente y el registro que se persiste con su hash.
+func (r *RepositoryAuth) newRefreshToken(userID, familyID string) (*modelAuth.RefreshToken, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", err
+	}
+	value := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := r.now().UTC()
+	return &modelAuth.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashRefreshToken(value),
+		ExpiresAt: now.Add(r.refreshTTL),
+		CreatedAt: now,
+	}, value, nil
+}
+
+func hashRefreshToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"CrudPlatform/internal/core/domain/auth"
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelAuth "CrudPlatform/internal/core/domain/repository/model/auth"
+	schemaAuth "CrudPlatform/internal/core/domain/repository/schema/auth"
+	schema "CrudPlatform/internal/core/domain/repository/schema/users"
+	mockRepository "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newAuthService(t *testing.T) (*RepositoryAuth, *mockRepository.DBRepositoryUsers, *mockRepository.DBRepositoryTokens, *mockRepository.TokenIssuer) {
+	users := mockRepository.NewDBRepositoryUsers(t)
+	tokens := mockRepository.NewDBRepositoryTokens(t)
+	issuer := mockRepository.NewTokenIssuer(t)
+	return NewServiceAuth(users, tokens, issuer, time.Hour), users, tokens, issuer
+}
+
+func TestNewServiceAuth(t *testing.T) {
+	svc, _, _, _ := newAuthService(t)
+	assert.NotNil(t, svc, "El servicio no debe ser nil")
+}
+
+func TestLogin(t *testing.T) {
+	svc, users, tokens, issuer := newAuthService(t)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	users.On("SelectUserCredentials", mock.Anything, mock.Anything).Return(&schema.UserCredentials{ID: "user-1", Role: "creator", PasswordHash: string(hash)}, nil)
+	tokens.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(token *modelAuth.RefreshToken) bool {
+		return token.UserID == "user-1" && token.FamilyID != "" && token.TokenHash != ""
+	})).Return(nil)
+	issuer.On("Issue", mock.Anything, "user-1", auth.RoleCreator).Return("access-token", 15*time.Minute, nil)
+
+	c := context.Background()
+	response, err := svc.Login(c, &modelAuth.Login{Email: "john@example.com", Password: "s3cret"})
+	require.NoError(t, err)
+
+	data := response.Data.(schemaAuth.TokenResponse)
+	assert.Equal(t, "access-token", data.AccessToken)
+	assert.Equal(t, "Bearer", data.TokenType)
+	assert.Equal(t, 900, data.ExpiresIn)
+	assert.NotEmpty(t, data.RefreshToken)
+	assert.Equal(t, "Login", response.Result.Source)
+}
+
+func TestLogin_WrongPassword(t *testing.T) {
+	svc, users, _, _ := newAuthService(t)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	users.On("SelectUserCredentials", mock.Anything, mock.Anything).Return(&schema.UserCredentials{ID: "user-1", PasswordHash: string(hash)}, nil)
+
+	c := context.Background()
+	_, err = svc.Login(c, &modelAuth.Login{Email: "john@example.com", Password: "wrong"})
+	assert.ErrorIs(t, err, modelAuth.ErrInvalidCredentials)
+}
+
+func TestLogin_UnknownEmail(t *testing.T) {
+	svc, users, _, _ := newAuthService(t)
+
+	users.On("SelectUserCredentials", mock.Anything, mock.Anything).Return(nil, modelAuth.ErrInvalidCredentials)
+
+	c := context.Background()
+	_, err := svc.Login(c, &modelAuth.Login{Email: "missing@example.com", Password: "s3cret"})
+	assert.Equal(t, modelAuth.ErrInvalidCredentials, err)
+}
+
+func TestLogin_ErrorCase(t *testing.T) {
+	svc, users, _, _ := newAuthService(t)
+
+	users.On("SelectUserCredentials", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
+
+	c := context.Background()
+	_, err := svc.Login(c, &modelAuth.Login{Email: "john@example.com", Password: "s3cret"})
+	assert.EqualError(t, err, "error simulado")
+}
+
+func TestRefresh(t *testing.T) {
+	svc, users, tokens, issuer := newAuthService(t)
+
+	current := &modelAuth.RefreshToken{ID: "token-1", UserID: "user-1", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}
+	tokens.On("SelectRefreshToken", mock.Anything, hashRefreshToken("refresh-1")).Return(current, nil)
+	tokens.On("RotateRefreshToken", mock.Anything, current, mock.MatchedBy(func(next *modelAuth.RefreshToken) bool {
+		return next.FamilyID == "family-1" && next.UserID == "user-1" && next.ID != current.ID
+	})).Return(nil)
+	users.On("Select", mock.Anything, mock.Anything).Return(&schema.UsersGetResponse{ID: "user-1", Role: "admin"}, nil)
+	issuer.On("Issue", mock.Anything, "user-1", auth.RoleAdmin).Return("access-token", 15*time.Minute, nil)
+
+	c := context.Background()
+	response, err := svc.Refresh(c, &modelAuth.Refresh{RefreshToken: "refresh-1"})
+	require.NoError(t, err)
+
+	data := response.Data.(schemaAuth.TokenResponse)
+	assert.NotEqual(t, "refresh-1", data.RefreshToken)
+	assert.Equal(t, "Refresh", response.Result.Source)
+}
+
+func TestRefresh_ReusedTokenRevokesFamily(t *testing.T) {
+	svc, _, tokens, _ := newAuthService(t)
+
+	revokedAt := time.Now().Add(-time.Minute)
+	current := &modelAuth.RefreshToken{ID: "token-1", UserID: "user-1", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+	tokens.On("SelectRefreshToken", mock.Anything, mock.Anything).Return(current, nil)
+	tokens.On("RevokeRefreshTokenFamily", mock.Anything, "family-1").Return(nil)
+
+	c := context.Background()
+	_, err := svc.Refresh(c, &modelAuth.Refresh{RefreshToken: "refresh-1"})
+	assert.ErrorIs(t, err, modelAuth.ErrInvalidRefreshToken)
+}
+
+func TestRefresh_ConcurrentRotationRevokesFamily(t *testing.T) {
+	svc, users, tokens, _ := newAuthService(t)
+
+	current := &modelAuth.RefreshToken{ID: "token-1", UserID: "user-1", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}
+	tokens.On("SelectRefreshToken", mock.Anything, mock.Anything).Return(current, nil)
+	users.On("Select", mock.Anything, mock.Anything).Return(&schema.UsersGetResponse{ID: "user-1", Role: "creator"}, nil)
+	tokens.On("RotateRefreshToken", mock.Anything, current, mock.Anything).Return(modelAuth.ErrInvalidRefreshToken)
+	tokens.On("RevokeRefreshTokenFamily", mock.Anything, "family-1").Return(nil)
+
+	c := context.Background()
+	_, err := svc.Refresh(c, &modelAuth.Refresh{RefreshToken: "refresh-1"})
+	assert.ErrorIs(t, err, modelAuth.ErrInvalidRefreshToken)
+}
+
+func TestRefresh_DeletedUser(t *testing.T) {
+	svc, users, tokens, _ := newAuthService(t)
+
+	current := &modelAuth.RefreshToken{ID: "token-1", UserID: "user-1", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}
+	tokens.On("SelectRefreshToken", mock.Anything, mock.Anything).Return(current, nil)
+	users.On("Select", mock.Anything, mock.Anything).Return(nil, entity.NewError(entity.KindNotFound, "user with id user-1 not found"))
+
+	c := context.Background()
+	_, err := svc.Refresh(c, &modelAuth.Refresh{RefreshToken: "refresh-1"})
+	assert.ErrorIs(t, err, modelAuth.ErrInvalidRefreshToken)
+}
+
+func TestRefresh_Expired(t *testing.T) {
+	svc, _, tokens, _ := newAuthService(t)
+
+	current := &modelAuth.RefreshToken{ID: "token-1", UserID: "user-1", FamilyID: "family-1", ExpiresAt: time.Now().Add(-time.Minute)}
+	tokens.On("SelectRefreshToken", mock.Anything, mock.Anything).Return(current, nil)
+
+	c := context.Background()
+	_, err := svc.Refresh(c, &modelAuth.Refresh{RefreshToken: "refresh-1"})
+	assert.ErrorIs(t, err, modelAuth.ErrInvalidRefreshToken)
+}
+
+func TestLogout(t *testing.T) {
+	svc, _, tokens, _ := newAuthService(t)
+
+	current := &modelAuth.RefreshToken{ID: "token-1", UserID: "user-1", FamilyID: "family-1"}
+	tokens.On("SelectRefreshToken", mock.Anything, hashRefreshToken("refresh-1")).Return(current, nil)
+	tokens.On("RevokeRefreshTokenFamily", mock.Anything, "family-1").Return(nil)
+
+	c := context.Background()
+	response, err := svc.Logout(c, &modelAuth.Logout{RefreshToken: "refresh-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Logout", response.Result.Source)
+}
+
+func TestLogout_UnknownToken(t *testing.T) {
+	svc, _, tokens, _ := newAuthService(t)
+
+	tokens.On("SelectRefreshToken", mock.Anything, mock.Anything).Return(nil, modelAuth.ErrInvalidRefreshToken)
+
+	c := context.Background()
+	_, err := svc.Logout(c, &modelAuth.Logout{RefreshToken: "unknown"})
+	assert.ErrorIs(t, err, modelAuth.ErrInvalidRefreshToken)
+}
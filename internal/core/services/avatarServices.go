@@ -0,0 +1,185 @@
+package service
+
+import (
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/domain/logging"
+	"CrudPlatform/internal/core/domain/media"
+	"CrudPlatform/internal/core/ports"
+	"bytes"
+	"context"
+	"errors"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelAudit "CrudPlatform/internal/core/domain/repository/model/audit"
+	model "CrudPlatform/internal/core/domain/repository/model/users"
+	schema "CrudPlatform/internal/core/domain/repository/schema/users"
+
+	"github.com/google/uuid"
+)
+
+// defaultAvatarSize es la miniatura que sirve el image_path cuando no se pide un tamaño.
+const defaultAvatarSize = 256
+
+// RepositoryAvatar recibe las imágenes de los avatares, las guarda como miniaturas sin metadatos
+// y apunta el image_path del usuario a la URL que las sirve.
+type RepositoryAvatar struct {
+	users   ports.DBRepositoryUsers
+	blobs   ports.BlobStore
+	policy  ports.AuthorizationPolicy
+	audit   auditor
+	maxSize int64
+}
+
+func NewServiceAvatar(users ports.DBRepositoryUsers, blobs ports.BlobStore, policy ports.AuthorizationPolicy, audit ports.DBRepositoryAudit, maxSize int64) *RepositoryAvatar {
+	return &RepositoryAvatar{
+		users:   users,
+		blobs:   blobs,
+		policy:  policy,
+		audit:   auditor{repo: audit, entityType: modelAudit.EntityUser},
+		maxSize: maxSize,
+	}
+}
+
+func (r *RepositoryAvatar) UploadAvatar(ctx context.Context, request *model.UploadAvatar) (*entity.Response, error) {
+
+	if err := authorize(ctx, r.policy, auth.PermissionUsersUpdate, request.ID); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(request.Body, r.maxSize+1))
+	if err != nil {
+		return nil, entity.WrapError(entity.KindValidation, err, "error reading avatar")
+	}
+	if int64(len(data)) > r.maxSize {
+		return nil, entity.NewError(entity.KindTooLarge, "avatar exceeds the maximum size of %d bytes", r.maxSize)
+	}
+	img, err := media.DecodeImage(data)
+	if err != nil {
+		return nil, imageError(err)
+	}
+
+	file, err := r.storeThumbnails(ctx, request.ID, img)
+	if err != nil {
+		return nil, err
+	}
+
+	imagePath := strings.TrimSuffix(request.BaseURL, "/") + "/users/" + url.PathEscape(request.ID) + "/avatar/" + file
+	var current *schema.UsersGetResponse
+	var resp *schema.UsersUpdateResponse
+	err = r.audit.atomic(ctx, func(ctx context.Context) error {
+		// El avatar anterior se lee en la transacción de la escritura para borrar y auditar el que
+		// realmente se reemplaza.
+		var err error
+		if current, err = r.users.Select(ctx, &entity.GetRecord{ID: request.ID, ForUpdate: true}); err != nil {
+			return err
+		}
+		if resp, err = r.users.Update(ctx, &model.UpdateUser{Id: request.ID, ImagePath: &imagePath, Version: request.Version}); err != nil {
+			return err
+		}
+		return r.audit.record(ctx, modelAudit.ActionUpdate, request.ID, current, resp)
+	})
+	if err != nil {
+		r.discard(ctx, request.ID, file)
+		return nil, err
+	}
+	if previous, ok := model.AvatarFile(current.ImagePath, request.ID); ok {
+		r.discard(ctx, request.ID, previous)
+	}
+
+	return &entity.Response{
+		Data:    resp,
+		Version: resp.Version,
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusOK),
+					Message:      http.StatusText(http.StatusOK),
+					Detail:       "Avatar Actualizado",
+				},
+			},
+			Source: "Upload Avatar",
+		},
+	}, nil
+
+}
+
+// OpenAvatar abre una miniatura. Los avatares son públicos, como cualquier imagen enlazada desde
+// image_path, así que no se verifican permisos; quien lo recibe debe cerrarlo.
+func (r *RepositoryAvatar) OpenAvatar(ctx context.Context, request *model.GetAvatar) (*model.Avatar, error) {
+	size := request.Size
+	if size == 0 {
+		size = defaultAvatarSize
+	}
+	if !model.ValidAvatarFile(request.File) || strings.ContainsAny(request.UserID, "/\\") || strings.Trim(request.UserID, ".") == "" {
+		return nil, entity.NewError(entity.KindNotFound, "avatar %s not found", request.File)
+	}
+
+	body, err := r.blobs.Open(ctx, model.AvatarKey(request.UserID, request.File, size))
+	if err != nil {
+		return nil, err
+	}
+	return &model.Avatar{Body: body, ContentType: avatarContentType(request.File)}, nil
+}
+
+// storeThumbnails guarda una miniatura por tamaño y retorna el nombre de archivo que las identifica.
+// Las fotos siguen en JPEG y el resto pasa a PNG, que conserva la transparencia.
+func (r *RepositoryAvatar) storeThumbnails(ctx context.Context, userID string, img *media.Image) (string, error) {
+	file := uuid.NewString() + ".png"
+	if img.Format == media.FormatJPEG {
+		file = strings.TrimSuffix(file, ".png") + ".jpg"
+	}
+
+	for _, size := range model.AvatarSizes {
+		var buf bytes.Buffer
+		var err error
+		if img.Format == media.FormatJPEG {
+			err = jpeg.Encode(&buf, img.Thumbnail(size), &jpeg.Options{Quality: 85})
+		} else {
+			err = png.Encode(&buf, img.Thumbnail(size))
+		}
+		if err == nil {
+			err = r.blobs.Put(ctx, model.AvatarKey(userID, file, size), &buf, int64(buf.Len()))
+		}
+		if err != nil {
+			r.discard(ctx, userID, file)
+			return "", entity.WrapError(entity.KindOf(err), err, "error storing avatar thumbnail")
+		}
+	}
+	return file, nil
+}
+
+// discard borra las miniaturas de un avatar que ya no se usa; un error solo se registra en el log.
+func (r *RepositoryAvatar) discard(ctx context.Context, userID, file string) {
+	for _, size := range model.AvatarSizes {
+		key := model.AvatarKey(userID, file, size)
+		if err := r.blobs.Delete(context.WithoutCancel(ctx), key); err != nil {
+			logging.FromContext(ctx).Warn("delete avatar blob", "user_id", userID, "blob_key", key, "error", err)
+		}
+	}
+}
+
+func avatarContentType(file string) string {
+	if strings.HasSuffix(file, ".jpg") {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// imageError clasifica por qué se rechazó una imagen.
+func imageError(err error) error {
+	switch {
+	case errors.Is(err, media.ErrUnsupportedImage):
+		return entity.WrapError(entity.KindUnsupportedMediaType, err, "avatar must be a JPEG, PNG or GIF image")
+	case errors.Is(err, media.ErrImageTooLarge):
+		return entity.WrapError(entity.KindTooLarge, err, "avatar dimensions are too large")
+	default:
+		return entity.WrapError(entity.KindValidation, err, "avatar is not a valid image")
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"CrudPlatform/internal/core/domain/auth"
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/users"
+	schema "CrudPlatform/internal/core/domain/repository/schema/users"
+	mockRepository "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const avatarID = "0b7e4c1d-2f3a-4b5c-8d9e-1a2b3c4d5e6f"
+
+func newAvatarService(t *testing.T, maxSize int64) (*RepositoryAvatar, *mockRepository.DBRepositoryUsers, *memoryBlobs) {
+	users := mockRepository.NewDBRepositoryUsers(t)
+	blobs := &memoryBlobs{}
+	return NewServiceAvatar(users, blobs, auth.NewPolicy(), nil, maxSize), users, blobs
+}
+
+func pngImage(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0x80
+	}
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestNewServiceAvatar(t *testing.T) {
+	service := NewServiceAvatar(mockRepository.NewDBRepositoryUsers(t), &memoryBlobs{}, auth.NewPolicy(), mockRepository.NewDBRepositoryAudit(t), 1<<20)
+	assert.NotNil(t, service, "El servicio no debe ser nil")
+}
+
+func TestUploadAvatar(t *testing.T) {
+	ctx := testContext("1", auth.RoleViewer)
+
+	t.Run("StoresThumbnails", func(t *testing.T) {
+		svc, users, blobs := newAvatarService(t, 1<<20)
+		previous := "https://api.example.com/users/1/avatar/" + avatarID + ".png"
+		require.NoError(t, blobs.Put(ctx, "avatars/1/"+avatarID+"/64.png", strings.NewReader("old"), 3))
+		require.NoError(t, blobs.Put(ctx, "avatars/1/"+avatarID+"/256.png", strings.NewReader("old"), 3))
+
+		users.On("Select", mock.Anything, &entity.GetRecord{ID: "1", ForUpdate: true}).Return(&schema.UsersGetResponse{ID: "1", ImagePath: previous}, nil)
+		users.On("Update", mock.Anything, mock.MatchedBy(func(request *model.UpdateUser) bool {
+			return request.Id == "1" && request.ImagePath != nil && request.Name == nil &&
+				strings.HasPrefix(*request.ImagePath, "https://api.example.com/users/1/avatar/") && strings.HasSuffix(*request.ImagePath, ".png")
+		})).Return(&schema.UsersUpdateResponse{ID: "1", Version: 2}, nil)
+
+		response, err := svc.UploadAvatar(ctx, &model.UploadAvatar{ID: "1", Body: bytes.NewReader(pngImage(t, 300, 200)), BaseURL: "https://api.example.com/"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), response.Version)
+
+		keys := blobs.keys()
+		sort.Strings(keys)
+		require.Len(t, keys, 2)
+		assert.True(t, strings.HasSuffix(keys[0], "/256.png"))
+		assert.True(t, strings.HasSuffix(keys[1], "/64.png"))
+		assert.NotContains(t, keys[0], avatarID)
+
+		thumb, err := png.Decode(bytes.NewReader(blobs.blobs[keys[1]]))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 64, 64), thumb.Bounds())
+	})
+
+	t.Run("NotAnImage", func(t *testing.T) {
+		svc, _, blobs := newAvatarService(t, 1<<20)
+
+		_, err := svc.UploadAvatar(ctx, &model.UploadAvatar{ID: "1", Body: strings.NewReader("<svg/>")})
+		assert.Equal(t, entity.KindUnsupportedMediaType, entity.KindOf(err))
+		assert.Empty(t, blobs.keys())
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		svc, _, _ := newAvatarService(t, 16)
+
+		_, err := svc.UploadAvatar(ctx, &model.UploadAvatar{ID: "1", Body: bytes.NewReader(pngImage(t, 10, 10))})
+		assert.Equal(t, entity.KindTooLarge, entity.KindOf(err))
+	})
+
+	t.Run("StaleVersionDiscardsThumbnails", func(t *testing.T) {
+		svc, users, blobs := newAvatarService(t, 1<<20)
+		version := int64(1)
+		users.On("Select", mock.Anything, mock.Anything).Return(&schema.UsersGetResponse{ID: "1"}, nil)
+		users.On("Update", mock.Anything, mock.Anything).Return(nil, entity.NewError(entity.KindPreconditionFailed, "stale"))
+
+		_, err := svc.UploadAvatar(ctx, &model.UploadAvatar{ID: "1", Body: bytes.NewReader(pngImage(t, 10, 10)), Version: &version})
+		assert.Equal(t, entity.KindPreconditionFailed, entity.KindOf(err))
+		assert.Empty(t, blobs.keys())
+	})
+
+	t.Run("OtherProfileForbidden", func(t *testing.T) {
+		svc, _, _ := newAvatarService(t, 1<<20)
+
+		_, err := svc.UploadAvatar(ctx, &model.UploadAvatar{ID: "2", Body: bytes.NewReader(pngImage(t, 10, 10))})
+		assertForbidden(t, err, auth.PermissionUsersUpdate)
+	})
+}
+
+func TestOpenAvatar(t *testing.T) {
+	svc, _, blobs := newAvatarService(t, 1<<20)
+	ctx := testContext("", auth.RoleViewer)
+	require.NoError(t, blobs.Put(ctx, "avatars/1/"+avatarID+"/64.jpg", strings.NewReader("small"), 5))
+	require.NoError(t, blobs.Put(ctx, "avatars/1/"+avatarID+"/256.jpg", strings.NewReader("large"), 5))
+
+	avatar, err := svc.OpenAvatar(ctx, &model.GetAvatar{UserID: "1", File: avatarID + ".jpg", Size: 64})
+	require.NoError(t, err)
+	data, _ := io.ReadAll(avatar.Body)
+	assert.Equal(t, "small", string(data))
+	assert.Equal(t, "image/jpeg", avatar.ContentType)
+
+	avatar, err = svc.OpenAvatar(ctx, &model.GetAvatar{UserID: "1", File: avatarID + ".jpg"})
+	require.NoError(t, err)
+	data, _ = io.ReadAll(avatar.Body)
+	assert.Equal(t, "large", string(data))
+
+	_, err = svc.OpenAvatar(ctx, &model.GetAvatar{UserID: "..", File: avatarID + ".jpg"})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+
+	_, err = svc.OpenAvatar(ctx, &model.GetAvatar{UserID: "1", File: "../secret.jpg"})
+	assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+}
+
+func TestAvatarFile(t *testing.T) {
+	file, ok := model.AvatarFile("https://api.example.com/users/1/avatar/"+avatarID+".jpg", "1")
+	assert.True(t, ok)
+	assert.Equal(t, avatarID+".jpg", file)
+
+	_, ok = model.AvatarFile("https://api.example.com/users/2/avatar/"+avatarID+".jpg", "1")
+	assert.False(t, ok)
+
+	_, ok = model.AvatarFile("https://cdn.example.com/me.png", "1")
+	assert.False(t, ok)
+}
@@ -1,113 +1,39 @@
 package service
 
 import (
+	"CrudPlatform/internal/core/domain/auth"
 	"CrudPlatform/internal/core/ports"
-	"net/http"
-	"strconv"
+	"context"
 
 	entity "CrudPlatform/internal/core/domain/repository"
+	modelAudit "CrudPlatform/internal/core/domain/repository/model/audit"
 	model "CrudPlatform/internal/core/domain/repository/model/challenges"
-
-	"github.com/gin-gonic/gin"
+	schema "CrudPlatform/internal/core/domain/repository/schema/challenges"
 )
 
-type RepositoryChallenge struct {
-	repo ports.DBRepositoryChallenge
-}
-
-func NewServiceChallenge(repo ports.DBRepositoryChallenge) *RepositoryChallenge {
-	return &RepositoryChallenge{
-		repo: repo,
-	}
-}
-
-func (r *RepositoryChallenge) CreateChallenge(ctx *gin.Context, request *model.Challenge) (*entity.Response, error) {
-
-	resp, err := r.repo.CreateChallenge(ctx, request)
-	if err != nil {
-		return nil, err
-	}
-
-	return &entity.Response{
-		Data: resp,
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Creado",
-				},
-			},
-			Source: "Create Challenge",
-		},
-	}, nil
-
-}
-
-func (r *RepositoryChallenge) SelectChallenge(ctx *gin.Context, request *model.GetChallenge) (*entity.Response, error) {
-
-	resp, err := r.repo.SelectChallenge(ctx, request)
-	if err != nil {
-		return nil, err
-	}
-
-	return &entity.Response{
-		Data: resp,
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Seleccionado",
-				},
-			},
-			Source: "Select Challenge",
+type RepositoryChallenge = Service[model.Challenge, model.UpdateChallenge, model.ListChallenges, schema.ChallengeGetResponse, schema.ChallengeUpdateResponse]
+
+// NewServiceChallenge crea el servicio de challenges; solo su creador o un admin los modifica.
+func NewServiceChallenge(repo ports.DBRepositoryChallenge, policy ports.AuthorizationPolicy, audit ports.DBRepositoryAudit) *RepositoryChallenge {
+	return NewCRUDService(repo, policy, audit, Entity[model.Challenge, model.UpdateChallenge, model.ListChallenges, schema.ChallengeGetResponse]{
+		Name:      "Challenge",
+		Plural:    "Challenges",
+		AuditType: modelAudit.EntityChallenge,
+		Permissions: Permissions{
+			Create:  auth.PermissionChallengesCreate,
+			Read:    auth.PermissionChallengesRead,
+			Update:  auth.PermissionChallengesUpdate,
+			Delete:  auth.PermissionChallengesDelete,
+			Restore: auth.PermissionChallengesRestore,
 		},
-	}, nil
-
-}
-
-func (r *RepositoryChallenge) UpdateChallenge(ctx *gin.Context, request *model.UpdateChallenge) (*entity.Response, error) {
-
-	resp, err := r.repo.UpdateChallenge(ctx, request)
-	if err != nil {
-		return nil, err
-	}
-
-	return &entity.Response{
-		Data: resp,
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Actualizado",
-				},
-			},
-			Source: "Update Challenge",
+		Owner:    func(challenge *schema.ChallengeGetResponse) string { return challenge.CreatedBy },
+		UpdateID: func(request *model.UpdateChallenge) string { return request.ID },
+		List: func(request *model.ListChallenges) (*entity.PageRequest, *entity.ListFilter) {
+			return &request.PageRequest, &request.ListFilter
 		},
-	}, nil
-
-}
-
-func (r *RepositoryChallenge) DeleteChallenge(ctx *gin.Context, request *model.DeleteChallenge) (*entity.Response, error) {
-
-	err := r.repo.DeleteChallenge(ctx, request)
-	if err != nil {
-		return nil, err
-	}
-
-	return &entity.Response{
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Eliminado",
-				},
-			},
-			Source: "Delete Challenge",
+		Prepare: func(ctx context.Context, request *model.Challenge) error {
+			request.CreatedBy = currentUserID(ctx)
+			return nil
 		},
-	}, nil
-
+	})
 }
@@ -2,32 +2,31 @@ package service
 
 import (
 	"errors"
-	"net/http/httptest"
 	"testing"
 
+	"CrudPlatform/internal/core/domain/auth"
 	entity "CrudPlatform/internal/core/domain/repository"
 	model "CrudPlatform/internal/core/domain/repository/model/challenges"
 	schema "CrudPlatform/internal/core/domain/repository/schema/challenges"
 	mockRepository "CrudPlatform/internal/core/ports/mocks"
 
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestNewServiceChallenge(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
-	service := NewServiceChallenge(mockRepo)
+	service := NewServiceChallenge(mockRepo, auth.NewPolicy(), mockRepository.NewDBRepositoryAudit(t))
 	assert.NotNil(t, service, "El servicio no debe ser nil")
 }
 
 func TestCreateChallenge(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
-	svc := &RepositoryChallenge{repo: mockRepo}
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
 
-	mockRepo.On("CreateChallenge", mock.Anything, mock.Anything).Return("123", nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return("123", nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c := testContext("user-1", auth.RoleAdmin)
 	req := &model.Challenge{
 		Title:       "Test Challenge",
 		Description: "Test Description",
@@ -44,42 +43,42 @@ func TestCreateChallenge(t *testing.T) {
 		},
 	}
 
-	response, err := svc.CreateChallenge(c, req)
+	response, err := svc.Create(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestCreateChallenge_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
-	svc := &RepositoryChallenge{repo: mockRepo}
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
 
-	mockRepo.On("CreateChallenge", mock.Anything, mock.Anything).Return("", errors.New("error simulado"))
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return("", errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c := testContext("user-1", auth.RoleAdmin)
 	req := &model.Challenge{
 		Title:       "Test Challenge",
 		Description: "Test Description",
 		Difficulty:  3,
 	}
 
-	response, err := svc.CreateChallenge(c, req)
+	response, err := svc.Create(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
 
 func TestSelectChallenge(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
-	svc := &RepositoryChallenge{repo: mockRepo}
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
 
 	mockResponse := &schema.ChallengeGetResponse{
 		Title:       "Test Challenge",
 		Description: "Test Description",
 		Difficulty:  3,
 	}
-	mockRepo.On("SelectChallenge", mock.Anything, mock.Anything).Return(mockResponse, nil)
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(mockResponse, nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.GetChallenge{ID: "123"}
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &entity.GetRecord{ID: "123"}
 
 	expectedResp := &entity.Response{
 		Data: mockResponse,
@@ -91,46 +90,49 @@ func TestSelectChallenge(t *testing.T) {
 		},
 	}
 
-	response, err := svc.SelectChallenge(c, req)
+	response, err := svc.Select(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestSelectChallenge_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
-	svc := &RepositoryChallenge{repo: mockRepo}
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
 
-	mockRepo.On("SelectChallenge", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.GetChallenge{ID: "123"}
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &entity.GetRecord{ID: "123"}
 
-	response, err := svc.SelectChallenge(c, req)
+	response, err := svc.Select(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
 
 func TestUpdateChallenge(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
-	svc := &RepositoryChallenge{repo: mockRepo}
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schema.ChallengeGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
 
 	mockResponse := &schema.ChallengeUpdateResponse{
 		Title:       "Updated Challenge",
 		Description: "Updated Description",
 		Difficulty:  4,
+		Version:     2,
 	}
-	mockRepo.On("UpdateChallenge", mock.Anything, mock.Anything).Return(mockResponse, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(mockResponse, nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.UpdateChallenge{
-		ID:          "123",
+	c := testContext("user-1", auth.RoleCreator)
+	req := model.ReplaceChallenge{
 		Title:       "Updated Challenge",
 		Description: "Updated Description",
 		Difficulty:  4,
-	}
+	}.Update("123")
 
 	expectedResp := &entity.Response{
-		Data: mockResponse,
+		Data:    mockResponse,
+		Version: 2,
 		Result: entity.Result{
 			Details: []entity.Detail{
 				{InternalCode: "200", Message: "OK", Detail: "Registro Actualizado"},
@@ -139,38 +141,41 @@ func TestUpdateChallenge(t *testing.T) {
 		},
 	}
 
-	response, err := svc.UpdateChallenge(c, req)
+	response, err := svc.Update(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestUpdateChallenge_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
-	svc := &RepositoryChallenge{repo: mockRepo}
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schema.ChallengeGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
 
-	mockRepo.On("UpdateChallenge", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.UpdateChallenge{
-		ID:          "123",
+	c := testContext("user-1", auth.RoleCreator)
+	req := model.ReplaceChallenge{
 		Title:       "Updated Challenge",
 		Description: "Updated Description",
 		Difficulty:  4,
-	}
+	}.Update("123")
 
-	response, err := svc.UpdateChallenge(c, req)
+	response, err := svc.Update(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
 
 func TestDeleteChallenge(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
-	svc := &RepositoryChallenge{repo: mockRepo}
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schema.ChallengeGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
 
-	mockRepo.On("DeleteChallenge", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.DeleteChallenge{ID: "123"}
+	c := testContext("user-1", auth.RoleCreator)
+	req := &entity.DeleteRecord{ID: "123"}
 
 	expectedResp := &entity.Response{
 		Result: entity.Result{
@@ -181,21 +186,72 @@ func TestDeleteChallenge(t *testing.T) {
 		},
 	}
 
-	response, err := svc.DeleteChallenge(c, req)
+	response, err := svc.Delete(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestDeleteChallenge_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
-	svc := &RepositoryChallenge{repo: mockRepo}
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schema.ChallengeGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
+
+	mockRepo.On("Delete", mock.Anything, mock.Anything).Return(errors.New("error simulado"))
+
+	c := testContext("user-1", auth.RoleCreator)
+	req := &entity.DeleteRecord{ID: "123"}
+
+	response, err := svc.Delete(c, req)
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestListChallenges(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	mockPage := &entity.Page[schema.ChallengeGetResponse]{
+		Items:      []schema.ChallengeGetResponse{{ID: "123", Title: "Test Challenge", Difficulty: 3}},
+		Total:      11,
+		NextCursor: "next",
+	}
+	mockRepo.On("List", mock.Anything, mock.Anything).Return(mockPage, nil)
+
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &model.ListChallenges{PageRequest: entity.PageRequest{PageSize: 50}}
+
+	expectedResp := &entity.ResponseWithList{
+		Data: []interface{}{mockPage.Items[0]},
+		Pagination: &entity.Pagination{
+			Total:      11,
+			Page:       1,
+			PageSize:   entity.MaxPageSize,
+			NextCursor: "next",
+		},
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{InternalCode: "200", Message: "OK", Detail: "Registros Listados"},
+			},
+			Source: "List Challenges",
+		},
+	}
+
+	response, err := svc.List(c, req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResp, response)
+}
+
+func TestListChallenges_ErrorCase(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
 
-	mockRepo.On("DeleteChallenge", mock.Anything, mock.Anything).Return(errors.New("error simulado"))
+	mockRepo.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.DeleteChallenge{ID: "123"}
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &model.ListChallenges{}
 
-	response, err := svc.DeleteChallenge(c, req)
+	response, err := svc.List(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/ports"
+	"context"
+	"net/http"
+	"strconv"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelAudit "CrudPlatform/internal/core/domain/repository/model/audit"
+)
+
+// Permissions son los permisos que exige cada operación de Service.
+type Permissions struct {
+	Create  auth.Permission
+	Read    auth.Permission
+	Update  auth.Permission
+	Delete  auth.Permission
+	Restore auth.Permission
+}
+
+// Entity describe una entidad para Service: cómo se nombra, qué permisos exige y los hooks con
+// las reglas propias de la entidad. Los hooks opcionales pueden quedar en nil.
+type Entity[C, U, L, T any] struct {
+	// Name y Plural forman el Source de las respuestas, como "Create Video" o "List Videos".
+	Name   string
+	Plural string
+	// AuditType es el tipo de entidad de los eventos de auditoría.
+	AuditType   string
+	Permissions Permissions
+	// Owner retorna el dueño de un registro, contra el que se verifican los permisos de escritura.
+	// Sin Owner el dueño es el propio registro, como en los usuarios, y solo se lee el estado
+	// previo cuando hay auditoría.
+	Owner func(record *T) string
+	// UpdateID retorna el id del registro que modifica una actualización.
+	UpdateID func(request *U) string
+	// List retorna la paginación y los filtros comunes de un listado.
+	List func(request *L) (*entity.PageRequest, *entity.ListFilter)
+	// Prepare valida y completa una creación ya autorizada, antes de guardarla.
+	Prepare func(ctx context.Context, request *C) error
+	// ValidateSelect valida una lectura antes de ir al repositorio.
+	ValidateSelect func(request *entity.GetRecord) error
+	// Expand completa un registro leído, por ejemplo con sus relaciones.
+	Expand func(ctx context.Context, record *T, request *entity.GetRecord) error
+}
+
+// Service implementa ports.Service sobre un ports.Repository: autoriza cada operación, la
+// audita y arma la respuesta. T y W exponen su versión para el ETag de las respuestas.
+type Service[C, U, L any, T, W entity.Versioned] struct {
+	repo   ports.Repository[C, U, L, T, W]
+	policy ports.AuthorizationPolicy
+	audit  auditor
+	entity Entity[C, U, L, T]
+}
+
+// NewCRUDService crea el servicio de una entidad; con audit nil las escrituras no se auditan.
+func NewCRUDService[C, U, L any, T, W entity.Versioned](repo ports.Repository[C, U, L, T, W], policy ports.AuthorizationPolicy, audit ports.DBRepositoryAudit, definition Entity[C, U, L, T]) *Service[C, U, L, T, W] {
+	return &Service[C, U, L, T, W]{
+		repo:   repo,
+		policy: policy,
+		audit:  auditor{repo: audit, entityType: definition.AuditType},
+		entity: definition,
+	}
+}
+
+// authorizeWrite verifica permission sobre el registro id y retorna su estado previo para la
+// auditoría. Con Owner el registro se lee siempre, porque el dueño está en él. Se llama dentro de
+// atomic: el registro se lee bloqueado en la misma transacción que la escritura, así que ni el
+// dueño verificado ni el estado auditado pueden cambiar antes de escribir.
+func (s *Service[C, U, L, T, W]) authorizeWrite(ctx context.Context, permission auth.Permission, id string) (*T, error) {
+	if s.entity.Owner == nil {
+		if err := authorize(ctx, s.policy, permission, id); err != nil {
+			return nil, err
+		}
+		if !s.audit.enabled() {
+			return nil, nil
+		}
+		return s.repo.Select(ctx, &entity.GetRecord{ID: id, ForUpdate: true})
+	}
+
+	current, err := s.repo.Select(ctx, &entity.GetRecord{ID: id, ForUpdate: true})
+	if err != nil {
+		return nil, err
+	}
+	return current, authorize(ctx, s.policy, permission, s.entity.Owner(current))
+}
+
+func (s *Service[C, U, L, T, W]) Create(ctx context.Context, request *C) (*entity.Response, error) {
+
+	if err := authorize(ctx, s.policy, s.entity.Permissions.Create, ""); err != nil {
+		return nil, err
+	}
+	if s.entity.Prepare != nil {
+		if err := s.entity.Prepare(ctx, request); err != nil {
+			return nil, err
+		}
+	}
+
+	var resp string
+	err := s.audit.atomic(ctx, func(ctx context.Context) error {
+		var err error
+		if resp, err = s.repo.Create(ctx, request); err != nil {
+			return err
+		}
+		return s.audit.recordCreate(ctx, resp, func() (any, error) {
+			return s.repo.Select(ctx, &entity.GetRecord{ID: resp})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.response(resp, 0, "Registro Creado", "Create"), nil
+
+}
+
+func (s *Service[C, U, L, T, W]) Select(ctx context.Context, request *entity.GetRecord) (*entity.Response, error) {
+
+	if err := authorize(ctx, s.policy, s.entity.Permissions.Read, ""); err != nil {
+		return nil, err
+	}
+	if err := authorizeDeleted(ctx, s.policy, request.IncludeDeleted); err != nil {
+		return nil, err
+	}
+	if s.entity.ValidateSelect != nil {
+		if err := s.entity.ValidateSelect(request); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.repo.Select(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if s.entity.Expand != nil {
+		if err := s.entity.Expand(ctx, resp, request); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.response(resp, (*resp).GetVersion(), "Registro Seleccionado", "Select"), nil
+
+}
+
+func (s *Service[C, U, L, T, W]) Update(ctx context.Context, request *U) (*entity.Response, error) {
+
+	id := s.entity.UpdateID(request)
+	var resp *W
+	err := s.audit.atomic(ctx, func(ctx context.Context) error {
+		current, err := s.authorizeWrite(ctx, s.entity.Permissions.Update, id)
+		if err != nil {
+			return err
+		}
+		if resp, err = s.repo.Update(ctx, request); err != nil {
+			return err
+		}
+		return s.audit.record(ctx, modelAudit.ActionUpdate, id, current, resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.response(resp, (*resp).GetVersion(), "Registro Actualizado", "Update"), nil
+
+}
+
+func (s *Service[C, U, L, T, W]) Delete(ctx context.Context, request *entity.DeleteRecord) (*entity.Response, error) {
+
+	err := s.audit.atomic(ctx, func(ctx context.Context) error {
+		current, err := s.authorizeWrite(ctx, s.entity.Permissions.Delete, request.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, request); err != nil {
+			return err
+		}
+		return s.audit.record(ctx, modelAudit.ActionDelete, request.ID, current, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.response(nil, 0, "Registro Eliminado", "Delete"), nil
+
+}
+
+func (s *Service[C, U, L, T, W]) Restore(ctx context.Context, request *entity.RestoreRecord) (*entity.Response, error) {
+
+	var resp *W
+	err := s.audit.atomic(ctx, func(ctx context.Context) error {
+		owner := request.ID
+		if s.entity.Owner != nil {
+			// El registro eliminado se carga igual para verificar el permiso contra su dueño.
+			current, err := s.repo.Select(ctx, &entity.GetRecord{ID: request.ID, IncludeDeleted: true, ForUpdate: true})
+			if err != nil {
+				return err
+			}
+			owner = s.entity.Owner(current)
+		}
+		if err := authorize(ctx, s.policy, s.entity.Permissions.Restore, owner); err != nil {
+			return err
+		}
+
+		var err error
+		if resp, err = s.repo.Restore(ctx, request); err != nil {
+			return err
+		}
+		return s.audit.record(ctx, modelAudit.ActionRestore, request.ID, nil, resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.response(resp, (*resp).GetVersion(), "Registro Restaurado", "Restore"), nil
+
+}
+
+func (s *Service[C, U, L, T, W]) List(ctx context.Context, request *L) (*entity.ResponseWithList, error) {
+
+	page, filter := s.entity.List(request)
+	if err := authorize(ctx, s.policy, s.entity.Permissions.Read, ""); err != nil {
+		return nil, err
+	}
+	if err := authorizeDeleted(ctx, s.policy, filter.IncludeDeleted); err != nil {
+		return nil, err
+	}
+
+	page.Normalize()
+
+	resp, err := s.repo.List(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ResponseWithList{
+		Data:       resp.Data(),
+		Pagination: entity.NewPagination(*page, resp),
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusOK),
+					Message:      http.StatusText(http.StatusOK),
+					Detail:       "Registros Listados",
+				},
+			},
+			Source: "List " + s.entity.Plural,
+		},
+	}, nil
+
+}
+
+// response arma la respuesta de una operación sobre un registro; Delete no retorna datos.
+func (s *Service[C, U, L, T, W]) response(data any, version int64, detail, operation string) *entity.Response {
+	return &entity.Response{
+		Data:    data,
+		Version: version,
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusOK),
+					Message:      http.StatusText(http.StatusOK),
+					Detail:       detail,
+				},
+			},
+			Source: operation + " " + s.entity.Name,
+		},
+	}
+}
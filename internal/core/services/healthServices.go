@@ -0,0 +1,91 @@
+package service
+
+import (
+	"CrudPlatform/internal/core/domain/health"
+	"CrudPlatform/internal/core/domain/logging"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// errShuttingDown es el resultado de la verificación shutdown mientras el servidor se apaga.
+var errShuttingDown = errors.New("server is shutting down")
+
+// ServiceHealth responde los probes. Live solo confirma que el proceso atiende requests; Ready
+// además ejecuta las verificaciones de las dependencias, cada una limitada a timeout.
+type ServiceHealth struct {
+	checks   []health.Check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+func NewServiceHealth(timeout time.Duration, checks ...health.Check) *ServiceHealth {
+	return &ServiceHealth{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// Drain hace que Ready falle desde ahora, para que Kubernetes deje de enviar tráfico al pod
+// mientras termina los requests en curso.
+func (s *ServiceHealth) Drain() {
+	s.draining.Store(true)
+}
+
+func (s *ServiceHealth) Live(ctx context.Context) *health.Report {
+	return &health.Report{Status: health.StatusOK}
+}
+
+// Ready ejecuta las verificaciones en paralelo; el reporte falla si alguna falla o si el servidor
+// se está apagando.
+func (s *ServiceHealth) Ready(ctx context.Context) *health.Report {
+	checks := append([]health.Check{{Name: "shutdown", Run: s.checkDraining}}, s.checks...)
+	results := make([]health.Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := &health.Report{Status: health.StatusOK, Checks: make(map[string]health.Result, len(checks))}
+	for i, check := range checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != health.StatusOK {
+			report.Status = health.StatusFail
+		}
+	}
+	return report
+}
+
+func (s *ServiceHealth) run(ctx context.Context, check health.Check) health.Result {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := check.Run(ctx)
+	result := health.Result{Status: health.StatusOK, DurationMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		// /readyz es público: el error del driver, con hosts o usuarios, solo va al log.
+		logging.FromContext(ctx).Warn("readiness check failed", "check", check.Name, "error", err)
+		result.Status = health.StatusFail
+		result.Error = health.ErrorUnavailable
+	}
+	return result
+}
+
+func (s *ServiceHealth) checkDraining(context.Context) error {
+	if s.draining.Load() {
+		return errShuttingDown
+	}
+	return nil
+}
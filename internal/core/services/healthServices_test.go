@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"CrudPlatform/internal/core/domain/health"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceHealth_Ready(t *testing.T) {
+	ok := health.Check{Name: "database", Run: func(context.Context) error { return nil }}
+	failing := health.Check{Name: "migrations", Run: func(context.Context) error { return errors.New("pending migrations: 1") }}
+
+	t.Run("Ok", func(t *testing.T) {
+		report := NewServiceHealth(time.Second, ok).Ready(context.Background())
+
+		assert.True(t, report.Healthy())
+		assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, health.StatusOK, report.Checks["shutdown"].Status)
+	})
+
+	t.Run("FailingCheck", func(t *testing.T) {
+		report := NewServiceHealth(time.Second, ok, failing).Ready(context.Background())
+
+		assert.False(t, report.Healthy())
+		assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, health.StatusFail, report.Checks["migrations"].Status)
+		// El detalle va al log; el reporte, que es público, no lo expone.
+		assert.Equal(t, health.ErrorUnavailable, report.Checks["migrations"].Error)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		// Una verificación colgada se corta al vencer el timeout en lugar de bloquear el probe.
+		hanging := health.Check{Name: "database", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+		report := NewServiceHealth(10*time.Millisecond, hanging).Ready(context.Background())
+
+		assert.False(t, report.Healthy())
+		assert.Equal(t, health.StatusFail, report.Checks["database"].Status)
+	})
+
+	t.Run("Draining", func(t *testing.T) {
+		service := NewServiceHealth(time.Second, ok)
+		service.Drain()
+
+		ready := service.Ready(context.Background())
+		assert.False(t, ready.Healthy())
+		assert.Equal(t, health.StatusFail, ready.Checks["shutdown"].Status)
+
+		// Mientras drena el proceso sigue vivo: Kubernetes no debe reiniciarlo.
+		assert.True(t, service.Live(context.Background()).Healthy())
+	})
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/ports"
+	"context"
+)
+
+// authorize consulta la política con los claims del usuario autenticado en el contexto.
+func authorize(ctx context.Context, policy ports.AuthorizationPolicy, permission auth.Permission, ownerID string) error {
+	claims, _ := auth.FromContext(ctx)
+	return policy.Authorize(claims, permission, ownerID)
+}
+
+// authorizeDeleted exige el permiso de leer registros eliminados cuando la consulta los incluye.
+func authorizeDeleted(ctx context.Context, policy ports.AuthorizationPolicy, includeDeleted bool) error {
+	if !includeDeleted {
+		return nil
+	}
+	return authorize(ctx, policy, auth.PermissionDeletedRead, "")
+}
+
+// currentUserID retorna el id del usuario autenticado, o vacío si no hay uno.
+func currentUserID(ctx context.Context) string {
+	if claims, ok := auth.FromContext(ctx); ok {
+		return claims.Subject
+	}
+	return ""
+}
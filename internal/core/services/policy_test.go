@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"CrudPlatform/internal/core/domain/auth"
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelChallenge "CrudPlatform/internal/core/domain/repository/model/challenges"
+	model "CrudPlatform/internal/core/domain/repository/model/users"
+	schemaChallenges "CrudPlatform/internal/core/domain/repository/schema/challenges"
+	schemaUsers "CrudPlatform/internal/core/domain/repository/schema/users"
+	schemaVideos "CrudPlatform/internal/core/domain/repository/schema/videos"
+	mockRepository "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testContext crea un contexto con los claims de un usuario autenticado, como lo deja el middleware.
+func testContext(subject string, role auth.Role) context.Context {
+	return auth.WithClaims(context.Background(), &auth.Claims{Subject: subject, Role: role})
+}
+
+func assertForbidden(t *testing.T, err error, permission auth.Permission) {
+	var forbidden *auth.ForbiddenError
+	if assert.ErrorAs(t, err, &forbidden) {
+		assert.Equal(t, permission, forbidden.Permission)
+	}
+}
+
+func TestCreateChallenge_RecordsCreator(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(request *modelChallenge.Challenge) bool {
+		return request.CreatedBy == "creator-1"
+	})).Return("123", nil)
+
+	_, err := svc.Create(testContext("creator-1", auth.RoleCreator), &modelChallenge.Challenge{Title: "Test Challenge"})
+	assert.NoError(t, err)
+}
+
+func TestCreateChallenge_ViewerForbidden(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	_, err := svc.Create(testContext("viewer-1", auth.RoleViewer), &modelChallenge.Challenge{Title: "Test Challenge"})
+	assertForbidden(t, err, auth.PermissionChallengesCreate)
+}
+
+func TestUpdateChallenge_NotOwnerForbidden(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schemaChallenges.ChallengeGetResponse{ID: "123", CreatedBy: "creator-1"}, nil)
+
+	_, err := svc.Update(testContext("creator-2", auth.RoleCreator), &modelChallenge.UpdateChallenge{ID: "123"})
+	assertForbidden(t, err, auth.PermissionChallengesUpdate)
+}
+
+func TestDeleteChallenge_AdminAllowed(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schemaChallenges.ChallengeGetResponse{ID: "123", CreatedBy: "creator-1"}, nil)
+	mockRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := svc.Delete(testContext("admin-1", auth.RoleAdmin), &entity.DeleteRecord{ID: "123"})
+	assert.NoError(t, err)
+}
+
+func TestDeleteVideo_NotOwnerForbidden(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryVideo(t)
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schemaVideos.VideosGetResponse{ID: "123", CreatedBy: "creator-1"}, nil)
+
+	_, err := svc.Delete(testContext("creator-2", auth.RoleCreator), &entity.DeleteRecord{ID: "123"})
+	assertForbidden(t, err, auth.PermissionVideosDelete)
+}
+
+func TestUpdateUser_OtherProfileForbidden(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
+
+	_, err := svc.Update(testContext("user-1", auth.RoleCreator), &model.UpdateUser{Id: "user-2"})
+	assertForbidden(t, err, auth.PermissionUsersUpdate)
+}
+
+func TestCreateUser_OnlyAdmin(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
+
+	_, err := svc.Create(testContext("user-1", auth.RoleCreator), &model.User{Name: "John Doe"})
+	assertForbidden(t, err, auth.PermissionUsersCreate)
+}
+
+func TestCreateUser_InvalidRole(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
+
+	_, err := svc.Create(testContext("admin-1", auth.RoleAdmin), &model.User{Name: "John Doe", Role: "root"})
+	assert.EqualError(t, err, `invalid role "root"`)
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+}
+
+func TestSelectVideo_WithoutClaimsForbidden(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryVideo(t)
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
+
+	c := context.Background()
+	_, err := svc.Select(c, &entity.GetRecord{ID: "123"})
+	assertForbidden(t, err, auth.PermissionVideosRead)
+}
+
+func TestListChallenges_IncludeDeletedOnlyAdmin(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	request := &modelChallenge.ListChallenges{ListFilter: entity.ListFilter{IncludeDeleted: true}}
+	_, err := svc.List(testContext("creator-1", auth.RoleCreator), request)
+	assertForbidden(t, err, auth.PermissionDeletedRead)
+}
+
+func TestSelectUser_IncludeDeletedAdmin(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
+
+	deletedAt := "2024-01-01T00:00:00Z"
+	mockRepo.On("Select", mock.Anything, &entity.GetRecord{ID: "123", IncludeDeleted: true}).
+		Return(&schemaUsers.UsersGetResponse{ID: "123", Version: 2, DeletedAt: &deletedAt}, nil)
+
+	resp, err := svc.Select(testContext("admin-1", auth.RoleAdmin), &entity.GetRecord{ID: "123", IncludeDeleted: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Version)
+}
+
+func TestRestoreChallenge_OwnerAllowed(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryChallenge(t)
+	svc := NewServiceChallenge(mockRepo, auth.NewPolicy(), nil)
+
+	// El challenge eliminado se carga incluyendo los eliminados para conocer a su creador.
+	mockRepo.On("Select", mock.Anything, &entity.GetRecord{ID: "123", IncludeDeleted: true, ForUpdate: true}).
+		Return(&schemaChallenges.ChallengeGetResponse{ID: "123", CreatedBy: "creator-1"}, nil)
+	mockRepo.On("Restore", mock.Anything, &entity.RestoreRecord{ID: "123"}).
+		Return(&schemaChallenges.ChallengeUpdateResponse{ID: "123", Version: 3}, nil)
+
+	resp, err := svc.Restore(testContext("creator-1", auth.RoleCreator), &entity.RestoreRecord{ID: "123"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Version)
+	assert.Equal(t, "Registro Restaurado", resp.Result.Details[0].Detail)
+}
+
+func TestRestoreVideo_NotOwnerForbidden(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryVideo(t)
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schemaVideos.VideosGetResponse{ID: "123", CreatedBy: "creator-1"}, nil)
+
+	_, err := svc.Restore(testContext("creator-2", auth.RoleCreator), &entity.RestoreRecord{ID: "123"})
+	assertForbidden(t, err, auth.PermissionVideosRestore)
+}
+
+func TestRestoreUser_OnlyAdmin(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
+
+	_, err := svc.Restore(testContext("user-1", auth.RoleCreator), &entity.RestoreRecord{ID: "user-1"})
+	assertForbidden(t, err, auth.PermissionUsersRestore)
+}
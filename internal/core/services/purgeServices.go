@@ -0,0 +1,115 @@
+package service
+
+import (
+	"CrudPlatform/internal/core/domain/logging"
+	"CrudPlatform/internal/core/ports"
+	"context"
+	"errors"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+)
+
+// PurgeJob borra definitivamente los registros que llevan más de retention eliminados, junto con
+// sus archivos, y los uploads por partes que no se completaron dentro de uploadTTL.
+// Mientras no se purgan, los registros eliminados se pueden restaurar.
+type PurgeJob struct {
+	repos     []ports.DBPurger
+	uploads   ports.DBRepositoryVideoUploads
+	blobs     ports.BlobStore
+	retention time.Duration
+	uploadTTL time.Duration
+	interval  time.Duration
+	now       func() time.Time
+}
+
+func NewPurgeJob(retention, uploadTTL, interval time.Duration, blobs ports.BlobStore, uploads ports.DBRepositoryVideoUploads, repos ...ports.DBPurger) *PurgeJob {
+	return &PurgeJob{
+		repos:     repos,
+		uploads:   uploads,
+		blobs:     blobs,
+		retention: retention,
+		uploadTTL: uploadTTL,
+		interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// Purge borra los registros eliminados antes del período de retención en todos los repositorios,
+// y después sus archivos, y retorna cuántos registros borró. Un repositorio o un archivo que falla
+// no impide purgar los demás.
+func (j *PurgeJob) Purge(ctx context.Context) (int64, error) {
+	before := j.now().UTC().Add(-j.retention)
+
+	var total int64
+	var errs []error
+	for _, repo := range j.repos {
+		purged, keys, err := repo.PurgeDeleted(ctx, before)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total += purged
+		errs = append(errs, j.removeBlobs(ctx, keys))
+	}
+	return total, errors.Join(errs...)
+}
+
+// ExpireUploads borra los uploads creados hace más de uploadTTL, que ya no se completarán, y las
+// partes que habían recibido, y retorna cuántos borró.
+func (j *PurgeJob) ExpireUploads(ctx context.Context) (int64, error) {
+	expired, keys, err := j.uploads.ExpireVideoUploads(ctx, j.now().UTC().Add(-j.uploadTTL))
+	if err != nil {
+		return 0, err
+	}
+	return expired, j.removeBlobs(ctx, keys)
+}
+
+// removeBlobs borra los archivos que ya no usa ningún registro. Una llave que ya no existe no es
+// un error, para que borrarlos de nuevo después de una purga interrumpida no falle.
+func (j *PurgeJob) removeBlobs(ctx context.Context, keys []string) error {
+	var errs []error
+	for _, key := range keys {
+		if err := j.blobs.Delete(ctx, key); err != nil && entity.KindOf(err) != entity.KindNotFound {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run purga cada interval hasta que ctx se cancela. Una retención de 0 desactiva la purga de los
+// registros eliminados y un uploadTTL de 0 la de los uploads; un interval de 0 desactiva ambas.
+func (j *PurgeJob) Run(ctx context.Context) {
+	if j.interval <= 0 || (j.retention <= 0 && j.uploadTTL <= 0) {
+		return
+	}
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if j.retention > 0 {
+				purged, err := j.Purge(ctx)
+				if err != nil {
+					logging.FromContext(ctx).Error("purge deleted records", "error", err)
+				}
+				if purged > 0 {
+					logging.FromContext(ctx).Info("purged deleted records", "count", purged)
+				}
+			}
+			if j.uploadTTL > 0 {
+				expired, err := j.ExpireUploads(ctx)
+				if err != nil {
+					logging.FromContext(ctx).Error("expire uploads", "error", err)
+				}
+				if expired > 0 {
+					logging.FromContext(ctx).Info("expired uploads", "count", expired)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	mockRepository "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeJob_Purge(t *testing.T) {
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	before := now.Add(-24 * time.Hour)
+
+	blobs := &memoryBlobs{}
+	for _, key := range []string{"videos/1/a", "avatars/2/b/64.png", "videos/3/kept"} {
+		require.NoError(t, blobs.Put(context.Background(), key, strings.NewReader("x"), 1))
+	}
+
+	videos := mockRepository.NewDBPurger(t)
+	challenges := mockRepository.NewDBPurger(t)
+	users := mockRepository.NewDBPurger(t)
+	videos.On("PurgeDeleted", context.Background(), before).Return(int64(2), []string{"videos/1/a", "uploads/gone/1"}, nil)
+	challenges.On("PurgeDeleted", context.Background(), before).Return(int64(0), nil, errors.New("error simulado"))
+	users.On("PurgeDeleted", context.Background(), before).Return(int64(1), []string{"avatars/2/b/64.png"}, nil)
+
+	job := NewPurgeJob(24*time.Hour, 0, time.Hour, blobs, mockRepository.NewDBRepositoryVideoUploads(t), videos, challenges, users)
+	job.now = func() time.Time { return now }
+
+	// Un repositorio con error no impide purgar los demás, y un archivo que ya no existe no es un error.
+	purged, err := job.Purge(context.Background())
+	assert.EqualError(t, err, "error simulado")
+	assert.Equal(t, int64(3), purged)
+	assert.Equal(t, []string{"videos/3/kept"}, blobs.keys())
+}
+
+func TestPurgeJob_ExpireUploads(t *testing.T) {
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	blobs := &memoryBlobs{}
+	require.NoError(t, blobs.Put(context.Background(), "uploads/1/a", strings.NewReader("x"), 1))
+	uploads := mockRepository.NewDBRepositoryVideoUploads(t)
+	uploads.On("ExpireVideoUploads", context.Background(), now.Add(-6*time.Hour)).Return(int64(1), []string{"uploads/1/a"}, nil)
+
+	job := NewPurgeJob(0, 6*time.Hour, time.Hour, blobs, uploads)
+	job.now = func() time.Time { return now }
+
+	expired, err := job.ExpireUploads(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), expired)
+	assert.Empty(t, blobs.keys())
+}
+
+func TestPurgeJob_RunDisabled(t *testing.T) {
+	repo := mockRepository.NewDBPurger(t)
+	uploads := mockRepository.NewDBRepositoryVideoUploads(t)
+
+	// Sin retención ni vigencia de los uploads, Run retorna de inmediato sin purgar.
+	NewPurgeJob(0, 0, time.Hour, &memoryBlobs{}, uploads, repo).Run(context.Background())
+}
@@ -1,113 +1,49 @@
 package service
 
 import (
+	"CrudPlatform/internal/core/domain/auth"
 	"CrudPlatform/internal/core/ports"
-	"net/http"
-	"strconv"
+	"context"
 
 	entity "CrudPlatform/internal/core/domain/repository"
+	modelAudit "CrudPlatform/internal/core/domain/repository/model/audit"
 	model "CrudPlatform/internal/core/domain/repository/model/users"
-
-	"github.com/gin-gonic/gin"
+	schema "CrudPlatform/internal/core/domain/repository/schema/users"
 )
 
-type Repository struct {
-	repo ports.DBRepositoryUsers
-}
-
-func NewService(repo ports.DBRepositoryUsers) *Repository {
-	return &Repository{
-		repo: repo,
-	}
-}
-
-func (r *Repository) CreateUser(ctx *gin.Context, request *model.User) (*entity.Response, error) {
-
-	resp, err := r.repo.CreateUser(ctx, request)
-	if err != nil {
-		return nil, err
-	}
-
-	return &entity.Response{
-		Data: resp,
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Creado",
-				},
-			},
-			Source: "Create User",
+type Repository = Service[model.User, model.UpdateUser, model.ListUsers, schema.UsersGetResponse, schema.UsersUpdateResponse]
+
+// NewService crea el servicio de usuarios. Los permisos sobre un usuario se verifican contra su
+// propio id, así que no define Owner.
+func NewService(repo ports.DBRepositoryUsers, policy ports.AuthorizationPolicy, audit ports.DBRepositoryAudit) *Repository {
+	return NewCRUDService(repo, policy, audit, Entity[model.User, model.UpdateUser, model.ListUsers, schema.UsersGetResponse]{
+		Name:      "User",
+		Plural:    "Users",
+		AuditType: modelAudit.EntityUser,
+		Permissions: Permissions{
+			Create:  auth.PermissionUsersCreate,
+			Read:    auth.PermissionUsersRead,
+			Update:  auth.PermissionUsersUpdate,
+			Delete:  auth.PermissionUsersDelete,
+			Restore: auth.PermissionUsersRestore,
 		},
-	}, nil
-
-}
-
-func (r *Repository) SelectUser(ctx *gin.Context, request *model.GetUser) (*entity.Response, error) {
-
-	resp, err := r.repo.SelectUser(ctx, request)
-	if err != nil {
-		return nil, err
-	}
-
-	return &entity.Response{
-		Data: resp,
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Seleccionado",
-				},
-			},
-			Source: "Select User",
+		UpdateID: func(request *model.UpdateUser) string { return request.Id },
+		List: func(request *model.ListUsers) (*entity.PageRequest, *entity.ListFilter) {
+			return &request.PageRequest, &request.ListFilter
 		},
-	}, nil
-
+		Prepare: prepareUser,
+	})
 }
 
-func (r *Repository) UpdateUser(ctx *gin.Context, request *model.UpdateUser) (*entity.Response, error) {
-
-	resp, err := r.repo.UpdateUser(ctx, request)
-	if err != nil {
-		return nil, err
+// prepareUser asigna el rol por defecto y reemplaza la contraseña por su hash.
+func prepareUser(_ context.Context, request *model.User) error {
+	if request.Role == "" {
+		request.Role = string(auth.RoleViewer)
 	}
-
-	return &entity.Response{
-		Data: resp,
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Actualizado",
-				},
-			},
-			Source: "Update User",
-		},
-	}, nil
-
-}
-
-func (r *Repository) DeleteUser(ctx *gin.Context, request *model.DeleteUser) (*entity.Response, error) {
-
-	err := r.repo.DeleteUser(ctx, request)
-	if err != nil {
-		return nil, err
+	if !auth.Role(request.Role).Valid() {
+		return entity.NewError(entity.KindValidation, "invalid role %q", request.Role)
 	}
 
-	return &entity.Response{
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Eliminado",
-				},
-			},
-			Source: "Delete User",
-		},
-	}, nil
-
+	// La contraseña nunca llega al repositorio en texto plano.
+	return request.HashPassword()
 }
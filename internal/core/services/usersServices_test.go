@@ -2,32 +2,33 @@ package service
 
 import (
 	"errors"
-	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"CrudPlatform/internal/core/domain/auth"
 	entity "CrudPlatform/internal/core/domain/repository"
 	model "CrudPlatform/internal/core/domain/repository/model/users"
 	schema "CrudPlatform/internal/core/domain/repository/schema/users"
 	mockRepository "CrudPlatform/internal/core/ports/mocks"
 
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestNewService(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryUsers(t)
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, auth.NewPolicy(), mockRepository.NewDBRepositoryAudit(t))
 	assert.NotNil(t, service, "El servicio no debe ser nil")
 }
 
 func TestCreateUser(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryUsers(t)
-	svc := &Repository{repo: mockRepo}
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
 
-	mockRepo.On("CreateUser", mock.Anything, mock.Anything).Return("123", nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return("123", nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c := testContext("user-1", auth.RoleAdmin)
 	req := &model.User{
 		Name:      "John Doe",
 		Email:     "john@example.com",
@@ -44,42 +45,76 @@ func TestCreateUser(t *testing.T) {
 		},
 	}
 
-	response, err := svc.CreateUser(c, req)
+	response, err := svc.Create(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
+func TestCreateUser_HashesPassword(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(user *model.User) bool {
+		return user.Password == "" && bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("s3cret")) == nil
+	})).Return("123", nil)
+
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &model.User{
+		Name:     "John Doe",
+		Email:    "john@example.com",
+		Password: "s3cret",
+	}
+
+	_, err := svc.Create(c, req)
+	assert.NoError(t, err)
+}
+
+func TestCreateUser_PasswordTooLongForBcrypt(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
+
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &model.User{
+		Name:     "John Doe",
+		Email:    "john@example.com",
+		Password: strings.Repeat("é", 40),
+	}
+
+	_, err := svc.Create(c, req)
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+}
+
 func TestCreateUser_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryUsers(t)
-	svc := &Repository{repo: mockRepo}
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
 
-	mockRepo.On("CreateUser", mock.Anything, mock.Anything).Return("", errors.New("error simulado"))
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return("", errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c := testContext("user-1", auth.RoleAdmin)
 	req := &model.User{
 		Name:      "John Doe",
 		Email:     "john@example.com",
 		ImagePath: "/path/to/image.jpg",
 	}
 
-	response, err := svc.CreateUser(c, req)
+	response, err := svc.Create(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
 
 func TestSelectUser(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryUsers(t)
-	svc := &Repository{repo: mockRepo}
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
 
 	mockResponse := &schema.UsersGetResponse{
 		Name:      "John Doe",
 		Email:     "john@example.com",
 		ImagePath: "/path/to/image.jpg",
 	}
-	mockRepo.On("SelectUser", mock.Anything, mock.Anything).Return(mockResponse, nil)
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(mockResponse, nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.GetUser{Id: "123"}
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &entity.GetRecord{ID: "123"}
 
 	expectedResp := &entity.Response{
 		Data: mockResponse,
@@ -91,43 +126,42 @@ func TestSelectUser(t *testing.T) {
 		},
 	}
 
-	response, err := svc.SelectUser(c, req)
+	response, err := svc.Select(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestSelectUser_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryUsers(t)
-	svc := &Repository{repo: mockRepo}
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
 
-	mockRepo.On("SelectUser", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.GetUser{Id: "123"}
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &entity.GetRecord{ID: "123"}
 
-	response, err := svc.SelectUser(c, req)
+	response, err := svc.Select(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
 
 func TestUpdateUser(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryUsers(t)
-	svc := &Repository{repo: mockRepo}
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
 
 	mockResponse := &schema.UsersUpdateResponse{
 		Name:      "John Doe Updated",
 		Email:     "john_updated@example.com",
 		ImagePath: "/path/to/new_image.jpg",
 	}
-	mockRepo.On("UpdateUser", mock.Anything, mock.Anything).Return(mockResponse, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(mockResponse, nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.UpdateUser{
-		Id:        "123",
+	c := testContext("123", auth.RoleViewer)
+	req := model.ReplaceUser{
 		Name:      "John Doe Updated",
 		Email:     "john_updated@example.com",
 		ImagePath: "/path/to/new_image.jpg",
-	}
+	}.Update("123")
 
 	expectedResp := &entity.Response{
 		Data: mockResponse,
@@ -139,38 +173,37 @@ func TestUpdateUser(t *testing.T) {
 		},
 	}
 
-	response, err := svc.UpdateUser(c, req)
+	response, err := svc.Update(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestUpdateUser_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryUsers(t)
-	svc := &Repository{repo: mockRepo}
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
 
-	mockRepo.On("UpdateUser", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.UpdateUser{
-		Id:        "123",
+	c := testContext("123", auth.RoleViewer)
+	req := model.ReplaceUser{
 		Name:      "John Doe Updated",
 		Email:     "john_updated@example.com",
 		ImagePath: "/path/to/new_image.jpg",
-	}
+	}.Update("123")
 
-	response, err := svc.UpdateUser(c, req)
+	response, err := svc.Update(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
 
 func TestDeleteUser(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryUsers(t)
-	svc := &Repository{repo: mockRepo}
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
 
-	mockRepo.On("DeleteUser", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.DeleteUser{Id: "123"}
+	c := testContext("123", auth.RoleViewer)
+	req := &entity.DeleteRecord{ID: "123"}
 
 	expectedResp := &entity.Response{
 		Result: entity.Result{
@@ -181,21 +214,70 @@ func TestDeleteUser(t *testing.T) {
 		},
 	}
 
-	response, err := svc.DeleteUser(c, req)
+	response, err := svc.Delete(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestDeleteUser_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryUsers(t)
-	svc := &Repository{repo: mockRepo}
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
+
+	mockRepo.On("Delete", mock.Anything, mock.Anything).Return(errors.New("error simulado"))
+
+	c := testContext("123", auth.RoleViewer)
+	req := &entity.DeleteRecord{ID: "123"}
+
+	response, err := svc.Delete(c, req)
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestListUsers(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
+
+	mockPage := &entity.Page[schema.UsersGetResponse]{
+		Items:      []schema.UsersGetResponse{{ID: "123", Name: "John Doe", Email: "john@example.com"}},
+		Total:      11,
+		NextCursor: "next",
+	}
+	mockRepo.On("List", mock.Anything, mock.Anything).Return(mockPage, nil)
+
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &model.ListUsers{PageRequest: entity.PageRequest{PageSize: 50}}
+
+	expectedResp := &entity.ResponseWithList{
+		Data: []interface{}{mockPage.Items[0]},
+		Pagination: &entity.Pagination{
+			Total:      11,
+			Page:       1,
+			PageSize:   entity.MaxPageSize,
+			NextCursor: "next",
+		},
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{InternalCode: "200", Message: "OK", Detail: "Registros Listados"},
+			},
+			Source: "List Users",
+		},
+	}
+
+	response, err := svc.List(c, req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResp, response)
+}
+
+func TestListUsers_ErrorCase(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewService(mockRepo, auth.NewPolicy(), nil)
 
-	mockRepo.On("DeleteUser", mock.Anything, mock.Anything).Return(errors.New("error simulado"))
+	mockRepo.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.DeleteUser{Id: "123"}
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &model.ListUsers{}
 
-	response, err := svc.DeleteUser(c, req)
+	response, err := svc.List(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
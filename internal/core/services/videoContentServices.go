@@ -0,0 +1,462 @@
+package service
+
+import (
+	"CrudPlatform/internal/core/domain/auth"
+	"CrudPlatform/internal/core/domain/logging"
+	"CrudPlatform/internal/core/domain/media"
+	"CrudPlatform/internal/core/ports"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	entity "CrudPlatform/internal/core/domain/repository"
+	modelAudit "CrudPlatform/internal/core/domain/repository/model/audit"
+	model "CrudPlatform/internal/core/domain/repository/model/videos"
+	schema "CrudPlatform/internal/core/domain/repository/schema/videos"
+
+	"github.com/google/uuid"
+)
+
+// RepositoryVideoContent gestiona los archivos de los videos: los guarda en el BlobStore, ya sea
+// en un solo request o por partes, y registra en el video su tamaño, tipo, checksum y duración.
+type RepositoryVideoContent struct {
+	videos  ports.DBRepositoryVideo
+	uploads ports.DBRepositoryVideoUploads
+	blobs   ports.BlobStore
+	policy  ports.AuthorizationPolicy
+	audit   auditor
+	maxSize int64
+	now     func() time.Time
+}
+
+func NewServiceVideoContent(videos ports.DBRepositoryVideo, uploads ports.DBRepositoryVideoUploads, blobs ports.BlobStore, policy ports.AuthorizationPolicy, audit ports.DBRepositoryAudit, maxSize int64) *RepositoryVideoContent {
+	return &RepositoryVideoContent{
+		videos:  videos,
+		uploads: uploads,
+		blobs:   blobs,
+		policy:  policy,
+		audit:   auditor{repo: audit, entityType: modelAudit.EntityVideo},
+		maxSize: maxSize,
+		now:     time.Now,
+	}
+}
+
+// authorizeUpload carga el video y verifica que el usuario pueda modificarlo.
+func (r *RepositoryVideoContent) authorizeUpload(ctx context.Context, id string) (*schema.VideosGetResponse, error) {
+	current, err := r.videos.Select(ctx, &entity.GetRecord{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	return current, authorize(ctx, r.policy, auth.PermissionVideosUpdate, current.CreatedBy)
+}
+
+func (r *RepositoryVideoContent) UploadContent(ctx context.Context, request *model.UploadContent) (*entity.Response, error) {
+
+	current, err := r.authorizeUpload(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	contentType, err := r.checkUpload(request.ContentType, request.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.store(ctx, current, request.Body, request.Size, contentType, request.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Response{
+		Data:    resp,
+		Version: resp.Version,
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusOK),
+					Message:      http.StatusText(http.StatusOK),
+					Detail:       "Archivo Guardado",
+				},
+			},
+			Source: "Upload Video Content",
+		},
+	}, nil
+
+}
+
+// OpenContent abre el archivo del video; quien lo recibe debe cerrarlo.
+func (r *RepositoryVideoContent) OpenContent(ctx context.Context, request *model.GetContent) (*model.Content, error) {
+
+	if err := authorize(ctx, r.policy, auth.PermissionVideosRead, ""); err != nil {
+		return nil, err
+	}
+
+	video, err := r.videos.Select(ctx, &entity.GetRecord{ID: request.ID})
+	if err != nil {
+		return nil, err
+	}
+	if video.Content == nil {
+		return nil, entity.NewError(entity.KindNotFound, "video with id %s has no content", request.ID)
+	}
+
+	body, err := r.blobs.Open(ctx, video.Content.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt, _ := time.Parse(time.RFC3339Nano, video.UpdatedAt)
+	return &model.Content{
+		Body:        body,
+		Size:        video.Content.Size,
+		ContentType: video.Content.ContentType,
+		Checksum:    video.Content.Checksum,
+		UpdatedAt:   updatedAt,
+	}, nil
+
+}
+
+func (r *RepositoryVideoContent) CreateUpload(ctx context.Context, request *model.CreateUpload) (*entity.Response, error) {
+
+	if _, err := r.authorizeUpload(ctx, request.VideoID); err != nil {
+		return nil, err
+	}
+	if request.Length <= 0 {
+		return nil, entity.NewError(entity.KindValidation, "upload length must be positive")
+	}
+	contentType, err := r.checkUpload(request.ContentType, request.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	upload := &model.VideoUpload{
+		ID:          uuid.NewString(),
+		VideoID:     request.VideoID,
+		Length:      request.Length,
+		ContentType: contentType,
+		CreatedBy:   currentUserID(ctx),
+		CreatedAt:   r.now().UTC(),
+	}
+	if err := r.uploads.CreateVideoUpload(ctx, upload); err != nil {
+		return nil, err
+	}
+
+	return &entity.Response{
+		Data: uploadResponse(upload, upload.Offset),
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusCreated),
+					Message:      http.StatusText(http.StatusCreated),
+					Detail:       "Upload Creado",
+				},
+			},
+			Source: "Create Upload",
+		},
+	}, nil
+
+}
+
+func (r *RepositoryVideoContent) SelectUpload(ctx context.Context, request *model.GetUpload) (*entity.Response, error) {
+
+	if _, err := r.authorizeUpload(ctx, request.VideoID); err != nil {
+		return nil, err
+	}
+
+	upload, err := r.uploads.SelectVideoUpload(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Response{
+		Data: uploadResponse(upload, upload.Offset),
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusOK),
+					Message:      http.StatusText(http.StatusOK),
+					Detail:       "Registro Seleccionado",
+				},
+			},
+			Source: "Select Upload",
+		},
+	}, nil
+
+}
+
+// AppendUpload guarda la parte a continuación de lo ya recibido. La parte que completa el upload
+// une todas las partes en el archivo del video; si eso falla, un PATCH vacío en el offset final
+// lo reintenta.
+func (r *RepositoryVideoContent) AppendUpload(ctx context.Context, request *model.AppendUpload) (*entity.Response, error) {
+
+	current, err := r.authorizeUpload(ctx, request.VideoID)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := r.uploads.SelectVideoUpload(ctx, &model.GetUpload{VideoID: request.VideoID, ID: request.ID})
+	if err != nil {
+		return nil, err
+	}
+	if request.Offset != upload.Offset {
+		return nil, entity.NewError(entity.KindConflict, "upload %s is at offset %d, not %d", upload.ID, upload.Offset, request.Offset)
+	}
+	remaining := upload.Length - upload.Offset
+	if request.Size > remaining {
+		return nil, entity.NewError(entity.KindTooLarge, "part of %d bytes exceeds the %d bytes left in upload %s", request.Size, remaining, upload.ID)
+	}
+
+	offset := upload.Offset
+	if remaining > 0 {
+		part, err := r.storePart(ctx, upload, request)
+		if err != nil {
+			return nil, err
+		}
+		if part != nil {
+			offset = part.Offset + part.Size
+			upload.Parts = append(upload.Parts, *part)
+		}
+	}
+
+	response := uploadResponse(upload, offset)
+	var version int64
+	if offset == upload.Length {
+		video, err := r.store(ctx, current, &partsReader{ctx: ctx, blobs: r.blobs, parts: upload.Parts}, upload.Length, upload.ContentType, nil)
+		if err != nil {
+			return nil, err
+		}
+		r.removeUpload(ctx, upload)
+		response.Video = video
+		version = video.Version
+	}
+
+	return &entity.Response{
+		Data:    response,
+		Version: version,
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusOK),
+					Message:      http.StatusText(http.StatusOK),
+					Detail:       "Parte Recibida",
+				},
+			},
+			Source: "Append Upload",
+		},
+	}, nil
+
+}
+
+func (r *RepositoryVideoContent) DeleteUpload(ctx context.Context, request *model.GetUpload) (*entity.Response, error) {
+
+	if _, err := r.authorizeUpload(ctx, request.VideoID); err != nil {
+		return nil, err
+	}
+
+	upload, err := r.uploads.SelectVideoUpload(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.uploads.DeleteVideoUpload(ctx, request); err != nil {
+		return nil, err
+	}
+	r.discard(ctx, partKeys(upload.Parts)...)
+
+	return &entity.Response{
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{
+					InternalCode: strconv.Itoa(http.StatusOK),
+					Message:      http.StatusText(http.StatusOK),
+					Detail:       "Registro Eliminado",
+				},
+			},
+			Source: "Delete Upload",
+		},
+	}, nil
+
+}
+
+// checkUpload valida el tipo y el tamaño declarados antes de leer el contenido y retorna el tipo normalizado.
+func (r *RepositoryVideoContent) checkUpload(contentType string, size int64) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "video/") {
+		return "", entity.NewError(entity.KindUnsupportedMediaType, "content type %q is not a video", contentType)
+	}
+	if size > r.maxSize {
+		return "", entity.NewError(entity.KindTooLarge, "video exceeds the maximum size of %d bytes", r.maxSize)
+	}
+	return mediaType, nil
+}
+
+// storePart guarda una parte del upload y la registra. Una parte vacía no se registra y retorna nil.
+func (r *RepositoryVideoContent) storePart(ctx context.Context, upload *model.VideoUpload, request *model.AppendUpload) (*model.UploadPart, error) {
+	key := "uploads/" + upload.ID + "/" + uuid.NewString()
+	body := &sizeLimit{r: request.Body, max: upload.Length - upload.Offset}
+	if err := r.blobs.Put(ctx, key, body, request.Size); err != nil {
+		r.discard(ctx, key)
+		return nil, err
+	}
+	if body.read == 0 {
+		r.discard(ctx, key)
+		return nil, nil
+	}
+
+	part := &model.UploadPart{UploadID: upload.ID, Offset: upload.Offset, Size: body.read, Key: key}
+	if _, err := r.uploads.AppendVideoUploadPart(ctx, part); err != nil {
+		r.discard(ctx, key)
+		return nil, err
+	}
+	return part, nil
+}
+
+// store guarda el archivo con una llave nueva, calcula sus metadatos y los registra en el video.
+// El archivo anterior se borra solo después de actualizar el video, para que siempre tenga uno, y
+// el video solo se actualiza si todavía lo tiene: si otro upload lo reemplazó mientras tanto, el
+// archivo nuevo se borra y el request falla con KindConflict, sin dejar archivos huérfanos.
+func (r *RepositoryVideoContent) store(ctx context.Context, current *schema.VideosGetResponse, body io.Reader, size int64, contentType string, version *int64) (*schema.VideosUpdateResponse, error) {
+	var previous string
+	if current.Content != nil {
+		previous = current.Content.Key
+	}
+	key := "videos/" + current.ID + "/" + uuid.NewString()
+	hash := sha256.New()
+	counted := &sizeLimit{r: body, max: r.maxSize}
+
+	if err := r.blobs.Put(ctx, key, io.TeeReader(counted, hash), size); err != nil {
+		r.discard(ctx, key)
+		return nil, err
+	}
+	if counted.read == 0 {
+		r.discard(ctx, key)
+		return nil, entity.NewError(entity.KindValidation, "video content is empty")
+	}
+
+	update := &model.UpdateVideoContent{
+		ID:          current.ID,
+		Key:         key,
+		Size:        counted.read,
+		ContentType: contentType,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		Duration:    r.duration(ctx, key),
+		Version:     version,
+		Previous:    previous,
+	}
+	var resp *schema.VideosUpdateResponse
+	err := r.audit.atomic(ctx, func(ctx context.Context) error {
+		var err error
+		if resp, err = r.videos.UpdateVideoContent(ctx, update); err != nil {
+			return err
+		}
+		return r.audit.record(ctx, modelAudit.ActionUpdate, current.ID, current, resp)
+	})
+	if err != nil {
+		r.discard(ctx, key)
+		return nil, err
+	}
+	if previous != "" {
+		r.discard(ctx, previous)
+	}
+
+	return resp, nil
+}
+
+// duration lee la duración del archivo guardado; los formatos sin duración conocida quedan sin ella.
+func (r *RepositoryVideoContent) duration(ctx context.Context, key string) *float64 {
+	blob, err := r.blobs.Open(ctx, key)
+	if err != nil {
+		return nil
+	}
+	defer blob.Close()
+
+	duration, err := media.MP4Duration(blob)
+	if err != nil {
+		return nil
+	}
+	seconds := duration.Seconds()
+	return &seconds
+}
+
+// removeUpload borra un upload ya unido en el archivo del video. Si falla, las partes quedan
+// huérfanas pero el video ya tiene su archivo, así que solo se registra en el log.
+func (r *RepositoryVideoContent) removeUpload(ctx context.Context, upload *model.VideoUpload) {
+	if err := r.uploads.DeleteVideoUpload(context.WithoutCancel(ctx), &model.GetUpload{VideoID: upload.VideoID, ID: upload.ID}); err != nil {
+		logging.FromContext(ctx).Warn("delete completed upload", "video_id", upload.VideoID, "upload_id", upload.ID, "error", err)
+	}
+	r.discard(ctx, partKeys(upload.Parts)...)
+}
+
+// discard borra archivos que ya no se usan; un error solo se registra en el log.
+func (r *RepositoryVideoContent) discard(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := r.blobs.Delete(context.WithoutCancel(ctx), key); err != nil {
+			logging.FromContext(ctx).Warn("delete blob", "blob_key", key, "error", err)
+		}
+	}
+}
+
+func uploadResponse(upload *model.VideoUpload, offset int64) *schema.UploadResponse {
+	return &schema.UploadResponse{ID: upload.ID, VideoID: upload.VideoID, Length: upload.Length, Offset: offset}
+}
+
+func partKeys(parts []model.UploadPart) []string {
+	keys := make([]string, len(parts))
+	for i, part := range parts {
+		keys[i] = part.Key
+	}
+	return keys
+}
+
+// sizeLimit cuenta los bytes leídos y falla con KindTooLarge en cuanto se supera max.
+type sizeLimit struct {
+	r    io.Reader
+	max  int64
+	read int64
+}
+
+func (l *sizeLimit) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.max {
+		return n, entity.NewError(entity.KindTooLarge, "content exceeds %d bytes", l.max)
+	}
+	return n, err
+}
+
+// partsReader lee las partes de un upload una tras otra, abriendo cada una al llegar a ella.
+type partsReader struct {
+	ctx     context.Context
+	blobs   ports.BlobStore
+	parts   []model.UploadPart
+	current io.ReadCloser
+}
+
+func (r *partsReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.parts) == 0 {
+				return 0, io.EOF
+			}
+			blob, err := r.blobs.Open(r.ctx, r.parts[0].Key)
+			if err != nil {
+				return 0, err
+			}
+			r.current, r.parts = blob, r.parts[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"CrudPlatform/internal/core/domain/auth"
+	entity "CrudPlatform/internal/core/domain/repository"
+	model "CrudPlatform/internal/core/domain/repository/model/videos"
+	schema "CrudPlatform/internal/core/domain/repository/schema/videos"
+	mockRepository "CrudPlatform/internal/core/ports/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBlobs es un BlobStore en memoria para seguir qué archivos quedan guardados.
+type memoryBlobs struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+type memoryBlob struct {
+	*bytes.Reader
+}
+
+func (memoryBlob) Close() error { return nil }
+
+func (m *memoryBlobs) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.blobs == nil {
+		m.blobs = map[string][]byte{}
+	}
+	m.blobs[key] = data
+	return nil
+}
+
+func (m *memoryBlobs) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.blobs[key]
+	if !ok {
+		return nil, entity.NewError(entity.KindNotFound, "blob %s not found", key)
+	}
+	return memoryBlob{bytes.NewReader(data)}, nil
+}
+
+func (m *memoryBlobs) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.blobs, key)
+	return nil
+}
+
+func (m *memoryBlobs) keys() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []string
+	for key := range m.blobs {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func newVideoContentService(t *testing.T, maxSize int64) (*RepositoryVideoContent, *mockRepository.DBRepositoryVideo, *mockRepository.DBRepositoryVideoUploads, *memoryBlobs) {
+	videos := mockRepository.NewDBRepositoryVideo(t)
+	uploads := mockRepository.NewDBRepositoryVideoUploads(t)
+	blobs := &memoryBlobs{}
+	return NewServiceVideoContent(videos, uploads, blobs, auth.NewPolicy(), nil, maxSize), videos, uploads, blobs
+}
+
+func checksum(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestNewServiceVideoContent(t *testing.T) {
+	service := NewServiceVideoContent(mockRepository.NewDBRepositoryVideo(t), mockRepository.NewDBRepositoryVideoUploads(t), &memoryBlobs{}, auth.NewPolicy(), mockRepository.NewDBRepositoryAudit(t), 1<<20)
+	assert.NotNil(t, service, "El servicio no debe ser nil")
+}
+
+func TestUploadContent(t *testing.T) {
+	ctx := testContext("user-1", auth.RoleCreator)
+
+	t.Run("ReplacesPreviousFile", func(t *testing.T) {
+		svc, videos, _, blobs := newVideoContentService(t, 1<<20)
+		require.NoError(t, blobs.Put(ctx, "videos/123/old", strings.NewReader("old"), 3))
+
+		videos.On("Select", mock.Anything, &entity.GetRecord{ID: "123"}).
+			Return(&schema.VideosGetResponse{ID: "123", CreatedBy: "user-1", Content: &schema.VideoContent{Key: "videos/123/old"}}, nil)
+		videos.On("UpdateVideoContent", mock.Anything, mock.MatchedBy(func(request *model.UpdateVideoContent) bool {
+			return request.ID == "123" && request.Size == 5 && request.ContentType == "video/webm" &&
+				request.Checksum == checksum("hello") && request.Duration == nil && strings.HasPrefix(request.Key, "videos/123/") &&
+				request.Previous == "videos/123/old"
+		})).Return(&schema.VideosUpdateResponse{ID: "123", Version: 2}, nil)
+
+		response, err := svc.UploadContent(ctx, &model.UploadContent{ID: "123", ContentType: "video/webm; codecs=vp9", Size: -1, Body: strings.NewReader("hello")})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), response.Version)
+
+		keys := blobs.keys()
+		require.Len(t, keys, 1)
+		assert.NotEqual(t, "videos/123/old", keys[0])
+	})
+
+	t.Run("NotAVideo", func(t *testing.T) {
+		svc, videos, _, blobs := newVideoContentService(t, 1<<20)
+		videos.On("Select", mock.Anything, mock.Anything).Return(&schema.VideosGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
+
+		_, err := svc.UploadContent(ctx, &model.UploadContent{ID: "123", ContentType: "image/png", Size: 5, Body: strings.NewReader("hello")})
+		assert.Equal(t, entity.KindUnsupportedMediaType, entity.KindOf(err))
+		assert.Empty(t, blobs.keys())
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		svc, videos, _, blobs := newVideoContentService(t, 4)
+		videos.On("Select", mock.Anything, mock.Anything).Return(&schema.VideosGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
+
+		_, err := svc.UploadContent(ctx, &model.UploadContent{ID: "123", ContentType: "video/mp4", Size: -1, Body: strings.NewReader("hello")})
+		assert.Equal(t, entity.KindTooLarge, entity.KindOf(err))
+		assert.Empty(t, blobs.keys())
+	})
+
+	t.Run("StaleVersionKeepsPreviousFile", func(t *testing.T) {
+		svc, videos, _, blobs := newVideoContentService(t, 1<<20)
+		require.NoError(t, blobs.Put(ctx, "videos/123/old", strings.NewReader("old"), 3))
+		version := int64(1)
+
+		videos.On("Select", mock.Anything, mock.Anything).
+			Return(&schema.VideosGetResponse{ID: "123", CreatedBy: "user-1", Content: &schema.VideoContent{Key: "videos/123/old"}}, nil)
+		videos.On("UpdateVideoContent", mock.Anything, mock.MatchedBy(func(request *model.UpdateVideoContent) bool {
+			return request.Version != nil && *request.Version == 1
+		})).Return(nil, entity.NewError(entity.KindPreconditionFailed, "stale"))
+
+		_, err := svc.UploadContent(ctx, &model.UploadContent{ID: "123", ContentType: "video/mp4", Size: 5, Body: strings.NewReader("hello"), Version: &version})
+		assert.Equal(t, entity.KindPreconditionFailed, entity.KindOf(err))
+		assert.Equal(t, []string{"videos/123/old"}, blobs.keys())
+	})
+
+	t.Run("ReplacedConcurrentlyDiscardsNewFile", func(t *testing.T) {
+		svc, videos, _, blobs := newVideoContentService(t, 1<<20)
+		// Otro upload ya reemplazó videos/123/old por videos/123/other después de leer el video.
+		require.NoError(t, blobs.Put(ctx, "videos/123/other", strings.NewReader("other"), 5))
+
+		videos.On("Select", mock.Anything, mock.Anything).
+			Return(&schema.VideosGetResponse{ID: "123", CreatedBy: "user-1", Content: &schema.VideoContent{Key: "videos/123/old"}}, nil)
+		videos.On("UpdateVideoContent", mock.Anything, mock.MatchedBy(func(request *model.UpdateVideoContent) bool {
+			return request.Previous == "videos/123/old"
+		})).Return(nil, entity.NewError(entity.KindConflict, "replaced"))
+
+		_, err := svc.UploadContent(ctx, &model.UploadContent{ID: "123", ContentType: "video/mp4", Size: 5, Body: strings.NewReader("hello")})
+		assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+		assert.Equal(t, []string{"videos/123/other"}, blobs.keys())
+	})
+
+	t.Run("NotOwnerForbidden", func(t *testing.T) {
+		svc, videos, _, _ := newVideoContentService(t, 1<<20)
+		videos.On("Select", mock.Anything, mock.Anything).Return(&schema.VideosGetResponse{ID: "123", CreatedBy: "user-2"}, nil)
+
+		_, err := svc.UploadContent(ctx, &model.UploadContent{ID: "123", ContentType: "video/mp4", Size: 5, Body: strings.NewReader("hello")})
+		assertForbidden(t, err, auth.PermissionVideosUpdate)
+	})
+}
+
+func TestResumableUpload(t *testing.T) {
+	ctx := testContext("user-1", auth.RoleCreator)
+	video := &schema.VideosGetResponse{ID: "123", CreatedBy: "user-1"}
+
+	t.Run("Create", func(t *testing.T) {
+		svc, videos, uploads, _ := newVideoContentService(t, 1<<20)
+		videos.On("Select", mock.Anything, mock.Anything).Return(video, nil)
+		uploads.On("CreateVideoUpload", mock.Anything, mock.MatchedBy(func(upload *model.VideoUpload) bool {
+			return upload.ID != "" && upload.VideoID == "123" && upload.Length == 11 && upload.ContentType == "video/mp4" && upload.CreatedBy == "user-1"
+		})).Return(nil)
+
+		response, err := svc.CreateUpload(ctx, &model.CreateUpload{VideoID: "123", Length: 11, ContentType: "video/mp4"})
+		require.NoError(t, err)
+		created := response.Data.(*schema.UploadResponse)
+		assert.Equal(t, int64(0), created.Offset)
+		assert.Equal(t, int64(11), created.Length)
+	})
+
+	t.Run("CreateWithoutLength", func(t *testing.T) {
+		svc, videos, _, _ := newVideoContentService(t, 1<<20)
+		videos.On("Select", mock.Anything, mock.Anything).Return(video, nil)
+
+		_, err := svc.CreateUpload(ctx, &model.CreateUpload{VideoID: "123", Length: 0, ContentType: "video/mp4"})
+		assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+	})
+
+	t.Run("AppendPart", func(t *testing.T) {
+		svc, videos, uploads, blobs := newVideoContentService(t, 1<<20)
+		videos.On("Select", mock.Anything, mock.Anything).Return(video, nil)
+		uploads.On("SelectVideoUpload", mock.Anything, &model.GetUpload{VideoID: "123", ID: "up-1"}).
+			Return(&model.VideoUpload{ID: "up-1", VideoID: "123", Length: 11, ContentType: "video/mp4"}, nil)
+		uploads.On("AppendVideoUploadPart", mock.Anything, mock.MatchedBy(func(part *model.UploadPart) bool {
+			return part.UploadID == "up-1" && part.Offset == 0 && part.Size == 6
+		})).Return(int64(6), nil)
+
+		response, err := svc.AppendUpload(ctx, &model.AppendUpload{VideoID: "123", ID: "up-1", Offset: 0, Size: -1, Body: strings.NewReader("hello ")})
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), response.Data.(*schema.UploadResponse).Offset)
+		assert.Len(t, blobs.keys(), 1)
+	})
+
+	t.Run("LastPartAssemblesVideo", func(t *testing.T) {
+		svc, videos, uploads, blobs := newVideoContentService(t, 1<<20)
+		require.NoError(t, blobs.Put(ctx, "uploads/up-1/a", strings.NewReader("hello "), 6))
+
+		videos.On("Select", mock.Anything, mock.Anything).Return(video, nil)
+		uploads.On("SelectVideoUpload", mock.Anything, mock.Anything).
+			Return(&model.VideoUpload{ID: "up-1", VideoID: "123", Length: 11, Offset: 6, ContentType: "video/mp4",
+				Parts: []model.UploadPart{{UploadID: "up-1", Offset: 0, Size: 6, Key: "uploads/up-1/a"}}}, nil)
+		uploads.On("AppendVideoUploadPart", mock.Anything, mock.Anything).Return(int64(11), nil)
+		videos.On("UpdateVideoContent", mock.Anything, mock.MatchedBy(func(request *model.UpdateVideoContent) bool {
+			return request.Size == 11 && request.Checksum == checksum("hello world") && request.ContentType == "video/mp4"
+		})).Return(&schema.VideosUpdateResponse{ID: "123", Version: 3}, nil)
+		uploads.On("DeleteVideoUpload", mock.Anything, &model.GetUpload{VideoID: "123", ID: "up-1"}).Return(nil)
+
+		response, err := svc.AppendUpload(ctx, &model.AppendUpload{VideoID: "123", ID: "up-1", Offset: 6, Size: 5, Body: strings.NewReader("world")})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), response.Version)
+		assert.Equal(t, int64(3), response.Data.(*schema.UploadResponse).Video.Version)
+
+		keys := blobs.keys()
+		require.Len(t, keys, 1)
+		assert.True(t, strings.HasPrefix(keys[0], "videos/123/"))
+	})
+
+	t.Run("OffsetMismatch", func(t *testing.T) {
+		svc, videos, uploads, blobs := newVideoContentService(t, 1<<20)
+		videos.On("Select", mock.Anything, mock.Anything).Return(video, nil)
+		uploads.On("SelectVideoUpload", mock.Anything, mock.Anything).
+			Return(&model.VideoUpload{ID: "up-1", VideoID: "123", Length: 11, Offset: 6}, nil)
+
+		_, err := svc.AppendUpload(ctx, &model.AppendUpload{VideoID: "123", ID: "up-1", Offset: 0, Size: 5, Body: strings.NewReader("hello")})
+		assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+		assert.Empty(t, blobs.keys())
+	})
+
+	t.Run("ConcurrentPartDiscarded", func(t *testing.T) {
+		svc, videos, uploads, blobs := newVideoContentService(t, 1<<20)
+		videos.On("Select", mock.Anything, mock.Anything).Return(video, nil)
+		uploads.On("SelectVideoUpload", mock.Anything, mock.Anything).
+			Return(&model.VideoUpload{ID: "up-1", VideoID: "123", Length: 11}, nil)
+		uploads.On("AppendVideoUploadPart", mock.Anything, mock.Anything).
+			Return(int64(0), entity.NewError(entity.KindConflict, "moved"))
+
+		_, err := svc.AppendUpload(ctx, &model.AppendUpload{VideoID: "123", ID: "up-1", Offset: 0, Size: -1, Body: strings.NewReader("hello")})
+		assert.Equal(t, entity.KindConflict, entity.KindOf(err))
+		assert.Empty(t, blobs.keys())
+	})
+
+	t.Run("PartExceedsLength", func(t *testing.T) {
+		svc, videos, uploads, blobs := newVideoContentService(t, 1<<20)
+		videos.On("Select", mock.Anything, mock.Anything).Return(video, nil)
+		uploads.On("SelectVideoUpload", mock.Anything, mock.Anything).
+			Return(&model.VideoUpload{ID: "up-1", VideoID: "123", Length: 3}, nil)
+
+		_, err := svc.AppendUpload(ctx, &model.AppendUpload{VideoID: "123", ID: "up-1", Offset: 0, Size: -1, Body: strings.NewReader("hello")})
+		assert.Equal(t, entity.KindTooLarge, entity.KindOf(err))
+		assert.Empty(t, blobs.keys())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		svc, videos, uploads, blobs := newVideoContentService(t, 1<<20)
+		require.NoError(t, blobs.Put(ctx, "uploads/up-1/a", strings.NewReader("hello "), 6))
+		videos.On("Select", mock.Anything, mock.Anything).Return(video, nil)
+		uploads.On("SelectVideoUpload", mock.Anything, mock.Anything).
+			Return(&model.VideoUpload{ID: "up-1", VideoID: "123", Length: 11, Offset: 6,
+				Parts: []model.UploadPart{{UploadID: "up-1", Offset: 0, Size: 6, Key: "uploads/up-1/a"}}}, nil)
+		uploads.On("DeleteVideoUpload", mock.Anything, &model.GetUpload{VideoID: "123", ID: "up-1"}).Return(nil)
+
+		_, err := svc.DeleteUpload(ctx, &model.GetUpload{VideoID: "123", ID: "up-1"})
+		require.NoError(t, err)
+		assert.Empty(t, blobs.keys())
+	})
+}
+
+func TestOpenContent(t *testing.T) {
+	ctx := testContext("user-2", auth.RoleViewer)
+
+	t.Run("Opens", func(t *testing.T) {
+		svc, videos, _, blobs := newVideoContentService(t, 1<<20)
+		require.NoError(t, blobs.Put(ctx, "videos/123/a", strings.NewReader("hello"), 5))
+		videos.On("Select", mock.Anything, mock.Anything).Return(&schema.VideosGetResponse{
+			ID: "123", UpdatedAt: "2024-05-01T10:00:00Z",
+			Content: &schema.VideoContent{Key: "videos/123/a", Size: 5, ContentType: "video/mp4", Checksum: "abc"},
+		}, nil)
+
+		content, err := svc.OpenContent(ctx, &model.GetContent{ID: "123"})
+		require.NoError(t, err)
+		defer content.Body.Close()
+
+		data, err := io.ReadAll(content.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		assert.Equal(t, "video/mp4", content.ContentType)
+		assert.Equal(t, 2024, content.UpdatedAt.Year())
+	})
+
+	t.Run("WithoutContent", func(t *testing.T) {
+		svc, videos, _, _ := newVideoContentService(t, 1<<20)
+		videos.On("Select", mock.Anything, mock.Anything).Return(&schema.VideosGetResponse{ID: "123"}, nil)
+
+		_, err := svc.OpenContent(ctx, &model.GetContent{ID: "123"})
+		assert.Equal(t, entity.KindNotFound, entity.KindOf(err))
+	})
+
+	t.Run("ErrorCase", func(t *testing.T) {
+		svc, videos, _, _ := newVideoContentService(t, 1<<20)
+		videos.On("Select", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
+
+		_, err := svc.OpenContent(ctx, &model.GetContent{ID: "123"})
+		assert.Error(t, err)
+	})
+}
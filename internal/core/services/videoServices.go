@@ -1,113 +1,129 @@
 package service
 
 import (
+	"CrudPlatform/internal/core/domain/auth"
 	"CrudPlatform/internal/core/ports"
-	"net/http"
-	"strconv"
+	"context"
 
 	entity "CrudPlatform/internal/core/domain/repository"
+	modelAudit "CrudPlatform/internal/core/domain/repository/model/audit"
 	model "CrudPlatform/internal/core/domain/repository/model/videos"
-
-	"github.com/gin-gonic/gin"
+	schemaVideos "CrudPlatform/internal/core/domain/repository/schema/videos"
 )
 
-type RepositoryVideo struct {
-	repo ports.DBRepositoryVideo
-}
+// videoIncludes son las relaciones que Select puede expandir con include.
+var videoIncludes = map[string]bool{"user": true, "challenge": true}
 
-func NewServiceVideo(repo ports.DBRepositoryVideo) *RepositoryVideo {
-	return &RepositoryVideo{
-		repo: repo,
-	}
-}
+type RepositoryVideo = Service[model.Videos, model.UpdateVideo, model.ListVideos, schemaVideos.VideosGetResponse, schemaVideos.VideosUpdateResponse]
 
-func (r *RepositoryVideo) CreateVideo(ctx *gin.Context, request *model.Videos) (*entity.Response, error) {
-
-	resp, err := r.repo.CreateVideo(ctx, request)
-	if err != nil {
-		return nil, err
-	}
+// videoRelations resuelve el usuario y el challenge de un video al crearlo y al expandirlo.
+type videoRelations struct {
+	users      ports.DBRepositoryUsers
+	challenges ports.DBRepositoryChallenge
+	policy     ports.AuthorizationPolicy
+}
 
-	return &entity.Response{
-		Data: resp,
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Creado",
-				},
-			},
-			Source: "Create Video",
+func NewServiceVideo(repo ports.DBRepositoryVideo, users ports.DBRepositoryUsers, challenges ports.DBRepositoryChallenge, policy ports.AuthorizationPolicy, audit ports.DBRepositoryAudit) *RepositoryVideo {
+	relations := &videoRelations{users: users, challenges: challenges, policy: policy}
+	return NewCRUDService(repo, policy, audit, Entity[model.Videos, model.UpdateVideo, model.ListVideos, schemaVideos.VideosGetResponse]{
+		Name:      "Video",
+		Plural:    "Videos",
+		AuditType: modelAudit.EntityVideo,
+		Permissions: Permissions{
+			Create:  auth.PermissionVideosCreate,
+			Read:    auth.PermissionVideosRead,
+			Update:  auth.PermissionVideosUpdate,
+			Delete:  auth.PermissionVideosDelete,
+			Restore: auth.PermissionVideosRestore,
 		},
-	}, nil
-
+		Owner:    func(video *schemaVideos.VideosGetResponse) string { return video.CreatedBy },
+		UpdateID: func(request *model.UpdateVideo) string { return request.ID },
+		List: func(request *model.ListVideos) (*entity.PageRequest, *entity.ListFilter) {
+			return &request.PageRequest, &request.ListFilter
+		},
+		Prepare: func(ctx context.Context, request *model.Videos) error {
+			request.CreatedBy = currentUserID(ctx)
+			return relations.assignUploader(ctx, request)
+		},
+		ValidateSelect: validateVideoIncludes,
+		Expand: func(ctx context.Context, video *schemaVideos.VideosGetResponse, request *entity.GetRecord) error {
+			return relations.expand(ctx, video, request.Includes())
+		},
+	})
 }
 
-func (r *RepositoryVideo) SelectVideo(ctx *gin.Context, request *model.GetVideo) (*entity.Response, error) {
-
-	resp, err := r.repo.SelectVideo(ctx, request)
-	if err != nil {
-		return nil, err
+func validateVideoIncludes(request *entity.GetRecord) error {
+	for _, include := range request.Includes() {
+		if !videoIncludes[include] {
+			return &entity.ValidationError{Fields: []entity.FieldError{
+				{Field: "include", Message: "must be a comma separated list of: user, challenge"},
+			}}
+		}
 	}
-
-	return &entity.Response{
-		Data: resp,
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Seleccionado",
-				},
-			},
-			Source: "Select Video",
-		},
-	}, nil
-
+	return nil
 }
 
-func (r *RepositoryVideo) UpdateVideo(ctx *gin.Context, request *model.UpdateVideo) (*entity.Response, error) {
+// assignUploader define el usuario dueño del video. Por defecto es quien lo sube, si es un
+// usuario registrado; publicarlo a nombre de otro usuario exige poder modificar ese usuario.
+func (r *videoRelations) assignUploader(ctx context.Context, request *model.Videos) error {
+	if request.UserID != "" {
+		if request.UserID == request.CreatedBy {
+			return nil
+		}
+		return authorize(ctx, r.policy, auth.PermissionUsersUpdate, request.UserID)
+	}
 
-	resp, err := r.repo.UpdateVideo(ctx, request)
+	if request.CreatedBy == "" {
+		return nil
+	}
+	_, err := r.users.Select(ctx, &entity.GetRecord{ID: request.CreatedBy})
 	if err != nil {
-		return nil, err
+		// Los tokens de proveedores externos pueden no tener un usuario local asociado.
+		if entity.KindOf(err) == entity.KindNotFound {
+			return nil
+		}
+		return err
 	}
-
-	return &entity.Response{
-		Data: resp,
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Actualizado",
-				},
-			},
-			Source: "Update Video",
-		},
-	}, nil
-
+	request.UserID = request.CreatedBy
+	return nil
 }
 
-func (r *RepositoryVideo) DeleteVideo(ctx *gin.Context, request *model.DeleteVideo) (*entity.Response, error) {
-
-	err := r.repo.DeleteVideo(ctx, request)
-	if err != nil {
-		return nil, err
+// expand completa las relaciones pedidas con include, verificando el permiso de lectura de cada una.
+func (r *videoRelations) expand(ctx context.Context, video *schemaVideos.VideosGetResponse, includes []string) error {
+	for _, include := range includes {
+		switch include {
+		case "user":
+			if video.UserID == "" || video.User != nil {
+				continue
+			}
+			if err := authorize(ctx, r.policy, auth.PermissionUsersRead, ""); err != nil {
+				return err
+			}
+			user, err := r.users.Select(ctx, &entity.GetRecord{ID: video.UserID})
+			if err != nil {
+				// Un usuario eliminado no se expande, igual que un video sin usuario.
+				if entity.KindOf(err) == entity.KindNotFound {
+					continue
+				}
+				return err
+			}
+			video.User = user
+		case "challenge":
+			if video.ChallengeID == "" || video.Challenge != nil {
+				continue
+			}
+			if err := authorize(ctx, r.policy, auth.PermissionChallengesRead, ""); err != nil {
+				return err
+			}
+			challenge, err := r.challenges.Select(ctx, &entity.GetRecord{ID: video.ChallengeID})
+			if err != nil {
+				if entity.KindOf(err) == entity.KindNotFound {
+					continue
+				}
+				return err
+			}
+			video.Challenge = challenge
+		}
 	}
-
-	return &entity.Response{
-		Result: entity.Result{
-			Details: []entity.Detail{
-				{
-					InternalCode: strconv.Itoa(http.StatusOK),
-					Message:      http.StatusText(http.StatusOK),
-					Detail:       "Registro Eliminado",
-				},
-			},
-			Source: "Delete Challenge",
-		},
-	}, nil
-
+	return nil
 }
@@ -2,32 +2,37 @@ package service
 
 import (
 	"errors"
-	"net/http/httptest"
 	"testing"
 
+	"CrudPlatform/internal/core/domain/auth"
 	entity "CrudPlatform/internal/core/domain/repository"
 	model "CrudPlatform/internal/core/domain/repository/model/videos"
+	schemaChallenges "CrudPlatform/internal/core/domain/repository/schema/challenges"
+	schemaUsers "CrudPlatform/internal/core/domain/repository/schema/users"
 	schema "CrudPlatform/internal/core/domain/repository/schema/videos"
 	mockRepository "CrudPlatform/internal/core/ports/mocks"
 
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestNewServiceVideo(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryVideo(t)
-	service := NewServiceVideo(mockRepo)
+	service := NewServiceVideo(mockRepo, mockRepository.NewDBRepositoryUsers(t), mockRepository.NewDBRepositoryChallenge(t), auth.NewPolicy(), mockRepository.NewDBRepositoryAudit(t))
 	assert.NotNil(t, service, "El servicio no debe ser nil")
 }
 
 func TestCreateVideo(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryVideo(t)
-	svc := &RepositoryVideo{repo: mockRepo}
+	mockUsers := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewServiceVideo(mockRepo, mockUsers, nil, auth.NewPolicy(), nil)
 
-	mockRepo.On("CreateVideo", mock.Anything, mock.Anything).Return("123", nil)
+	mockUsers.On("Select", mock.Anything, mock.Anything).Return(&schemaUsers.UsersGetResponse{ID: "user-1"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(request *model.Videos) bool {
+		return request.UserID == "user-1" && request.CreatedBy == "user-1"
+	})).Return("123", nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c := testContext("user-1", auth.RoleAdmin)
 	req := &model.Videos{
 		Title:       "Test Video",
 		Description: "Test Description",
@@ -43,40 +48,91 @@ func TestCreateVideo(t *testing.T) {
 		},
 	}
 
-	response, err := svc.CreateVideo(c, req)
+	response, err := svc.Create(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestCreateVideo_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryVideo(t)
-	svc := &RepositoryVideo{repo: mockRepo}
+	mockUsers := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewServiceVideo(mockRepo, mockUsers, nil, auth.NewPolicy(), nil)
 
-	mockRepo.On("CreateVideo", mock.Anything, mock.Anything).Return("", errors.New("error simulado"))
+	mockUsers.On("Select", mock.Anything, mock.Anything).Return(&schemaUsers.UsersGetResponse{ID: "user-1"}, nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return("", errors.New("error simulado"))
+
+	c := testContext("user-1", auth.RoleAdmin)
 	req := &model.Videos{
 		Title:       "Test Video",
 		Description: "Test Description",
 	}
 
-	response, err := svc.CreateVideo(c, req)
+	response, err := svc.Create(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
 
+func TestCreateVideo_SubjectWithoutLocalUser(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryVideo(t)
+	mockUsers := mockRepository.NewDBRepositoryUsers(t)
+	svc := NewServiceVideo(mockRepo, mockUsers, nil, auth.NewPolicy(), nil)
+
+	mockUsers.On("Select", mock.Anything, mock.Anything).Return(nil, entity.NewError(entity.KindNotFound, "user with id external-1 not found"))
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(request *model.Videos) bool {
+		return request.UserID == "" && request.CreatedBy == "external-1"
+	})).Return("123", nil)
+
+	_, err := svc.Create(testContext("external-1", auth.RoleCreator), &model.Videos{Title: "Test Video"})
+	assert.NoError(t, err)
+}
+
+func TestCreateVideo_OnBehalfOfOtherUserForbidden(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryVideo(t)
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
+
+	_, err := svc.Create(testContext("creator-1", auth.RoleCreator), &model.Videos{Title: "Test Video", UserID: "creator-2"})
+	assertForbidden(t, err, auth.PermissionUsersUpdate)
+}
+
+func TestSelectVideo_Include(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryVideo(t)
+	mockUsers := mockRepository.NewDBRepositoryUsers(t)
+	mockChallenges := mockRepository.NewDBRepositoryChallenge(t)
+	svc := NewServiceVideo(mockRepo, mockUsers, mockChallenges, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schema.VideosGetResponse{ID: "123", UserID: "user-1", ChallengeID: "challenge-1"}, nil)
+	mockUsers.On("Select", mock.Anything, mock.Anything).Return(&schemaUsers.UsersGetResponse{ID: "user-1", Name: "John Doe"}, nil)
+	mockChallenges.On("Select", mock.Anything, mock.Anything).Return(&schemaChallenges.ChallengeGetResponse{ID: "challenge-1", Title: "Pitch"}, nil)
+
+	response, err := svc.Select(testContext("viewer-1", auth.RoleViewer), &entity.GetRecord{ID: "123", Include: "user, challenge"})
+	assert.NoError(t, err)
+
+	video := response.Data.(*schema.VideosGetResponse)
+	assert.Equal(t, "John Doe", video.User.Name)
+	assert.Equal(t, "Pitch", video.Challenge.Title)
+}
+
+func TestSelectVideo_UnknownInclude(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryVideo(t)
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
+
+	_, err := svc.Select(testContext("viewer-1", auth.RoleViewer), &entity.GetRecord{ID: "123", Include: "comments"})
+	assert.Equal(t, entity.KindValidation, entity.KindOf(err))
+}
+
 func TestSelectVideo(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryVideo(t)
-	svc := &RepositoryVideo{repo: mockRepo}
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
 
 	mockResponse := &schema.VideosGetResponse{
 		Title:       "Test Video",
 		Description: "Test Description",
 	}
-	mockRepo.On("SelectVideo", mock.Anything, mock.Anything).Return(mockResponse, nil)
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(mockResponse, nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.GetVideo{ID: "123"}
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &entity.GetRecord{ID: "123"}
 
 	expectedResp := &entity.Response{
 		Data: mockResponse,
@@ -88,41 +144,42 @@ func TestSelectVideo(t *testing.T) {
 		},
 	}
 
-	response, err := svc.SelectVideo(c, req)
+	response, err := svc.Select(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestSelectVideo_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryVideo(t)
-	svc := &RepositoryVideo{repo: mockRepo}
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
 
-	mockRepo.On("SelectVideo", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.GetVideo{ID: "123"}
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &entity.GetRecord{ID: "123"}
 
-	response, err := svc.SelectVideo(c, req)
+	response, err := svc.Select(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
 
 func TestUpdateVideo(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryVideo(t)
-	svc := &RepositoryVideo{repo: mockRepo}
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schema.VideosGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
 
 	mockResponse := &schema.VideosUpdateResponse{
 		Title:       "Updated Video",
 		Description: "Updated Description",
 	}
-	mockRepo.On("UpdateVideo", mock.Anything, mock.Anything).Return(mockResponse, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(mockResponse, nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.UpdateVideo{
-		ID:          "123",
+	c := testContext("user-1", auth.RoleCreator)
+	req := model.ReplaceVideo{
 		Title:       "Updated Video",
 		Description: "Updated Description",
-	}
+	}.Update("123")
 
 	expectedResp := &entity.Response{
 		Data: mockResponse,
@@ -134,62 +191,116 @@ func TestUpdateVideo(t *testing.T) {
 		},
 	}
 
-	response, err := svc.UpdateVideo(c, req)
+	response, err := svc.Update(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestUpdateVideo_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryVideo(t)
-	svc := &RepositoryVideo{repo: mockRepo}
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schema.VideosGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
 
-	mockRepo.On("UpdateVideo", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.UpdateVideo{
-		ID:          "123",
+	c := testContext("user-1", auth.RoleCreator)
+	req := model.ReplaceVideo{
 		Title:       "Updated Video",
 		Description: "Updated Description",
-	}
+	}.Update("123")
 
-	response, err := svc.UpdateVideo(c, req)
+	response, err := svc.Update(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
 
 func TestDeleteVideo(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryVideo(t)
-	svc := &RepositoryVideo{repo: mockRepo}
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schema.VideosGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
 
-	mockRepo.On("DeleteVideo", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.DeleteVideo{ID: "123"}
+	c := testContext("user-1", auth.RoleCreator)
+	req := &entity.DeleteRecord{ID: "123"}
 
 	expectedResp := &entity.Response{
 		Result: entity.Result{
 			Details: []entity.Detail{
 				{InternalCode: "200", Message: "OK", Detail: "Registro Eliminado"},
 			},
-			Source: "Delete Challenge",
+			Source: "Delete Video",
 		},
 	}
 
-	response, err := svc.DeleteVideo(c, req)
+	response, err := svc.Delete(c, req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResp, response)
 }
 
 func TestDeleteVideo_ErrorCase(t *testing.T) {
 	mockRepo := mockRepository.NewDBRepositoryVideo(t)
-	svc := &RepositoryVideo{repo: mockRepo}
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
+
+	mockRepo.On("Select", mock.Anything, mock.Anything).Return(&schema.VideosGetResponse{ID: "123", CreatedBy: "user-1"}, nil)
+
+	mockRepo.On("Delete", mock.Anything, mock.Anything).Return(errors.New("error simulado"))
+
+	c := testContext("user-1", auth.RoleCreator)
+	req := &entity.DeleteRecord{ID: "123"}
+
+	response, err := svc.Delete(c, req)
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestListVideos(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryVideo(t)
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
+
+	mockPage := &entity.Page[schema.VideosGetResponse]{
+		Items:      []schema.VideosGetResponse{{ID: "123", Title: "Test Video"}},
+		Total:      11,
+		NextCursor: "next",
+	}
+	mockRepo.On("List", mock.Anything, mock.Anything).Return(mockPage, nil)
+
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &model.ListVideos{PageRequest: entity.PageRequest{PageSize: 50}}
+
+	expectedResp := &entity.ResponseWithList{
+		Data: []interface{}{mockPage.Items[0]},
+		Pagination: &entity.Pagination{
+			Total:      11,
+			Page:       1,
+			PageSize:   entity.MaxPageSize,
+			NextCursor: "next",
+		},
+		Result: entity.Result{
+			Details: []entity.Detail{
+				{InternalCode: "200", Message: "OK", Detail: "Registros Listados"},
+			},
+			Source: "List Videos",
+		},
+	}
+
+	response, err := svc.List(c, req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResp, response)
+}
+
+func TestListVideos_ErrorCase(t *testing.T) {
+	mockRepo := mockRepository.NewDBRepositoryVideo(t)
+	svc := NewServiceVideo(mockRepo, nil, nil, auth.NewPolicy(), nil)
 
-	mockRepo.On("DeleteVideo", mock.Anything, mock.Anything).Return(errors.New("error simulado"))
+	mockRepo.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("error simulado"))
 
-	c, _ := gin.CreateTestContext(httptest.NewRecorder())
-	req := &model.DeleteVideo{ID: "123"}
+	c := testContext("user-1", auth.RoleAdmin)
+	req := &model.ListVideos{}
 
-	response, err := svc.DeleteVideo(c, req)
+	response, err := svc.List(c, req)
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
@@ -1,16 +1,92 @@
 package main
 
 import (
+	"CrudPlatform/cmd/config"
 	"CrudPlatform/cmd/config/db"
 	"CrudPlatform/internal/adapters/handlers/http"
-	"log"
+	"CrudPlatform/internal/adapters/repository"
+	"CrudPlatform/internal/adapters/storage"
+	"CrudPlatform/internal/core/domain/health"
+	"CrudPlatform/internal/core/domain/logging"
+	services "CrudPlatform/internal/core/services"
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 )
 
 func main() {
-	dbInstance, err := db.NewPostgreSQLDB()
+	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
-		log.Fatal("Error opening database:", err)
+		fatal("Error loading configuration", err)
 	}
 
-	http.RunServer(dbInstance)
+	logger := logging.New(os.Stdout, cfg.Log.Format, cfg.Log.SlogLevel())
+	slog.SetDefault(logger)
+
+	dbInstance, err := db.Open(cfg.Database)
+	if err != nil {
+		fatal("Error opening database", err)
+	}
+
+	migrator, err := db.NewMigrator(dbInstance, cfg.Database.Driver)
+	if err != nil {
+		fatal("Error loading migrations", err)
+	}
+
+	if len(cfg.Args) > 0 && cfg.Args[0] == "migrate" {
+		if err := runMigrate(context.Background(), migrator, cfg.Args[1:], os.Stdout); err != nil {
+			fatal("Error running migrations", err)
+		}
+		return
+	}
+
+	if err := migrator.Up(context.Background()); err != nil {
+		fatal("Error applying migrations", err)
+	}
+
+	// SIGTERM, que Kubernetes envía antes de matar el pod, o Ctrl-C apagan el servidor ordenadamente.
+	// Una segunda señal ya no espera: restaura el comportamiento por defecto y termina el proceso.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	// Los registros eliminados, con sus archivos, y los uploads abandonados se purgan en segundo
+	// plano mientras el servidor atiende requests.
+	videos := repository.NewBdRepositoryVideo(dbInstance, cfg.Database.QueryTimeout)
+	purge := services.NewPurgeJob(cfg.Purge.Retention, cfg.Purge.UploadTTL, cfg.Purge.Interval, storage.New(cfg.Storage), videos,
+		videos,
+		repository.NewBdRepositoryChallenge(dbInstance, cfg.Database.QueryTimeout),
+		repository.NewBdRepository(dbInstance, cfg.Database.QueryTimeout),
+	)
+	workers, stopWorkers := context.WithCancel(logging.With(context.Background(), logger.With("job", "purge")))
+	var running sync.WaitGroup
+	running.Go(func() { purge.Run(workers) })
+
+	// /readyz falla si la base de datos no responde o si falta aplicar alguna migración.
+	probes := services.NewServiceHealth(cfg.Server.HealthTimeout,
+		health.Check{Name: "database", Run: dbInstance.PingContext},
+		health.Check{Name: "migrations", Run: migrator.CheckApplied},
+	)
+
+	serverErr := http.RunServer(ctx, dbInstance, cfg, probes)
+
+	// Los workers se detienen después de los requests, y la base de datos se cierra al final.
+	stopWorkers()
+	running.Wait()
+	if err := dbInstance.Close(); err != nil {
+		slog.Error("Error closing database", "error", err)
+	}
+	if serverErr != nil {
+		fatal("Server stopped", serverErr)
+	}
+	slog.Info("server stopped")
+}
+
+// fatal registra el error con el logger por defecto y termina el proceso.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"CrudPlatform/cmd/config/db"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const migrateUsage = "usage: migrate up|down|status|to N"
+
+// runMigrate ejecuta el subcomando migrate sobre la base de datos indicada.
+func runMigrate(ctx context.Context, migrator *db.Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(ctx); err != nil {
+			return err
+		}
+	case "down":
+		if err := migrator.Down(ctx); err != nil {
+			return err
+		}
+	case "to":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid migration version %q", args[1])
+		}
+		if err := migrator.To(ctx, version); err != nil {
+			return err
+		}
+	case "status":
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range status {
+		appliedAt := "pending"
+		if item.Applied {
+			appliedAt = item.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(out, "%04d_%s\t%s\n", item.Version, item.Name, appliedAt)
+	}
+
+	return nil
+}